@@ -2,31 +2,311 @@ package alertmanager
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"github.com/go-logr/logr"
+	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-multierror"
+	"github.com/openshift/managed-upgrade-operator/config"
 	amSilence "github.com/prometheus/alertmanager/api/v2/client/silence"
 	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
 	"net/http"
+	"net/url"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sort"
+	"sync"
+	"time"
 )
 
+const defaultMaxConcurrency = 4
+
+// SilenceSpec describes a silence to be created
+type SilenceSpec struct {
+	Matchers  amv2Models.Matchers
+	StartsAt  strfmt.DateTime
+	EndsAt    strfmt.DateTime
+	CreatedBy string
+	Comment   string
+}
+
 //go:generate mockgen -destination=mocks/alertManagerSilenceClient.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/alertmanager AlertManagerSilencer
 type AlertManagerSilencer interface {
-	Create(matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) error
-	List(filter []string) (*amSilence.GetSilencesOK, error)
-	Delete(id string) error
-	Update(id string, endsAt strfmt.DateTime) error
-	Filter(predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error)
+	Create(ctx context.Context, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) error
+	List(ctx context.Context, filter []string) (*amSilence.GetSilencesOK, error)
+	ListSilences(ctx context.Context, filter []string) ([]*amv2Models.GettableSilence, error)
+	ListByMatchers(ctx context.Context, matchers amv2Models.Matchers) ([]*amv2Models.GettableSilence, error)
+	GetByID(ctx context.Context, id string) (*amv2Models.GettableSilence, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, id string, endsAt strfmt.DateTime) error
+	CreateBatch(ctx context.Context, specs []SilenceSpec) ([]string, error)
+	CreateWithID(ctx context.Context, id string, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) (string, error)
+	CreateOrUpdate(ctx context.Context, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) (string, error)
+	Filter(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error)
+	FilterDistinct(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error)
+	FilterActive(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error)
+	FilterPending(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error)
+	DeleteByFilter(ctx context.Context, predicates ...SilencePredicate) (int, error)
+	Count(ctx context.Context, predicates ...SilencePredicate) (int, error)
+	Exists(ctx context.Context, matchers amv2Models.Matchers, comment string) (bool, string, error)
+	Healthy(ctx context.Context) error
 }
 
 type AlertManagerSilenceClient struct {
 	Transport *httptransport.Runtime
+	// TLSConfig is used when connecting to Alertmanager, allowing the service CA bundle
+	// to be supplied. When unset the system defaults are used.
+	TLSConfig *tls.Config
+	// Proxy selects the proxy for each request. When unset the HTTP_PROXY, HTTPS_PROXY and
+	// NO_PROXY environment variables are used.
+	Proxy func(*http.Request) (*url.URL, error)
+	// HTTPClient, when set, is used for all requests in place of the client built from TLSConfig,
+	// Proxy and RedirectPolicy
+	HTTPClient *http.Client
+	// RedirectPolicy decides whether redirects from Alertmanager, such as an HA member pointing
+	// at another, are followed. DefaultRedirectPolicy is used when unset.
+	RedirectPolicy RedirectPolicy
+	// BearerToken is sent as an Authorization header on every request
+	BearerToken string
+	// TokenSource, when set, is consulted on every request for the bearer token so
+	// that rotated tokens are picked up. It takes precedence over BearerToken.
+	TokenSource func() (string, error)
+	// RetryPolicy applied to Create, Delete and Update. DefaultRetryPolicy is used when unset.
+	RetryPolicy *RetryPolicy
+	// Metrics records silence operations. The operator's Prometheus registry is used when unset.
+	Metrics SilenceMetrics
+	// Logger receives debug logs of silence changes. Nothing is logged when unset.
+	Logger logr.Logger
+	// RequestTimeout bounds each request made to Alertmanager, in addition to any deadline
+	// on the caller's context. Zero means no additional timeout.
+	RequestTimeout time.Duration
+	// MaxConcurrency bounds the number of silences CreateBatch creates at once
+	MaxConcurrency int
+	// Creator is recorded as the creator of silences created without one.
+	// config.OperatorName is used when unset.
+	Creator string
+	// Formats is the strfmt registry handed to the generated silence client. strfmt.Default is used when unset.
+	Formats strfmt.Registry
+
+	clientOnce sync.Once
+	client     *http.Client
+}
+
+// requestContext derives the context for a single request, applying RequestTimeout if set.
+// The earlier of the caller's deadline and the timeout wins.
+func (ams *AlertManagerSilenceClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ams.RequestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, ams.RequestTimeout)
+}
+
+// httpClient returns the HTTP client shared by all requests, building it on first use
+func (ams *AlertManagerSilenceClient) httpClient() *http.Client {
+	ams.clientOnce.Do(func() {
+		if ams.HTTPClient != nil {
+			ams.client = ams.HTTPClient
+			return
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		// Route through the cluster-wide proxy, honouring NO_PROXY for in-cluster addresses
+		transport.Proxy = http.ProxyFromEnvironment
+		if ams.Proxy != nil {
+			transport.Proxy = ams.Proxy
+		}
+		if ams.TLSConfig != nil {
+			transport.TLSClientConfig = ams.TLSConfig
+		}
+		policy := ams.redirectPolicy()
+		ams.client = &http.Client{
+			Transport:     &redirectTransport{next: transport, policy: policy},
+			CheckRedirect: policy,
+		}
+	})
+	return ams.client
 }
 
 // Creates a silence in Alertmanager instance defined in Transport
-func (ams *AlertManagerSilenceClient) Create(matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) error {
+func (ams *AlertManagerSilenceClient) Create(ctx context.Context, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) (err error) {
+	defer ams.observe(operationCreate, time.Now(), &err)
+
+	_, err = ams.create(ctx, SilenceSpec{
+		Matchers:  matchers,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedBy: creator,
+		Comment:   comment,
+	})
+	return err
+}
+
+// create validates and creates the silence described by spec, returning its id
+func (ams *AlertManagerSilenceClient) create(ctx context.Context, spec SilenceSpec) (string, error) {
+	spec.CreatedBy = ams.creator(spec.CreatedBy)
+	if err := validateMatchers(spec.Matchers); err != nil {
+		return "", &silenceError{kind: ErrInvalidSilence, err: err}
+	}
+
+	id, err := ams.post(ctx, &amv2Models.PostableSilence{
+		Silence: amv2Models.Silence{
+			CreatedBy: &spec.CreatedBy,
+			Comment:   &spec.Comment,
+			EndsAt:    &spec.EndsAt,
+			StartsAt:  &spec.StartsAt,
+			Matchers:  spec.Matchers,
+		},
+	})
+	if err != nil {
+		ams.logger().Error(err, "Failed to create silence", "comment", spec.Comment, "matchers", formatMatchers(spec.Matchers))
+		return "", err
+	}
+
+	ams.logger().V(1).Info("Created silence", "id", id, "comment", spec.Comment, "matchers", formatMatchers(spec.Matchers))
+	return id, nil
+}
+
+// CreateBatch creates the silences concurrently, bounded by MaxConcurrency. The returned ids are
+// in the same order as specs, with an empty id for each silence that could not be created, so
+// that the caller can clean up after a partial failure.
+func (ams *AlertManagerSilenceClient) CreateBatch(ctx context.Context, specs []SilenceSpec) ([]string, error) {
+	ids := make([]string, len(specs))
+	errs := make([]error, len(specs))
+	sem := make(chan struct{}, ams.maxConcurrency())
+
+	var wg sync.WaitGroup
+	for i := range specs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var err error
+			start := time.Now()
+			ids[i], err = ams.create(ctx, specs[i])
+			ams.observe(operationCreate, start, &err)
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	var createErrors *multierror.Error
+	for i, err := range errs {
+		if err != nil {
+			createErrors = multierror.Append(createErrors, fmt.Errorf("unable to create silence %q: %w", specs[i].Comment, err))
+		}
+	}
+	return ids, createErrors.ErrorOrNil()
+}
+
+func (ams *AlertManagerSilenceClient) maxConcurrency() int {
+	if ams.MaxConcurrency > 0 {
+		return ams.MaxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// CreateWithID creates or updates the silence with the supplied id, returning the id of the
+// resulting silence. Supplying an id that Alertmanager does not know creates a new silence,
+// which will be given a new id.
+func (ams *AlertManagerSilenceClient) CreateWithID(ctx context.Context, id string, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) (newID string, err error) {
+	defer ams.observe(operationCreate, time.Now(), &err)
+
+	creator = ams.creator(creator)
+	if err := validateMatchers(matchers); err != nil {
+		return "", &silenceError{kind: ErrInvalidSilence, err: err}
+	}
+
+	silence := &amv2Models.PostableSilence{
+		ID: id,
+		Silence: amv2Models.Silence{
+			CreatedBy: &creator,
+			Comment:   &comment,
+			EndsAt:    &endsAt,
+			StartsAt:  &startsAt,
+			Matchers:  matchers,
+		},
+	}
+	if id != "" {
+		_, err = ams.GetByID(ctx, id)
+		if errors.Is(err, ErrSilenceNotFound) {
+			silence.ID = ""
+		} else if err != nil {
+			return "", err
+		}
+	}
+
+	newID, err = ams.post(ctx, silence)
+	if err != nil {
+		ams.logger().Error(err, "Failed to create silence", "id", id, "comment", comment, "matchers", formatMatchers(matchers))
+		return "", err
+	}
+
+	ams.logger().V(1).Info("Created silence", "id", newID, "requestedID", id, "comment", comment, "matchers", formatMatchers(matchers))
+	return newID, nil
+}
+
+// creator returns the supplied creator, falling back to the client's default when it is empty
+func (ams *AlertManagerSilenceClient) creator(creator string) string {
+	if creator != "" {
+		return creator
+	}
+	if ams.Creator != "" {
+		return ams.Creator
+	}
+	return config.OperatorName
+}
+
+func (ams *AlertManagerSilenceClient) logger() logr.Logger {
+	if ams.Logger == nil {
+		return logf.NullLogger{}
+	}
+	return ams.Logger
+}
+
+// post sends the silence to Alertmanager and returns the id it was stored under
+func (ams *AlertManagerSilenceClient) post(ctx context.Context, silence *amv2Models.PostableSilence) (string, error) {
 	pParams := &amSilence.PostSilencesParams{
-		Silence: &amv2Models.PostableSilence{
+		Silence:    silence,
+		HTTPClient: ams.httpClient(),
+	}
+
+	silenceClient := ams.silenceClient()
+	var result *amSilence.PostSilencesOK
+	err := ams.retryPolicy().do(ctx, func() error {
+		reqCtx, cancel := ams.requestContext(ctx)
+		defer cancel()
+		pParams.Context = reqCtx
+		var err error
+		result, err = silenceClient.PostSilences(pParams)
+		return err
+	})
+	if err != nil {
+		return "", wrapError(err)
+	}
+
+	return result.Payload.SilenceID, nil
+}
+
+// CreateOrUpdate ensures a single active silence exists for the creator and comment, extending
+// an existing one rather than creating a duplicate. Where several match, the most recently
+// updated is kept and the others are removed. The id of the resulting silence is returned.
+func (ams *AlertManagerSilenceClient) CreateOrUpdate(ctx context.Context, matchers amv2Models.Matchers, startsAt strfmt.DateTime, endsAt strfmt.DateTime, creator string, comment string) (string, error) {
+	creator = ams.creator(creator)
+	existing, err := ams.Filter(ctx, IsActive(), CreatedBy(creator), func(s *amv2Models.GettableSilence) bool {
+		return s.Comment != nil && *s.Comment == comment
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(*existing) == 0 {
+		if err := validateMatchers(matchers); err != nil {
+			return "", &silenceError{kind: ErrInvalidSilence, err: err}
+		}
+		return ams.post(ctx, &amv2Models.PostableSilence{
 			Silence: amv2Models.Silence{
 				CreatedBy: &creator,
 				Comment:   &comment,
@@ -34,106 +314,429 @@ func (ams *AlertManagerSilenceClient) Create(matchers amv2Models.Matchers, start
 				StartsAt:  &startsAt,
 				Matchers:  matchers,
 			},
-		},
-		Context:    context.TODO(),
-		HTTPClient: &http.Client{},
+		})
 	}
 
-	silenceClient := amSilence.New(ams.Transport, strfmt.Default)
-	_, err := silenceClient.PostSilences(pParams)
-	if err != nil {
-		return err
+	silences := *existing
+	sort.SliceStable(silences, func(i, j int) bool {
+		return updatedAt(&silences[i]).After(updatedAt(&silences[j]))
+	})
+	for _, s := range silences[1:] {
+		err = ams.Delete(ctx, *s.ID)
+		if err != nil {
+			return "", fmt.Errorf("unable to remove duplicate silence %s: %w", *s.ID, err)
+		}
 	}
 
-	return nil
+	newest := silences[0]
+	if newest.EndsAt != nil && time.Time(*newest.EndsAt).Equal(time.Time(endsAt)) {
+		return *newest.ID, nil
+	}
+	return ams.update(ctx, *newest.ID, endsAt)
 }
 
-// list silences in Alertmanager instance defined in Transport
-func (ams *AlertManagerSilenceClient) List(filter []string) (*amSilence.GetSilencesOK, error) {
+func updatedAt(s *amv2Models.GettableSilence) time.Time {
+	if s.UpdatedAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*s.UpdatedAt)
+}
+
+// list silences in Alertmanager instance defined in Transport.
+// Prefer ListSilences, which returns the silences without the generated response wrapper.
+func (ams *AlertManagerSilenceClient) List(ctx context.Context, filter []string) (_ *amSilence.GetSilencesOK, err error) {
+	defer ams.observe(operationList, time.Now(), &err)
+
+	reqCtx, cancel := ams.requestContext(ctx)
+	defer cancel()
 	gParams := &amSilence.GetSilencesParams{
 		Filter:     filter,
-		Context:    context.TODO(),
-		HTTPClient: &http.Client{},
+		Context:    reqCtx,
+		HTTPClient: ams.httpClient(),
 	}
 
-	silenceClient := amSilence.New(ams.Transport, strfmt.Default)
+	silenceClient := ams.silenceClient()
 	results, err := silenceClient.GetSilences(gParams)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return results, nil
 }
 
-// Delete silence in Alertmanager instance defined in Transport
-func (ams *AlertManagerSilenceClient) Delete(id string) error {
-	dParams := &amSilence.DeleteSilenceParams{
-		SilenceID:  strfmt.UUID(id),
-		Context:    context.TODO(),
-		HTTPClient: &http.Client{},
+// ListSilences returns the silences in the Alertmanager instance defined in Transport
+// matching the supplied Alertmanager filter expressions
+func (ams *AlertManagerSilenceClient) ListSilences(ctx context.Context, filter []string) ([]*amv2Models.GettableSilence, error) {
+	results, err := ams.List(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	silenceClient := amSilence.New(ams.Transport, strfmt.Default)
-	_, err := silenceClient.DeleteSilence(dParams)
+	return results.Payload, nil
+}
+
+// ListByMatchers returns the silences in the Alertmanager instance defined in Transport
+// whose matchers include all of the supplied matchers
+func (ams *AlertManagerSilenceClient) ListByMatchers(ctx context.Context, matchers amv2Models.Matchers) ([]*amv2Models.GettableSilence, error) {
+	filter, err := filterExpressions(matchers)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return ams.ListSilences(ctx, filter)
+}
+
+// Healthy returns an error if the Alertmanager instance defined in Transport can not be reached
+// or will not list silences
+func (ams *AlertManagerSilenceClient) Healthy(ctx context.Context) error {
+	_, err := ams.List(ctx, []string{})
+	if err != nil {
+		return fmt.Errorf("alertmanager silence API is not available: %w", err)
+	}
 	return nil
 }
 
-// Update silence end time in AlertManager instance defined in Transport
-func (ams *AlertManagerSilenceClient) Update(id string, endsAt strfmt.DateTime) error {
-	silenceClient := amSilence.New(ams.Transport, strfmt.Default)
+// GetByID returns the silence with the supplied id from the Alertmanager instance defined in Transport
+func (ams *AlertManagerSilenceClient) GetByID(ctx context.Context, id string) (*amv2Models.GettableSilence, error) {
 	gParams := &amSilence.GetSilenceParams{
 		SilenceID:  strfmt.UUID(id),
-		Context:    context.TODO(),
-		HTTPClient: &http.Client{},
+		HTTPClient: ams.httpClient(),
 	}
-	result, err := silenceClient.GetSilence(gParams)
+
+	silenceClient := ams.silenceClient()
+	var result *amSilence.GetSilenceOK
+	err := ams.retryPolicy().do(ctx, func() error {
+		reqCtx, cancel := ams.requestContext(ctx)
+		defer cancel()
+		gParams.Context = reqCtx
+		var err error
+		result, err = silenceClient.GetSilence(gParams)
+		return err
+	})
 	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result.Payload, nil
+}
+
+// Delete silence in Alertmanager instance defined in Transport.
+// Deleting a silence that does not exist is not an error.
+func (ams *AlertManagerSilenceClient) Delete(ctx context.Context, id string) (err error) {
+	defer ams.observe(operationDelete, time.Now(), &err)
+
+	dParams := &amSilence.DeleteSilenceParams{
+		SilenceID:  strfmt.UUID(id),
+		HTTPClient: ams.httpClient(),
+	}
+
+	silenceClient := ams.silenceClient()
+	err = ams.retryPolicy().do(ctx, func() error {
+		reqCtx, cancel := ams.requestContext(ctx)
+		defer cancel()
+		dParams.Context = reqCtx
+		_, err := silenceClient.DeleteSilence(dParams)
 		return err
+	})
+	// The silence is already gone, which is the outcome the caller wanted
+	if isNotFound(err) {
+		ams.logger().V(1).Info("Silence to delete was not found", "id", id)
+		return nil
+	}
+	if err != nil {
+		ams.logger().Error(err, "Failed to delete silence", "id", id)
+		return wrapError(err)
+	}
+
+	ams.logger().V(1).Info("Deleted silence", "id", id)
+	return nil
+}
+
+// Update silence end time in AlertManager instance defined in Transport.
+// The silence keeps its id unless it has already expired, in which case a new silence is created.
+func (ams *AlertManagerSilenceClient) Update(ctx context.Context, id string, endsAt strfmt.DateTime) (err error) {
+	defer ams.observe(operationUpdate, time.Now(), &err)
+
+	newID, err := ams.update(ctx, id, endsAt)
+	if err != nil {
+		ams.logger().Error(err, "Failed to update silence", "id", id)
+		return err
+	}
+
+	ams.logger().V(1).Info("Updated silence", "id", id, "newID", newID, "endsAt", endsAt.String())
+	return nil
+}
+
+// update sets the end time of the silence, returning the id of the resulting silence
+func (ams *AlertManagerSilenceClient) update(ctx context.Context, id string, endsAt strfmt.DateTime) (string, error) {
+	silence, err := ams.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if err := checkUpdatable(silence); err != nil {
+		return "", fmt.Errorf("unable to update silence %s: %v", id, err)
+	}
+
+	// Alertmanager will not update an expired silence, so create a fresh one in its place
+	if *silence.Status.State == amv2Models.SilenceStatusStateExpired {
+		fresh := silence.Silence
+		fresh.EndsAt = &endsAt
+		return ams.post(ctx, &amv2Models.PostableSilence{Silence: fresh})
 	}
 
+	// Supplying the id updates the existing silence in place, keeping the id stable
+	updated := &amv2Models.PostableSilence{ID: id, Silence: silence.Silence}
+	updated.EndsAt = &endsAt
+	newID, err := ams.post(ctx, updated)
+	if err == nil {
+		return newID, nil
+	}
+	if !errors.Is(err, ErrInvalidSilence) {
+		return "", err
+	}
+
+	// The in place update was rejected, so replace the silence instead
+	return ams.replace(ctx, silence, endsAt)
+}
+
+// checkUpdatable ensures the fields read when updating a silence are populated
+func checkUpdatable(silence *amv2Models.GettableSilence) error {
+	switch {
+	case silence == nil:
+		return fmt.Errorf("silence is empty")
+	case silence.ID == nil:
+		return fmt.Errorf("silence has no id")
+	case silence.Status == nil || silence.Status.State == nil:
+		return fmt.Errorf("silence has no status")
+	case silence.StartsAt == nil:
+		return fmt.Errorf("silence has no start time")
+	case silence.CreatedBy == nil:
+		return fmt.Errorf("silence has no creator")
+	case silence.Comment == nil:
+		return fmt.Errorf("silence has no comment")
+	}
+	return nil
+}
+
+// replace creates a copy of the silence ending at endsAt and removes the original. If the
+// original can not be removed the copy is rolled back so that the original survives unchanged.
+func (ams *AlertManagerSilenceClient) replace(ctx context.Context, silence *amv2Models.GettableSilence, endsAt strfmt.DateTime) (string, error) {
 	// Create a new silence first
-	err = ams.Create(result.Payload.Matchers, *result.Payload.StartsAt, endsAt, *result.Payload.CreatedBy, *result.Payload.Comment)
+	replacement := silence.Silence
+	replacement.EndsAt = &endsAt
+	newID, err := ams.post(ctx, &amv2Models.PostableSilence{Silence: replacement})
 	if err != nil {
-		return fmt.Errorf("unable to create replacement silence: %v", err)
+		return "", fmt.Errorf("unable to create replacement silence: %w", err)
 	}
 
 	// Remove the old silence if it's still active
-	if *result.Payload.Status.State == amv2Models.SilenceStatusStateActive {
-		err = ams.Delete(*result.Payload.ID)
+	if *silence.Status.State == amv2Models.SilenceStatusStateActive {
+		err = ams.Delete(ctx, *silence.ID)
 		if err != nil {
-			return fmt.Errorf("unable to remove replaced silence: %v", err)
+			if rollbackErr := ams.Delete(ctx, newID); rollbackErr != nil {
+				return "", fmt.Errorf("unable to remove replaced silence: %v, and unable to remove replacement silence %s: %v", err, newID, rollbackErr)
+			}
+			return "", fmt.Errorf("unable to remove replaced silence: %w", err)
 		}
 	}
 
-	return nil
+	return newID, nil
+}
+
+// authenticatedTransport attaches an auth info writer to every operation submitted through it
+type authenticatedTransport struct {
+	runtime.ClientTransport
+	authInfo runtime.ClientAuthInfoWriter
+}
+
+func (t *authenticatedTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	operation.AuthInfo = t.authInfo
+	return t.ClientTransport.Submit(operation)
+}
+
+// bearerTokenAuth returns an auth info writer setting the bearer token, or nil if no token is configured
+func (ams *AlertManagerSilenceClient) bearerTokenAuth() runtime.ClientAuthInfoWriter {
+	if ams.TokenSource == nil && ams.BearerToken == "" {
+		return nil
+	}
+	return runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+		token := ams.BearerToken
+		if ams.TokenSource != nil {
+			var err error
+			token, err = ams.TokenSource()
+			if err != nil {
+				return fmt.Errorf("unable to retrieve bearer token: %v", err)
+			}
+		}
+		return r.SetHeaderParam(runtime.HeaderAuthorization, "Bearer "+token)
+	})
+}
+
+// silenceClient returns the generated Alertmanager silence client for the configured transport
+func (ams *AlertManagerSilenceClient) silenceClient() *amSilence.Client {
+	var transport runtime.ClientTransport = ams.Transport
+	if authInfo := ams.bearerTokenAuth(); authInfo != nil {
+		transport = &authenticatedTransport{ClientTransport: ams.Transport, authInfo: authInfo}
+	}
+	return amSilence.New(transport, ams.formats())
+}
+
+// formats returns the configured strfmt registry, or strfmt.Default if none is set
+func (ams *AlertManagerSilenceClient) formats() strfmt.Registry {
+	if ams.Formats == nil {
+		return strfmt.Default
+	}
+	return ams.Formats
 }
 
 type SilencePredicate func(*amv2Models.GettableSilence) bool
 
 // Filter silences in Alertmanager based on the predicates
-func (ams *AlertManagerSilenceClient) Filter(predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error) {
-	silences, err := ams.List([]string{})
+func (ams *AlertManagerSilenceClient) Filter(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+	silences, err := ams.ListSilences(ctx, []string{})
 	if err != nil {
 		return nil, err
 	}
 
 	filteredSilences := []amv2Models.GettableSilence{}
-	for _, s := range silences.Payload {
-		var match = true
-		for _, p := range predicates {
-			if !p(s) {
-				match = false
-				break
-			}
-		}
-		if match {
+	for _, s := range silences {
+		if matchesAll(s, predicates) {
 			filteredSilences = append(filteredSilences, *s)
 		}
 	}
 
 	return &filteredSilences, nil
 }
+
+// FilterDistinct filters silences like Filter, collapsing silences with the same creator, comment
+// and matchers into the one that ends last
+func (ams *AlertManagerSilenceClient) FilterDistinct(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+	silences, err := ams.Filter(ctx, predicates...)
+	if err != nil {
+		return nil, err
+	}
+
+	distinct := []amv2Models.GettableSilence{}
+	index := map[string]int{}
+	for _, s := range *silences {
+		key := silenceKey(&s)
+		i, seen := index[key]
+		if !seen {
+			index[key] = len(distinct)
+			distinct = append(distinct, s)
+			continue
+		}
+		if endsAt(&s).After(endsAt(&distinct[i])) {
+			distinct[i] = s
+		}
+	}
+
+	return &distinct, nil
+}
+
+// silenceKey identifies silences that would have the same effect, for the same reason
+func silenceKey(s *amv2Models.GettableSilence) string {
+	var creator, comment string
+	if s.CreatedBy != nil {
+		creator = *s.CreatedBy
+	}
+	if s.Comment != nil {
+		comment = *s.Comment
+	}
+	return fmt.Sprintf("%q|%q|%s", creator, comment, matchersKey(s.Matchers))
+}
+
+func endsAt(s *amv2Models.GettableSilence) time.Time {
+	if s.EndsAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*s.EndsAt)
+}
+
+// FilterActive returns the active silences matching the predicates
+func (ams *AlertManagerSilenceClient) FilterActive(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+	return ams.Filter(ctx, append([]SilencePredicate{IsActive()}, predicates...)...)
+}
+
+// FilterPending returns the silences matching the predicates that have not started yet
+func (ams *AlertManagerSilenceClient) FilterPending(ctx context.Context, predicates ...SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+	return ams.Filter(ctx, append([]SilencePredicate{IsPending()}, predicates...)...)
+}
+
+// Count returns the number of silences matching the predicates
+func (ams *AlertManagerSilenceClient) Count(ctx context.Context, predicates ...SilencePredicate) (int, error) {
+	silences, err := ams.ListSilences(ctx, []string{})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, s := range silences {
+		if matchesAll(s, predicates) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func matchesAll(s *amv2Models.GettableSilence, predicates []SilencePredicate) bool {
+	for _, p := range predicates {
+		if !p(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Exists reports whether an active silence with the comment and the same matchers, in any
+// order, already exists, returning its id if so
+func (ams *AlertManagerSilenceClient) Exists(ctx context.Context, matchers amv2Models.Matchers, comment string) (bool, string, error) {
+	key := matchersKey(matchers)
+	silences, err := ams.Filter(ctx, IsActive(), func(s *amv2Models.GettableSilence) bool {
+		return s.Comment != nil && *s.Comment == comment && matchersKey(s.Matchers) == key
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	for _, s := range *silences {
+		if s.ID != nil {
+			return true, *s.ID, nil
+		}
+	}
+	return false, "", nil
+}
+
+// DeleteByFilter deletes every unexpired silence matching the predicates, returning the number
+// deleted. A failure to delete one silence does not stop the others from being deleted.
+func (ams *AlertManagerSilenceClient) DeleteByFilter(ctx context.Context, predicates ...SilencePredicate) (int, error) {
+	silences, err := ams.Filter(ctx, predicates...)
+	if err != nil {
+		return 0, err
+	}
+
+	unexpired := []amv2Models.GettableSilence{}
+	for _, s := range *silences {
+		if !hasState(amv2Models.SilenceStatusStateExpired)(&s) {
+			unexpired = append(unexpired, s)
+		}
+	}
+	return ams.deleteSilences(ctx, unexpired)
+}
+
+// deleteSilences deletes each silence, continuing past failures and returning the number deleted
+func (ams *AlertManagerSilenceClient) deleteSilences(ctx context.Context, silences []amv2Models.GettableSilence) (int, error) {
+	deleted := 0
+	var deleteErrors *multierror.Error
+	for _, s := range silences {
+		err := ams.Delete(ctx, *s.ID)
+		if err != nil {
+			deleteErrors = multierror.Append(deleteErrors, fmt.Errorf("unable to delete silence %s: %v", *s.ID, err))
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, deleteErrors.ErrorOrNil()
+}
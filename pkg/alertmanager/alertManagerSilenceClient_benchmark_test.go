@@ -0,0 +1,47 @@
+package alertmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newBenchmarkServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+}
+
+// BenchmarkListNewClientPerCall mirrors the previous behaviour of building a new
+// HTTP client, and so a new connection pool, for every request
+func BenchmarkListNewClientPerCall(b *testing.B) {
+	server := newBenchmarkServer()
+	defer server.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		silenceClient := newTestSilenceClient(server)
+		if _, err := silenceClient.List(context.TODO(), []string{}); err != nil {
+			b.Fatal(err)
+		}
+		silenceClient.httpClient().CloseIdleConnections()
+	}
+}
+
+// BenchmarkListReusedClient reuses a single client and its connections across requests
+func BenchmarkListReusedClient(b *testing.B) {
+	server := newBenchmarkServer()
+	defer server.Close()
+	silenceClient := newTestSilenceClient(server)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := silenceClient.List(context.TODO(), []string{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,699 @@
+package alertmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	"github.com/openshift/managed-upgrade-operator/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newTestSilenceClient returns a silence client pointed at the supplied test server
+func newTestSilenceClient(server *httptest.Server) *AlertManagerSilenceClient {
+	u, _ := url.Parse(server.URL)
+	return &AlertManagerSilenceClient{
+		Transport: httptransport.New(u.Host, "/api/v2/", []string{u.Scheme}),
+	}
+}
+
+var _ = Describe("Alert Manager Silence Client", func() {
+	var (
+		server        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		release       chan struct{}
+	)
+
+	Context("When the context is cancelled", func() {
+		BeforeEach(func() {
+			release = make(chan struct{})
+			// Simulate an unresponsive Alertmanager
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-release
+			}))
+			silenceClient = newTestSilenceClient(server)
+		})
+
+		AfterEach(func() {
+			close(release)
+			server.Close()
+		})
+
+		It("Should return promptly from List", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				cancel()
+			}()
+
+			start := time.Now()
+			_, err := silenceClient.List(ctx, []string{})
+			Expect(err).Should(HaveOccurred())
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It("Should time out a slow request when a request timeout is set", func() {
+			silenceClient.RequestTimeout = 50 * time.Millisecond
+
+			start := time.Now()
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It("Should keep the caller's deadline when it is shorter than the request timeout", func() {
+			silenceClient.RequestTimeout = time.Hour
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			_, err := silenceClient.List(ctx, []string{})
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It("Should not send the request when the context is already cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			start := time.Now()
+			err := silenceClient.Update(ctx, "test-id", strfmt.DateTime(time.Now()))
+			Expect(err).Should(HaveOccurred())
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+	})
+
+	Context("When connecting over TLS", func() {
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("Should trust a server signed by the supplied CA pool", func() {
+			pool := x509.NewCertPool()
+			pool.AddCert(server.Certificate())
+			silenceClient = newTestSilenceClient(server)
+			silenceClient.TLSConfig = &tls.Config{RootCAs: pool}
+
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Should verify against the system roots when no TLS config is set", func() {
+			silenceClient = newTestSilenceClient(server)
+
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("When a HTTP client is injected", func() {
+		It("Should send requests through the supplied client", func() {
+			var requests int
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+
+			silenceClient = newTestSilenceClient(server)
+			silenceClient.HTTPClient = server.Client()
+			for i := 0; i < 3; i++ {
+				_, err := silenceClient.List(context.TODO(), []string{})
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(silenceClient.httpClient()).To(BeIdenticalTo(server.Client()))
+			Expect(requests).To(Equal(3))
+		})
+	})
+
+	Context("When a bearer token is configured", func() {
+		var authHeaders []string
+
+		BeforeEach(func() {
+			authHeaders = []string{}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			silenceClient = newTestSilenceClient(server)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("Should send a static token on every request", func() {
+			silenceClient.BearerToken = "static-token"
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(authHeaders).To(Equal([]string{"Bearer static-token"}))
+		})
+
+		It("Should consult the token source on every request", func() {
+			calls := 0
+			silenceClient.TokenSource = func() (string, error) {
+				calls++
+				return fmt.Sprintf("token-%d", calls), nil
+			}
+			for i := 0; i < 2; i++ {
+				_, err := silenceClient.List(context.TODO(), []string{})
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(authHeaders).To(Equal([]string{"Bearer token-1", "Bearer token-2"}))
+		})
+
+		It("Should not send the request if the token source fails", func() {
+			silenceClient.TokenSource = func() (string, error) {
+				return "", fmt.Errorf("fake error")
+			}
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(err).Should(HaveOccurred())
+			Expect(authHeaders).To(BeEmpty())
+		})
+	})
+
+	Context("When listing silences by matchers", func() {
+		var filters []string
+
+		BeforeEach(func() {
+			filters = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				filters = r.URL.Query()["filter"]
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			silenceClient = newTestSilenceClient(server)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("Should send the matchers as quoted filter expressions", func() {
+			matchers := Matchers(
+				EqualMatcher("summary", `say "hi" \o/`),
+				RegexMatcher("namespace", "openshift-.*"),
+			)
+			_, err := silenceClient.ListByMatchers(context.TODO(), matchers)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(filters).To(Equal([]string{`summary="say \"hi\" \\o/"`, `namespace=~"openshift-.*"`}))
+		})
+
+		It("Should not send a request for invalid matchers", func() {
+			_, err := silenceClient.ListByMatchers(context.TODO(), Matchers(EqualMatcher("", "value")))
+			Expect(err).Should(HaveOccurred())
+			Expect(filters).To(BeNil())
+		})
+	})
+
+	Context("When a proxy is configured", func() {
+		It("Should use the proxy settings from the environment by default", func() {
+			silenceClient = &AlertManagerSilenceClient{}
+			transport := silenceClient.httpClient().Transport.(*redirectTransport).next.(*http.Transport)
+			Expect(reflect.ValueOf(transport.Proxy).Pointer()).To(Equal(reflect.ValueOf(http.ProxyFromEnvironment).Pointer()))
+		})
+
+		It("Should send requests through the proxy", func() {
+			var proxied []string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied = append(proxied, r.URL.String())
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer proxy.Close()
+			proxyURL, _ := url.Parse(proxy.URL)
+
+			consulted := 0
+			silenceClient = &AlertManagerSilenceClient{
+				Transport: httptransport.New("alertmanager.example.com", "/api/v2/", []string{"http"}),
+				Proxy: func(r *http.Request) (*url.URL, error) {
+					consulted++
+					return proxyURL, nil
+				},
+			}
+			_, err := silenceClient.List(context.TODO(), []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(consulted).To(Equal(1))
+			Expect(proxied).To(Equal([]string{"http://alertmanager.example.com/api/v2/silences"}))
+		})
+	})
+})
+
+var _ = Describe("Alert Manager Silence Client against a fake Alertmanager", func() {
+	var (
+		fam           *fakeAlertmanager
+		silenceClient *AlertManagerSilenceClient
+		testCreator   = "tester"
+		testComment   = "test comment"
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+	)
+
+	BeforeEach(func() {
+		fam = newFakeAlertmanager()
+		silenceClient = newTestSilenceClient(fam.server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	})
+
+	AfterEach(func() {
+		fam.Close()
+	})
+
+	Context("Getting a silence by id", func() {
+		It("Should return a silence that was created", func() {
+			err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			ids := fam.ids(amv2Models.SilenceStatusStateActive)
+			Expect(ids).To(HaveLen(1))
+
+			silence, err := silenceClient.GetByID(context.TODO(), ids[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silence.ID).To(Equal(ids[0]))
+			Expect(*silence.Comment).To(Equal(testComment))
+			Expect(*silence.CreatedBy).To(Equal(testCreator))
+		})
+
+		It("Should return ErrSilenceNotFound for an unknown silence", func() {
+			_, err := silenceClient.GetByID(context.TODO(), "00000000-0000-0000-0000-000000000099")
+			Expect(errors.Is(err, ErrSilenceNotFound)).To(BeTrue())
+		})
+	})
+	Context("Creating or updating a silence", func() {
+		var existing amv2Models.Silence
+
+		BeforeEach(func() {
+			start := testNow
+			// Truncated to the precision Alertmanager returns timestamps with
+			end := strfmt.DateTime(time.Now().UTC().Truncate(time.Millisecond).Add(30 * time.Minute))
+			existing = amv2Models.Silence{
+				CreatedBy: &testCreator,
+				Comment:   &testComment,
+				StartsAt:  &start,
+				EndsAt:    &end,
+				Matchers:  amv2Models.Matchers{},
+			}
+		})
+
+		It("Should create a silence when none exists", func() {
+			id, err := silenceClient.CreateOrUpdate(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+		})
+
+		It("Should extend an existing silence instead of creating a duplicate", func() {
+			fam.add(existing, amv2Models.SilenceStatusStateActive)
+
+			id, err := silenceClient.CreateOrUpdate(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+			Expect(time.Time(*fam.get(id).EndsAt).Unix()).To(Equal(time.Time(testEnd).Unix()))
+		})
+
+		It("Should not touch a silence created by someone else", func() {
+			otherCreator := "someone else"
+			other := existing
+			other.CreatedBy = &otherCreator
+			otherID := fam.add(other, amv2Models.SilenceStatusStateActive)
+
+			id, err := silenceClient.CreateOrUpdate(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(id).NotTo(Equal(otherID))
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(ConsistOf(id, otherID))
+		})
+
+		It("Should collapse duplicates down to the newest silence", func() {
+			olderID := fam.add(existing, amv2Models.SilenceStatusStateActive)
+			newerID := fam.add(existing, amv2Models.SilenceStatusStateActive)
+			older := strfmt.DateTime(time.Now().UTC().Add(-time.Hour))
+			fam.get(olderID).UpdatedAt = &older
+
+			id, err := silenceClient.CreateOrUpdate(context.TODO(), testMatchers, testNow, *existing.EndsAt, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+			Expect(fam.ids(amv2Models.SilenceStatusStateExpired)).To(ContainElement(olderID))
+			Expect(fam.requests).To(ContainElement("DELETE /api/v2/silence/" + olderID))
+			Expect(fam.requests).NotTo(ContainElement("DELETE /api/v2/silence/" + newerID))
+		})
+	})
+	Context("Listing silences", func() {
+		It("Should return the silences without the response wrapper", func() {
+			err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			silences, err := silenceClient.ListSilences(context.TODO(), []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(silences).To(HaveLen(1))
+			Expect(*silences[0].Comment).To(Equal(testComment))
+		})
+	})
+	Context("Updating a silence", func() {
+		var existing amv2Models.Silence
+
+		BeforeEach(func() {
+			start := strfmt.DateTime(time.Now().UTC().Add(-time.Hour))
+			end := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			existing = amv2Models.Silence{
+				CreatedBy: &testCreator,
+				Comment:   &testComment,
+				StartsAt:  &start,
+				EndsAt:    &end,
+				Matchers:  amv2Models.Matchers{},
+			}
+		})
+
+		It("Should keep the silence id when extending an active silence", func() {
+			id := fam.add(existing, amv2Models.SilenceStatusStateActive)
+
+			err := silenceClient.Update(context.TODO(), id, testEnd)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+			Expect(time.Time(*fam.get(id).EndsAt).Unix()).To(Equal(time.Time(testEnd).Unix()))
+			Expect(fam.requests).NotTo(ContainElement("DELETE /api/v2/silence/" + id))
+		})
+
+		It("Should create a fresh silence when the silence has expired", func() {
+			id := fam.add(existing, amv2Models.SilenceStatusStateExpired)
+
+			err := silenceClient.Update(context.TODO(), id, testEnd)
+			Expect(err).ShouldNot(HaveOccurred())
+			active := fam.ids(amv2Models.SilenceStatusStateActive)
+			Expect(active).To(HaveLen(1))
+			Expect(active[0]).NotTo(Equal(id))
+			Expect(*fam.get(active[0]).Comment).To(Equal(testComment))
+		})
+
+		It("Should replace the silence when the in place update is rejected", func() {
+			fam.rejectUpdates = true
+			id := fam.add(existing, amv2Models.SilenceStatusStateActive)
+
+			err := silenceClient.Update(context.TODO(), id, testEnd)
+			Expect(err).ShouldNot(HaveOccurred())
+			active := fam.ids(amv2Models.SilenceStatusStateActive)
+			Expect(active).To(HaveLen(1))
+			Expect(active[0]).NotTo(Equal(id))
+		})
+
+		It("Should roll back the replacement if the original can not be removed", func() {
+			fam.rejectUpdates = true
+			id := fam.add(existing, amv2Models.SilenceStatusStateActive)
+			fam.failDeletes[id] = true
+
+			err := silenceClient.Update(context.TODO(), id, testEnd)
+			Expect(err).Should(HaveOccurred())
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+			Expect(time.Time(*fam.get(id).EndsAt).Unix()).To(Equal(time.Time(*existing.EndsAt).Unix()))
+		})
+
+		It("Should return an error rather than panic when the silence has no status", func() {
+			id := fam.add(existing, amv2Models.SilenceStatusStateActive)
+			fam.get(id).Status = nil
+
+			var err error
+			Expect(func() { err = silenceClient.Update(context.TODO(), id, testEnd) }).NotTo(Panic())
+			Expect(err).To(MatchError(ContainSubstring("silence has no status")))
+			Expect(fam.requests).NotTo(ContainElement("POST /api/v2/silences"))
+		})
+
+		It("Should return an error rather than panic when the silence has no creator", func() {
+			existing.CreatedBy = nil
+			id := fam.add(existing, amv2Models.SilenceStatusStateActive)
+
+			var err error
+			Expect(func() { err = silenceClient.Update(context.TODO(), id, testEnd) }).NotTo(Panic())
+			Expect(err).To(MatchError(ContainSubstring("silence has no creator")))
+		})
+
+		It("Should return ErrSilenceNotFound for an unknown silence", func() {
+			err := silenceClient.Update(context.TODO(), "00000000-0000-0000-0000-000000000099", testEnd)
+			Expect(errors.Is(err, ErrSilenceNotFound)).To(BeTrue())
+		})
+	})
+	Context("Deleting silences by filter", func() {
+		var (
+			silence      amv2Models.Silence
+			otherComment = "other comment"
+		)
+
+		BeforeEach(func() {
+			end := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			silence = amv2Models.Silence{
+				CreatedBy: &testCreator,
+				Comment:   &testComment,
+				StartsAt:  &testNow,
+				EndsAt:    &end,
+				Matchers:  amv2Models.Matchers{},
+			}
+		})
+
+		It("Should delete matching silences and skip expired ones", func() {
+			first := fam.add(silence, amv2Models.SilenceStatusStateActive)
+			second := fam.add(silence, amv2Models.SilenceStatusStatePending)
+			expired := fam.add(silence, amv2Models.SilenceStatusStateExpired)
+			other := silence
+			other.Comment = &otherComment
+			unmatched := fam.add(other, amv2Models.SilenceStatusStateActive)
+
+			deleted, err := silenceClient.DeleteByFilter(context.TODO(), WithComment(testComment))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(deleted).To(Equal(2))
+			Expect(fam.ids(amv2Models.SilenceStatusStateExpired)).To(ConsistOf(first, second, expired))
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{unmatched}))
+			Expect(fam.requests).NotTo(ContainElement("DELETE /api/v2/silence/" + expired))
+		})
+
+		It("Should continue deleting after a failure and report it", func() {
+			failing := fam.add(silence, amv2Models.SilenceStatusStateActive)
+			deletable := fam.add(silence, amv2Models.SilenceStatusStateActive)
+			fam.failDeletes[failing] = true
+
+			deleted, err := silenceClient.DeleteByFilter(context.TODO(), WithComment(testComment))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(failing))
+			Expect(deleted).To(Equal(1))
+			Expect(fam.ids(amv2Models.SilenceStatusStateExpired)).To(Equal([]string{deletable}))
+		})
+	})
+	Context("Deleting a silence", func() {
+		It("Should succeed if the silence does not exist", func() {
+			err := silenceClient.Delete(context.TODO(), "00000000-0000-0000-0000-000000000099")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Should return server errors", func() {
+			end := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			id := fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &end}, amv2Models.SilenceStatusStateActive)
+			fam.failDeletes[id] = true
+
+			err := silenceClient.Delete(context.TODO(), id)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+	Context("Counting silences", func() {
+		BeforeEach(func() {
+			end := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			silence := amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &end}
+			fam.add(silence, amv2Models.SilenceStatusStateActive)
+			fam.add(silence, amv2Models.SilenceStatusStateActive)
+			fam.add(silence, amv2Models.SilenceStatusStateExpired)
+		})
+
+		It("Should count every silence without predicates", func() {
+			count, err := silenceClient.Count(context.TODO())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).To(Equal(3))
+		})
+
+		It("Should count silences matching all predicates", func() {
+			count, err := silenceClient.Count(context.TODO(), IsActive(), CreatedBy(testCreator))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("Should return zero when nothing matches", func() {
+			count, err := silenceClient.Count(context.TODO(), IsActive(), CreatedBy("someone else"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+	Context("Creating a silence with invalid matchers", func() {
+		It("Should not send the silence to Alertmanager", func() {
+			err := silenceClient.Create(context.TODO(), amv2Models.Matchers{}, testNow, testEnd, testCreator, testComment)
+			Expect(err).Should(HaveOccurred())
+			Expect(fam.requests).To(BeEmpty())
+		})
+	})
+	Context("Creating a silence without a creator", func() {
+		It("Should record the operator as the creator by default", func() {
+			Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "", testComment)).To(Succeed())
+			silence, err := silenceClient.GetByID(context.TODO(), fam.ids(amv2Models.SilenceStatusStateActive)[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silence.CreatedBy).To(Equal(config.OperatorName))
+		})
+
+		It("Should record the configured default creator", func() {
+			silenceClient.Creator = "muo-pod"
+			Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "", testComment)).To(Succeed())
+			silence, err := silenceClient.GetByID(context.TODO(), fam.ids(amv2Models.SilenceStatusStateActive)[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silence.CreatedBy).To(Equal("muo-pod"))
+		})
+
+		It("Should respect an explicit creator", func() {
+			silenceClient.Creator = "muo-pod"
+			Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, testCreator, testComment)).To(Succeed())
+			silence, err := silenceClient.GetByID(context.TODO(), fam.ids(amv2Models.SilenceStatusStateActive)[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silence.CreatedBy).To(Equal(testCreator))
+		})
+	})
+	Context("Creating a silence with an explicit id", func() {
+		It("Should create a new silence when the id is unknown", func() {
+			unknown := "00000000-0000-0000-0000-000000000099"
+			id, err := silenceClient.CreateWithID(context.TODO(), unknown, testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(id).NotTo(Equal(unknown))
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{id}))
+		})
+
+		It("Should update the existing silence when the id is known", func() {
+			end := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			existing := fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &end, Matchers: testMatchers}, amv2Models.SilenceStatusStateActive)
+
+			id, err := silenceClient.CreateWithID(context.TODO(), existing, testMatchers, testNow, testEnd, testCreator, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(id).To(Equal(existing))
+			Expect(fam.ids(amv2Models.SilenceStatusStateActive)).To(Equal([]string{existing}))
+			Expect(time.Time(*fam.get(existing).EndsAt).Unix()).To(Equal(time.Time(testEnd).Unix()))
+		})
+	})
+	Context("Filtering by state", func() {
+		var active, pending string
+
+		BeforeEach(func() {
+			later := strfmt.DateTime(time.Now().UTC().Add(time.Hour))
+			silence := amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &testEnd}
+			active = fam.add(silence, amv2Models.SilenceStatusStateActive)
+			silence.StartsAt = &later
+			pending = fam.add(silence, amv2Models.SilenceStatusStatePending)
+			fam.add(silence, amv2Models.SilenceStatusStateExpired)
+			noStatus := fam.add(silence, amv2Models.SilenceStatusStateActive)
+			fam.get(noStatus).Status = nil
+			noState := fam.add(silence, amv2Models.SilenceStatusStateActive)
+			fam.get(noState).Status.State = nil
+		})
+
+		It("Should return only active silences", func() {
+			silences, err := silenceClient.FilterActive(context.TODO())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silences).To(HaveLen(1))
+			Expect(*(*silences)[0].ID).To(Equal(active))
+		})
+
+		It("Should return only pending silences", func() {
+			silences, err := silenceClient.FilterPending(context.TODO(), CreatedBy(testCreator))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silences).To(HaveLen(1))
+			Expect(*(*silences)[0].ID).To(Equal(pending))
+		})
+	})
+	Context("Checking Alertmanager health", func() {
+		It("Should be healthy when Alertmanager is reachable", func() {
+			Expect(silenceClient.Healthy(context.TODO())).To(Succeed())
+		})
+
+		It("Should be unhealthy when the connection is refused", func() {
+			fam.Close()
+			err := silenceClient.Healthy(context.TODO())
+			Expect(err).To(MatchError(ContainSubstring("alertmanager silence API is not available")))
+		})
+	})
+	Context("Filtering distinct silences", func() {
+		It("Should collapse duplicates with reordered matchers, keeping the latest end", func() {
+			severity := newTestMatcher("severity", "(warning|info)", true)
+			namespace := newTestMatcher("namespace", "openshift-monitoring", false)
+			earlier := strfmt.DateTime(time.Now().UTC().Add(30 * time.Minute))
+			later := strfmt.DateTime(time.Now().UTC().Add(60 * time.Minute))
+
+			fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &earlier, Matchers: amv2Models.Matchers{severity, namespace}}, amv2Models.SilenceStatusStateActive)
+			latest := fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &later, Matchers: amv2Models.Matchers{namespace, severity}}, amv2Models.SilenceStatusStateActive)
+
+			silences, err := silenceClient.FilterDistinct(context.TODO(), IsActive())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silences).To(HaveLen(1))
+			Expect(*(*silences)[0].ID).To(Equal(latest))
+		})
+
+		It("Should keep silences whose matchers differ", func() {
+			fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &testEnd, Matchers: amv2Models.Matchers{newTestMatcher("severity", "warning", false)}}, amv2Models.SilenceStatusStateActive)
+			fam.add(amv2Models.Silence{CreatedBy: &testCreator, Comment: &testComment, StartsAt: &testNow, EndsAt: &testEnd, Matchers: amv2Models.Matchers{newTestMatcher("severity", "warning", true)}}, amv2Models.SilenceStatusStateActive)
+
+			silences, err := silenceClient.FilterDistinct(context.TODO())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(*silences).To(HaveLen(2))
+		})
+	})
+
+	Context("Checking whether a silence exists", func() {
+		var matchers amv2Models.Matchers
+
+		BeforeEach(func() {
+			matchers = Matchers(EqualMatcher("alertname", "Watchdog"), RegexMatcher("namespace", "openshift-.*"))
+			Expect(silenceClient.Create(context.TODO(), matchers, testNow, testEnd, testCreator, testComment)).To(Succeed())
+		})
+
+		It("Should find a silence with the same matchers and comment", func() {
+			exists, id, err := silenceClient.Exists(context.TODO(), matchers, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(id).To(Equal(fam.ids(amv2Models.SilenceStatusStateActive)[0]))
+		})
+
+		It("Should find a silence when the matchers are reordered", func() {
+			reordered := Matchers(RegexMatcher("namespace", "openshift-.*"), EqualMatcher("alertname", "Watchdog"))
+			exists, id, err := silenceClient.Exists(context.TODO(), reordered, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(id).NotTo(BeEmpty())
+		})
+
+		It("Should not match a different comment or matchers", func() {
+			exists, id, err := silenceClient.Exists(context.TODO(), matchers, "another comment")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			Expect(id).To(BeEmpty())
+
+			// Same name and value, but not a regex
+			different := Matchers(EqualMatcher("alertname", "Watchdog"), EqualMatcher("namespace", "openshift-.*"))
+			exists, _, err = silenceClient.Exists(context.TODO(), different, testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			exists, _, err = silenceClient.Exists(context.TODO(), Matchers(EqualMatcher("alertname", "Watchdog")), testComment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package alertmanager
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAlertManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AlertManager Suite")
+}
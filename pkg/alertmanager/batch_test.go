@@ -0,0 +1,99 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Creating silences in a batch", func() {
+	var (
+		server        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		mu            sync.Mutex
+		inFlight      int
+		maxInFlight   int
+		created       int
+		failComment   string
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+	)
+
+	specs := func(n int) []SilenceSpec {
+		s := []SilenceSpec{}
+		for i := 0; i < n; i++ {
+			s = append(s, SilenceSpec{
+				Matchers:  testMatchers,
+				StartsAt:  testNow,
+				EndsAt:    testEnd,
+				CreatedBy: "tester",
+				Comment:   fmt.Sprintf("comment %d", i),
+			})
+		}
+		return s
+	}
+
+	BeforeEach(func() {
+		inFlight, maxInFlight, created, failComment = 0, 0, 0, ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			postable := &amv2Models.PostableSilence{}
+			_ = json.NewDecoder(r.Body).Decode(postable)
+
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			inFlight--
+			w.Header().Set("Content-Type", "application/json")
+			if *postable.Comment == failComment {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode("fake error")
+				return
+			}
+			created++
+			_ = json.NewEncoder(w).Encode(map[string]string{"silenceID": "id-" + *postable.Comment})
+		}))
+		silenceClient = newTestSilenceClient(server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should not exceed the concurrency bound", func() {
+		silenceClient.MaxConcurrency = 2
+		ids, err := silenceClient.CreateBatch(context.TODO(), specs(8))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ids).To(HaveLen(8))
+		Expect(ids[3]).To(Equal("id-comment 3"))
+		Expect(created).To(Equal(8))
+		Expect(maxInFlight).To(BeNumerically("<=", 2))
+		Expect(maxInFlight).To(BeNumerically(">", 0))
+	})
+
+	It("Should report partial failures and return the ids that succeeded", func() {
+		failComment = "comment 1"
+		ids, err := silenceClient.CreateBatch(context.TODO(), specs(3))
+		Expect(err).To(MatchError(ContainSubstring("comment 1")))
+		Expect(ids).To(Equal([]string{"id-comment 0", "", "id-comment 2"}))
+	})
+})
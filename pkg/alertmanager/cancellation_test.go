@@ -0,0 +1,95 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Silence client cancellation", func() {
+	var (
+		server        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		release       chan struct{}
+		received      chan struct{}
+		testID        = "00000000-0000-0000-0000-000000000001"
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+	)
+
+	BeforeEach(func() {
+		release = make(chan struct{})
+		received = make(chan struct{}, 10)
+		// Every request blocks until the test finishes
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- struct{}{}
+			<-release
+		}))
+		silenceClient = newTestSilenceClient(server)
+	})
+
+	AfterEach(func() {
+		close(release)
+		server.Close()
+	})
+
+	operations := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"Create", func(ctx context.Context) error {
+			return silenceClient.Create(ctx, testMatchers, testNow, testEnd, "tester", "test comment")
+		}},
+		{"List", func(ctx context.Context) error {
+			_, err := silenceClient.List(ctx, []string{})
+			return err
+		}},
+		{"Delete", func(ctx context.Context) error {
+			return silenceClient.Delete(ctx, testID)
+		}},
+		{"Update", func(ctx context.Context) error {
+			return silenceClient.Update(ctx, testID, testEnd)
+		}},
+		{"GetByID", func(ctx context.Context) error {
+			_, err := silenceClient.GetByID(ctx, testID)
+			return err
+		}},
+	}
+
+	for _, op := range operations {
+		op := op
+
+		It(op.name+" should return promptly when cancelled mid-flight", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-received
+				cancel()
+			}()
+
+			start := time.Now()
+			err := op.call(ctx)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue(), "unexpected error: %v", err)
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It(op.name+" should return promptly when already cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			start := time.Now()
+			err := op.call(ctx)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue(), "unexpected error: %v", err)
+			Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+			Expect(received).To(BeEmpty())
+		})
+	}
+})
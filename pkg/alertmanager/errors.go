@@ -0,0 +1,86 @@
+package alertmanager
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	amSilence "github.com/prometheus/alertmanager/api/v2/client/silence"
+)
+
+var (
+	// ErrSilenceNotFound is returned when the requested silence does not exist in Alertmanager
+	ErrSilenceNotFound = fmt.Errorf("silence not found")
+	// ErrUnauthorized is returned when Alertmanager rejects the credentials used by the client
+	ErrUnauthorized = fmt.Errorf("unauthorized to manage alertmanager silences")
+	// ErrInvalidSilence is returned when a silence is rejected as invalid, either by Alertmanager or before it is sent
+	ErrInvalidSilence = fmt.Errorf("invalid silence")
+)
+
+// silenceError wraps an error from a silence operation with the kind of failure it represents,
+// so callers can use errors.Is on the kind and errors.As on the underlying error
+type silenceError struct {
+	kind error
+	err  error
+}
+
+func (e *silenceError) Error() string {
+	return fmt.Sprintf("%v: %v", e.kind, e.err)
+}
+
+func (e *silenceError) Unwrap() error {
+	return e.err
+}
+
+func (e *silenceError) Is(target error) bool {
+	return target == e.kind
+}
+
+// wrapError classifies an error returned by Alertmanager, returning it unchanged if it is
+// not one of the known kinds
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code, ok := statusCode(err)
+	if !ok {
+		return err
+	}
+	switch code {
+	case http.StatusNotFound:
+		return &silenceError{kind: ErrSilenceNotFound, err: err}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &silenceError{kind: ErrUnauthorized, err: err}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &silenceError{kind: ErrInvalidSilence, err: err}
+	}
+	return err
+}
+
+// statusCode returns the HTTP status code of an error response from Alertmanager
+func statusCode(err error) (int, bool) {
+	switch err.(type) {
+	case *amSilence.GetSilenceNotFound:
+		return http.StatusNotFound, true
+	case *amSilence.PostSilencesBadRequest:
+		return http.StatusBadRequest, true
+	case *amSilence.GetSilenceInternalServerError,
+		*amSilence.GetSilencesInternalServerError,
+		*amSilence.DeleteSilenceInternalServerError:
+		return http.StatusInternalServerError, true
+	}
+
+	var apiErr *runtime.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code, true
+	}
+	return 0, false
+}
+
+// isNotFound returns true if Alertmanager responded that the silence does not exist
+func isNotFound(err error) bool {
+	code, ok := statusCode(err)
+	return ok && code == http.StatusNotFound
+}
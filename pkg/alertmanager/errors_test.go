@@ -0,0 +1,109 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Silence client errors", func() {
+	var (
+		server        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		status        int
+		testID        = "00000000-0000-0000-0000-000000000001"
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`"fake error"`))
+		}))
+		silenceClient = newTestSilenceClient(server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	operations := map[string]func() error{
+		"Create": func() error {
+			return silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+		},
+		"Delete": func() error {
+			return silenceClient.Delete(context.TODO(), testID)
+		},
+		"Update": func() error {
+			return silenceClient.Update(context.TODO(), testID, testEnd)
+		},
+		"GetByID": func() error {
+			_, err := silenceClient.GetByID(context.TODO(), testID)
+			return err
+		},
+	}
+
+	for name, operation := range operations {
+		name, operation := name, operation
+
+		It(name+" should return ErrUnauthorized on a 401 response", func() {
+			status = http.StatusUnauthorized
+			err := operation()
+			Expect(errors.Is(err, ErrUnauthorized)).To(BeTrue())
+			var apiErr *runtime.APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It(name+" should return ErrUnauthorized on a 403 response", func() {
+			status = http.StatusForbidden
+			Expect(errors.Is(operation(), ErrUnauthorized)).To(BeTrue())
+		})
+	}
+
+	It("GetByID should return ErrSilenceNotFound on a 404 response", func() {
+		status = http.StatusNotFound
+		_, err := silenceClient.GetByID(context.TODO(), testID)
+		Expect(errors.Is(err, ErrSilenceNotFound)).To(BeTrue())
+	})
+
+	It("Update should return ErrSilenceNotFound on a 404 response", func() {
+		status = http.StatusNotFound
+		err := silenceClient.Update(context.TODO(), testID, testEnd)
+		Expect(errors.Is(err, ErrSilenceNotFound)).To(BeTrue())
+	})
+
+	It("Create should return ErrInvalidSilence on a 400 response", func() {
+		status = http.StatusBadRequest
+		err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+		Expect(errors.Is(err, ErrInvalidSilence)).To(BeTrue())
+	})
+
+	It("Create should return ErrInvalidSilence for invalid matchers without calling Alertmanager", func() {
+		status = http.StatusOK
+		err := silenceClient.Create(context.TODO(), amv2Models.Matchers{}, testNow, testEnd, "tester", "test comment")
+		Expect(errors.Is(err, ErrInvalidSilence)).To(BeTrue())
+	})
+
+	It("Should not classify server errors", func() {
+		status = http.StatusInternalServerError
+		err := silenceClient.Delete(context.TODO(), testID)
+		Expect(err).Should(HaveOccurred())
+		Expect(errors.Is(err, ErrUnauthorized)).To(BeFalse())
+		Expect(errors.Is(err, ErrSilenceNotFound)).To(BeFalse())
+		Expect(errors.Is(err, ErrInvalidSilence)).To(BeFalse())
+	})
+})
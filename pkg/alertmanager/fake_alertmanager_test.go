@@ -0,0 +1,178 @@
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// fakeAlertmanager is an in-memory stand in for the Alertmanager v2 silence API
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	silences map[string]*amv2Models.GettableSilence
+	nextID   int
+	requests []string
+	server   *httptest.Server
+
+	// rejectUpdates causes posts updating an existing silence to be rejected
+	rejectUpdates bool
+	// failDeletes holds the ids of silences whose deletion fails
+	failDeletes map[string]bool
+}
+
+func newFakeAlertmanager() *fakeAlertmanager {
+	fam := &fakeAlertmanager{
+		silences:    map[string]*amv2Models.GettableSilence{},
+		failDeletes: map[string]bool{},
+	}
+	fam.server = httptest.NewServer(http.HandlerFunc(fam.handle))
+	return fam
+}
+
+func (fam *fakeAlertmanager) Close() {
+	fam.server.Close()
+}
+
+// add stores a silence directly and returns its generated id
+func (fam *fakeAlertmanager) add(silence amv2Models.Silence, state string) string {
+	fam.mu.Lock()
+	defer fam.mu.Unlock()
+	id := fam.newID()
+	updatedAt := strfmt.DateTime(time.Now().UTC())
+	fam.silences[id] = &amv2Models.GettableSilence{
+		ID:        &id,
+		Status:    &amv2Models.SilenceStatus{State: &state},
+		UpdatedAt: &updatedAt,
+		Silence:   silence,
+	}
+	return id
+}
+
+// get returns a stored silence or nil
+func (fam *fakeAlertmanager) get(id string) *amv2Models.GettableSilence {
+	fam.mu.Lock()
+	defer fam.mu.Unlock()
+	return fam.silences[id]
+}
+
+// ids returns the ids of all stored silences in the supplied state
+func (fam *fakeAlertmanager) ids(state string) []string {
+	fam.mu.Lock()
+	defer fam.mu.Unlock()
+	ids := []string{}
+	for id, s := range fam.silences {
+		if s.Status != nil && s.Status.State != nil && *s.Status.State == state {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (fam *fakeAlertmanager) newID() string {
+	fam.nextID++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", fam.nextID)
+}
+
+func silenceState(s amv2Models.Silence) string {
+	now := time.Now()
+	if s.EndsAt != nil && time.Time(*s.EndsAt).Before(now) {
+		return amv2Models.SilenceStatusStateExpired
+	}
+	if s.StartsAt != nil && time.Time(*s.StartsAt).After(now) {
+		return amv2Models.SilenceStatusStatePending
+	}
+	return amv2Models.SilenceStatusStateActive
+}
+
+func (fam *fakeAlertmanager) handle(w http.ResponseWriter, r *http.Request) {
+	fam.mu.Lock()
+	defer fam.mu.Unlock()
+	fam.requests = append(fam.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.URL.Path == "/api/v2/silences" && r.Method == http.MethodGet:
+		silences := amv2Models.GettableSilences{}
+		for _, s := range fam.silences {
+			silences = append(silences, s)
+		}
+		sort.Slice(silences, func(i, j int) bool { return *silences[i].ID < *silences[j].ID })
+		_ = json.NewEncoder(w).Encode(silences)
+
+	case r.URL.Path == "/api/v2/silences" && r.Method == http.MethodPost:
+		postable := &amv2Models.PostableSilence{}
+		if err := json.NewDecoder(r.Body).Decode(postable); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		id := postable.ID
+		if id != "" && fam.rejectUpdates {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode("silence can not be updated")
+			return
+		}
+		if id != "" {
+			existing, ok := fam.silences[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode("silence not found")
+				return
+			}
+			// Expired silences can not be updated in place, Alertmanager creates a new one instead
+			if *existing.Status.State == amv2Models.SilenceStatusStateExpired {
+				id = ""
+			}
+		}
+		if id == "" {
+			id = fam.newID()
+		}
+		state := silenceState(postable.Silence)
+		updatedAt := strfmt.DateTime(time.Now().UTC())
+		fam.silences[id] = &amv2Models.GettableSilence{
+			ID:        &id,
+			Status:    &amv2Models.SilenceStatus{State: &state},
+			UpdatedAt: &updatedAt,
+			Silence:   postable.Silence,
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"silenceID": id})
+
+	case strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")
+		s, ok := fam.silences[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(s)
+		case http.MethodDelete:
+			if fam.failDeletes[id] {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode("fake error")
+				return
+			}
+			// Alertmanager refuses to expire a silence again
+			if *s.Status.State == amv2Models.SilenceStatusStateExpired {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(fmt.Sprintf("silence %s already expired", id))
+				return
+			}
+			expired := amv2Models.SilenceStatusStateExpired
+			s.Status.State = &expired
+		}
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,90 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingLogger is a logr.Logger that keeps every line logged through it
+type recordingLogger struct {
+	lines *[]string
+}
+
+func (l recordingLogger) record(msg string, keysAndValues ...interface{}) {
+	line := msg
+	for _, kv := range keysAndValues {
+		line += fmt.Sprintf(" %v", kv)
+	}
+	*l.lines = append(*l.lines, line)
+}
+
+func (l recordingLogger) Enabled() bool { return true }
+func (l recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(msg, keysAndValues...)
+}
+func (l recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.record(msg, append(keysAndValues, "error", err)...)
+}
+func (l recordingLogger) V(level int) logr.InfoLogger                         { return l }
+func (l recordingLogger) WithValues(keysAndValues ...interface{}) logr.Logger { return l }
+func (l recordingLogger) WithName(name string) logr.Logger                    { return l }
+
+var _ = Describe("Silence client logging", func() {
+	var (
+		fam           *fakeAlertmanager
+		silenceClient *AlertManagerSilenceClient
+		lines         []string
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+	)
+
+	BeforeEach(func() {
+		fam = newFakeAlertmanager()
+		lines = []string{}
+		silenceClient = newTestSilenceClient(fam.server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+		silenceClient.Logger = recordingLogger{lines: &lines}
+	})
+
+	AfterEach(func() {
+		fam.Close()
+	})
+
+	It("Should log the id, matchers and comment of a created silence", func() {
+		err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+		Expect(err).ShouldNot(HaveOccurred())
+		ids := fam.ids(amv2Models.SilenceStatusStateActive)
+		Expect(ids).To(HaveLen(1))
+
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(HavePrefix("Created silence"))
+		Expect(lines[0]).To(ContainSubstring(ids[0]))
+		Expect(lines[0]).To(ContainSubstring("test comment"))
+		Expect(lines[0]).To(ContainSubstring(`alertname="Watchdog"`))
+	})
+
+	It("Should log failures", func() {
+		fam.failDeletes["00000000-0000-0000-0000-000000000001"] = true
+		fam.add(amv2Models.Silence{StartsAt: &testNow, EndsAt: &testEnd}, amv2Models.SilenceStatusStateActive)
+
+		err := silenceClient.Delete(context.TODO(), "00000000-0000-0000-0000-000000000001")
+		Expect(err).Should(HaveOccurred())
+		Expect(strings.Join(lines, "\n")).To(ContainSubstring("Failed to delete silence"))
+	})
+
+	It("Should not require a logger", func() {
+		silenceClient.Logger = nil
+		err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,144 @@
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// EqualMatcher returns a matcher for alerts whose label name equals value
+func EqualMatcher(name, value string) *amv2Models.Matcher {
+	return newMatcher(name, value, false)
+}
+
+// RegexMatcher returns a matcher for alerts whose label name matches the regex pattern
+func RegexMatcher(name, pattern string) *amv2Models.Matcher {
+	return newMatcher(name, pattern, true)
+}
+
+// Matchers collects matchers into the form expected when creating a silence
+func Matchers(matchers ...*amv2Models.Matcher) amv2Models.Matchers {
+	return amv2Models.Matchers(matchers)
+}
+
+func newMatcher(name, value string, isRegex bool) *amv2Models.Matcher {
+	return &amv2Models.Matcher{
+		Name:    &name,
+		Value:   &value,
+		IsRegex: &isRegex,
+	}
+}
+
+// validateMatchers ensures the matchers can be sent to Alertmanager and will not
+// create a silence that matches every alert
+func validateMatchers(matchers amv2Models.Matchers) error {
+	if len(matchers) == 0 {
+		return fmt.Errorf("silence must have at least one matcher")
+	}
+
+	for i, m := range matchers {
+		if m == nil {
+			return fmt.Errorf("matcher %d is empty", i)
+		}
+		if m.Name == nil || *m.Name == "" {
+			return fmt.Errorf("matcher %d has no name", i)
+		}
+		if m.Value == nil {
+			return fmt.Errorf("matcher %d (%s) has no value", i, *m.Name)
+		}
+		if m.IsRegex != nil && *m.IsRegex {
+			// Alertmanager anchors regex matchers, so validate them the same way
+			if _, err := regexp.Compile("^(?:" + *m.Value + ")$"); err != nil {
+				return fmt.Errorf("matcher %d (%s) has an invalid regex %q: %v", i, *m.Name, *m.Value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatMatchers renders matchers in the Alertmanager label selector syntax for logging
+func formatMatchers(matchers amv2Models.Matchers) string {
+	formatted := []string{}
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		formatted = append(formatted, formatMatcher(m))
+	}
+	return "{" + strings.Join(formatted, ",") + "}"
+}
+
+// MatchersEqual reports whether a and b contain the same matchers, comparing the name, value
+// and whether each is a regex, regardless of order
+func MatchersEqual(a, b amv2Models.Matchers) bool {
+	return matchersKey(a) == matchersKey(b)
+}
+
+// matchersKey identifies a set of matchers regardless of the order they are in
+func matchersKey(matchers amv2Models.Matchers) string {
+	keys := []string{}
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		keys = append(keys, formatMatcher(m))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// labelNameRegexp matches the label names Alertmanager accepts in filter expressions
+var labelNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// filterExpressions converts matchers into the filter expressions accepted by the
+// Alertmanager silence list API
+func filterExpressions(matchers amv2Models.Matchers) ([]string, error) {
+	filter := []string{}
+	for i, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			return nil, fmt.Errorf("matcher %d is incomplete", i)
+		}
+		if !labelNameRegexp.MatchString(*m.Name) {
+			return nil, fmt.Errorf("matcher %d has an invalid label name %q", i, *m.Name)
+		}
+		op := "="
+		if m.IsRegex != nil && *m.IsRegex {
+			op = "=~"
+		}
+		filter = append(filter, *m.Name+op+quoteFilterValue(*m.Value))
+	}
+	return filter, nil
+}
+
+// quoteFilterValue quotes a value the way the Alertmanager matcher parser unquotes it.
+// Only backslashes, double quotes and newlines are escaped; strconv.Quote escapes more
+// than the parser understands.
+func quoteFilterValue(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '\\', '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func formatMatcher(m *amv2Models.Matcher) string {
+	op := "="
+	if m.IsRegex != nil && *m.IsRegex {
+		op = "=~"
+	}
+	return fmt.Sprintf("%s%s%q", *m.Name, op, *m.Value)
+}
@@ -0,0 +1,143 @@
+package alertmanager
+
+import (
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matcher validation", func() {
+	It("Should accept valid matchers", func() {
+		matchers := amv2Models.Matchers{
+			newTestMatcher("alertname", "Watchdog", false),
+			newTestMatcher("severity", "(warning|info)", true),
+		}
+		Expect(validateMatchers(matchers)).To(Succeed())
+	})
+
+	It("Should reject an empty matcher slice", func() {
+		Expect(validateMatchers(amv2Models.Matchers{})).NotTo(Succeed())
+	})
+
+	It("Should reject a matcher with a blank name", func() {
+		matchers := amv2Models.Matchers{
+			newTestMatcher("alertname", "Watchdog", false),
+			newTestMatcher("", "critical", false),
+		}
+		err := validateMatchers(matchers)
+		Expect(err).To(MatchError(ContainSubstring("matcher 1 has no name")))
+	})
+
+	It("Should reject a regex matcher that does not compile", func() {
+		matchers := amv2Models.Matchers{newTestMatcher("severity", "(warning|info", true)}
+		err := validateMatchers(matchers)
+		Expect(err).To(MatchError(ContainSubstring("severity")))
+	})
+
+	It("Should not compile values of non-regex matchers", func() {
+		matchers := amv2Models.Matchers{newTestMatcher("severity", "(warning", false)}
+		Expect(validateMatchers(matchers)).To(Succeed())
+	})
+})
+
+var _ = Describe("Matcher helpers", func() {
+	It("EqualMatcher should build a non-regex matcher", func() {
+		m := EqualMatcher("alertname", "Watchdog")
+		Expect(m.Name).NotTo(BeNil())
+		Expect(m.Value).NotTo(BeNil())
+		Expect(m.IsRegex).NotTo(BeNil())
+		Expect(*m.Name).To(Equal("alertname"))
+		Expect(*m.Value).To(Equal("Watchdog"))
+		Expect(*m.IsRegex).To(BeFalse())
+	})
+
+	It("RegexMatcher should build a regex matcher", func() {
+		m := RegexMatcher("severity", "(warning|info)")
+		Expect(*m.Name).To(Equal("severity"))
+		Expect(*m.Value).To(Equal("(warning|info)"))
+		Expect(*m.IsRegex).To(BeTrue())
+	})
+
+	It("Should not share pointers between matchers", func() {
+		first := EqualMatcher("a", "1")
+		second := EqualMatcher("b", "2")
+		Expect(first.Name).NotTo(BeIdenticalTo(second.Name))
+		Expect(*first.Name).To(Equal("a"))
+	})
+
+	It("Matchers should collect matchers in order", func() {
+		first := EqualMatcher("alertname", "Watchdog")
+		second := RegexMatcher("severity", "warning|info")
+		matchers := Matchers(first, second)
+		Expect(matchers).To(HaveLen(2))
+		Expect(matchers[0]).To(BeIdenticalTo(first))
+		Expect(matchers[1]).To(BeIdenticalTo(second))
+		Expect(validateMatchers(matchers)).To(Succeed())
+	})
+})
+
+var _ = Describe("Matcher filter expressions", func() {
+	It("Should quote equality and regex matchers", func() {
+		filter, err := filterExpressions(Matchers(
+			EqualMatcher("alertname", "Watchdog"),
+			RegexMatcher("severity", "warning|info"),
+		))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(Equal([]string{`alertname="Watchdog"`, `severity=~"warning|info"`}))
+	})
+
+	It("Should escape double quotes in values", func() {
+		filter, err := filterExpressions(Matchers(EqualMatcher("summary", `say "hi"`)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(Equal([]string{`summary="say \"hi\""`}))
+	})
+
+	It("Should escape backslashes in values", func() {
+		filter, err := filterExpressions(Matchers(EqualMatcher("path", `C:\temp\`)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(Equal([]string{`path="C:\\temp\\"`}))
+	})
+
+	It("Should leave regex special characters alone", func() {
+		filter, err := filterExpressions(Matchers(RegexMatcher("namespace", `^openshift-.*\.(a|b)+$`)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(Equal([]string{`namespace=~"^openshift-.*\\.(a|b)+$"`}))
+	})
+
+	It("Should escape newlines in values", func() {
+		filter, err := filterExpressions(Matchers(EqualMatcher("description", "line one\nline two")))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(Equal([]string{`description="line one\nline two"`}))
+	})
+
+	It("Should reject invalid label names", func() {
+		_, err := filterExpressions(Matchers(EqualMatcher("not a label", "value")))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Should reject incomplete matchers", func() {
+		_, err := filterExpressions(amv2Models.Matchers{&amv2Models.Matcher{}})
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Matcher comparison", func() {
+	It("Should treat reordered matchers as equal", func() {
+		a := Matchers(EqualMatcher("alertname", "Watchdog"), RegexMatcher("namespace", "openshift-.*"))
+		b := Matchers(RegexMatcher("namespace", "openshift-.*"), EqualMatcher("alertname", "Watchdog"))
+		Expect(MatchersEqual(a, b)).To(BeTrue())
+	})
+
+	It("Should distinguish regex from equality matchers", func() {
+		a := Matchers(RegexMatcher("namespace", "openshift"))
+		b := Matchers(EqualMatcher("namespace", "openshift"))
+		Expect(MatchersEqual(a, b)).To(BeFalse())
+	})
+
+	It("Should not treat a subset as equal", func() {
+		a := Matchers(EqualMatcher("alertname", "Watchdog"), EqualMatcher("severity", "info"))
+		b := Matchers(EqualMatcher("alertname", "Watchdog"))
+		Expect(MatchersEqual(a, b)).To(BeFalse())
+	})
+})
@@ -0,0 +1,73 @@
+package alertmanager
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsTag     = "upgradeoperator"
+	operationLabel = "operation"
+	outcomeLabel   = "outcome"
+
+	operationCreate = "create"
+	operationList   = "list"
+	operationDelete = "delete"
+	operationUpdate = "update"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// SilenceMetrics records the outcome and duration of silence operations
+type SilenceMetrics interface {
+	ObserveSilenceOperation(operation string, err error, duration time.Duration)
+}
+
+var (
+	metricSilenceOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsTag,
+		Name:      "silence_operations_total",
+		Help:      "Alertmanager silence operations performed, by operation and outcome",
+	}, []string{operationLabel, outcomeLabel})
+	metricSilenceOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsTag,
+		Name:      "silence_operation_duration_seconds",
+		Help:      "Duration of Alertmanager silence operations",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{operationLabel})
+
+	defaultSilenceMetrics = &prometheusSilenceMetrics{
+		operations: metricSilenceOperations,
+		duration:   metricSilenceOperationDuration,
+	}
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricSilenceOperations, metricSilenceOperationDuration)
+}
+
+// prometheusSilenceMetrics records silence operations to Prometheus collectors
+type prometheusSilenceMetrics struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+}
+
+func (m *prometheusSilenceMetrics) ObserveSilenceOperation(operation string, err error, duration time.Duration) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeFailure
+	}
+	m.operations.With(prometheus.Labels{operationLabel: operation, outcomeLabel: outcome}).Inc()
+	m.duration.With(prometheus.Labels{operationLabel: operation}).Observe(duration.Seconds())
+}
+
+func (ams *AlertManagerSilenceClient) observe(operation string, start time.Time, err *error) {
+	m := ams.Metrics
+	if m == nil {
+		m = defaultSilenceMetrics
+	}
+	m.ObserveSilenceOperation(operation, *err, time.Since(start))
+}
@@ -0,0 +1,79 @@
+package alertmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingSilenceMetrics counts observed operations by operation and outcome
+type recordingSilenceMetrics struct {
+	observed map[string]int
+}
+
+func (m *recordingSilenceMetrics) ObserveSilenceOperation(operation string, err error, duration time.Duration) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeFailure
+	}
+	m.observed[operation+"/"+outcome]++
+}
+
+var _ = Describe("Silence client metrics", func() {
+	var (
+		fam           *fakeAlertmanager
+		silenceClient *AlertManagerSilenceClient
+		recorder      *recordingSilenceMetrics
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+	)
+
+	BeforeEach(func() {
+		fam = newFakeAlertmanager()
+		recorder = &recordingSilenceMetrics{observed: map[string]int{}}
+		silenceClient = newTestSilenceClient(fam.server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+		silenceClient.Metrics = recorder
+	})
+
+	AfterEach(func() {
+		fam.Close()
+	})
+
+	It("Should record each operation and its outcome", func() {
+		Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")).To(Succeed())
+		Expect(silenceClient.Create(context.TODO(), amv2Models.Matchers{}, testNow, testEnd, "tester", "test comment")).NotTo(Succeed())
+		silences, err := silenceClient.ListSilences(context.TODO(), []string{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(silenceClient.Update(context.TODO(), *silences[0].ID, testEnd)).To(Succeed())
+		Expect(silenceClient.Delete(context.TODO(), *silences[0].ID)).To(Succeed())
+
+		Expect(recorder.observed).To(Equal(map[string]int{
+			"create/success": 1,
+			"create/failure": 1,
+			"list/success":   1,
+			"update/success": 1,
+			"delete/success": 1,
+		}))
+	})
+
+	It("Should increment the Prometheus collectors", func() {
+		operations := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_operations"}, []string{operationLabel, outcomeLabel})
+		duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_duration"}, []string{operationLabel})
+		silenceClient.Metrics = &prometheusSilenceMetrics{operations: operations, duration: duration}
+
+		Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")).To(Succeed())
+		Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")).To(Succeed())
+
+		Expect(testutil.ToFloat64(operations.WithLabelValues(operationCreate, outcomeSuccess))).To(Equal(float64(2)))
+		Expect(testutil.ToFloat64(operations.WithLabelValues(operationCreate, outcomeFailure))).To(Equal(float64(0)))
+	})
+})
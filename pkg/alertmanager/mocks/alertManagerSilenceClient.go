@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	strfmt "github.com/go-openapi/strfmt"
 	gomock "github.com/golang/mock/gomock"
 	alertmanager "github.com/openshift/managed-upgrade-operator/pkg/alertmanager"
@@ -36,39 +37,140 @@ func (m *MockAlertManagerSilencer) EXPECT() *MockAlertManagerSilencerMockRecorde
 	return m.recorder
 }
 
+// Count mocks base method
+func (m *MockAlertManagerSilencer) Count(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Count", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count
+func (mr *MockAlertManagerSilencerMockRecorder) Count(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Count), varargs...)
+}
+
 // Create mocks base method
-func (m *MockAlertManagerSilencer) Create(arg0 models.Matchers, arg1, arg2 strfmt.DateTime, arg3, arg4 string) error {
+func (m *MockAlertManagerSilencer) Create(arg0 context.Context, arg1 models.Matchers, arg2, arg3 strfmt.DateTime, arg4, arg5 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create
-func (mr *MockAlertManagerSilencerMockRecorder) Create(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockAlertManagerSilencerMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateBatch mocks base method
+func (m *MockAlertManagerSilencer) CreateBatch(arg0 context.Context, arg1 []alertmanager.SilenceSpec) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch
+func (mr *MockAlertManagerSilencerMockRecorder) CreateBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockAlertManagerSilencer)(nil).CreateBatch), arg0, arg1)
+}
+
+// CreateOrUpdate mocks base method
+func (m *MockAlertManagerSilencer) CreateOrUpdate(arg0 context.Context, arg1 models.Matchers, arg2, arg3 strfmt.DateTime, arg4, arg5 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdate indicates an expected call of CreateOrUpdate
+func (mr *MockAlertManagerSilencerMockRecorder) CreateOrUpdate(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockAlertManagerSilencer)(nil).CreateOrUpdate), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateWithID mocks base method
+func (m *MockAlertManagerSilencer) CreateWithID(arg0 context.Context, arg1 string, arg2 models.Matchers, arg3, arg4 strfmt.DateTime, arg5, arg6 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithID", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithID indicates an expected call of CreateWithID
+func (mr *MockAlertManagerSilencerMockRecorder) CreateWithID(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Create), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithID", reflect.TypeOf((*MockAlertManagerSilencer)(nil).CreateWithID), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // Delete mocks base method
-func (m *MockAlertManagerSilencer) Delete(arg0 string) error {
+func (m *MockAlertManagerSilencer) Delete(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockAlertManagerSilencerMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+func (mr *MockAlertManagerSilencerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Delete), arg0, arg1)
+}
+
+// DeleteByFilter mocks base method
+func (m *MockAlertManagerSilencer) DeleteByFilter(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteByFilter", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByFilter indicates an expected call of DeleteByFilter
+func (mr *MockAlertManagerSilencerMockRecorder) DeleteByFilter(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Delete), arg0)
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFilter", reflect.TypeOf((*MockAlertManagerSilencer)(nil).DeleteByFilter), varargs...)
+}
+
+// Exists mocks base method
+func (m *MockAlertManagerSilencer) Exists(arg0 context.Context, arg1 models.Matchers, arg2 string) (bool, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists
+func (mr *MockAlertManagerSilencerMockRecorder) Exists(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Exists), arg0, arg1, arg2)
 }
 
 // Filter mocks base method
-func (m *MockAlertManagerSilencer) Filter(arg0 ...alertmanager.SilencePredicate) (*[]models.GettableSilence, error) {
+func (m *MockAlertManagerSilencer) Filter(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (*[]models.GettableSilence, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range arg0 {
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "Filter", varargs...)
@@ -78,36 +180,156 @@ func (m *MockAlertManagerSilencer) Filter(arg0 ...alertmanager.SilencePredicate)
 }
 
 // Filter indicates an expected call of Filter
-func (mr *MockAlertManagerSilencerMockRecorder) Filter(arg0 ...interface{}) *gomock.Call {
+func (mr *MockAlertManagerSilencerMockRecorder) Filter(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Filter), arg0...)
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Filter), varargs...)
+}
+
+// FilterActive mocks base method
+func (m *MockAlertManagerSilencer) FilterActive(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (*[]models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterActive", varargs...)
+	ret0, _ := ret[0].(*[]models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterActive indicates an expected call of FilterActive
+func (mr *MockAlertManagerSilencerMockRecorder) FilterActive(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterActive", reflect.TypeOf((*MockAlertManagerSilencer)(nil).FilterActive), varargs...)
+}
+
+// FilterDistinct mocks base method
+func (m *MockAlertManagerSilencer) FilterDistinct(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (*[]models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterDistinct", varargs...)
+	ret0, _ := ret[0].(*[]models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterDistinct indicates an expected call of FilterDistinct
+func (mr *MockAlertManagerSilencerMockRecorder) FilterDistinct(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterDistinct", reflect.TypeOf((*MockAlertManagerSilencer)(nil).FilterDistinct), varargs...)
+}
+
+// FilterPending mocks base method
+func (m *MockAlertManagerSilencer) FilterPending(arg0 context.Context, arg1 ...alertmanager.SilencePredicate) (*[]models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterPending", varargs...)
+	ret0, _ := ret[0].(*[]models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterPending indicates an expected call of FilterPending
+func (mr *MockAlertManagerSilencerMockRecorder) FilterPending(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterPending", reflect.TypeOf((*MockAlertManagerSilencer)(nil).FilterPending), varargs...)
+}
+
+// GetByID mocks base method
+func (m *MockAlertManagerSilencer) GetByID(arg0 context.Context, arg1 string) (*models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID
+func (mr *MockAlertManagerSilencerMockRecorder) GetByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAlertManagerSilencer)(nil).GetByID), arg0, arg1)
+}
+
+// Healthy mocks base method
+func (m *MockAlertManagerSilencer) Healthy(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Healthy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Healthy indicates an expected call of Healthy
+func (mr *MockAlertManagerSilencerMockRecorder) Healthy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthy", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Healthy), arg0)
 }
 
 // List mocks base method
-func (m *MockAlertManagerSilencer) List(arg0 []string) (*silence.GetSilencesOK, error) {
+func (m *MockAlertManagerSilencer) List(arg0 context.Context, arg1 []string) (*silence.GetSilencesOK, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0)
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
 	ret0, _ := ret[0].(*silence.GetSilencesOK)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List
-func (mr *MockAlertManagerSilencerMockRecorder) List(arg0 interface{}) *gomock.Call {
+func (mr *MockAlertManagerSilencerMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlertManagerSilencer)(nil).List), arg0, arg1)
+}
+
+// ListByMatchers mocks base method
+func (m *MockAlertManagerSilencer) ListByMatchers(arg0 context.Context, arg1 models.Matchers) ([]*models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMatchers", arg0, arg1)
+	ret0, _ := ret[0].([]*models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMatchers indicates an expected call of ListByMatchers
+func (mr *MockAlertManagerSilencerMockRecorder) ListByMatchers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMatchers", reflect.TypeOf((*MockAlertManagerSilencer)(nil).ListByMatchers), arg0, arg1)
+}
+
+// ListSilences mocks base method
+func (m *MockAlertManagerSilencer) ListSilences(arg0 context.Context, arg1 []string) ([]*models.GettableSilence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSilences", arg0, arg1)
+	ret0, _ := ret[0].([]*models.GettableSilence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSilences indicates an expected call of ListSilences
+func (mr *MockAlertManagerSilencerMockRecorder) ListSilences(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlertManagerSilencer)(nil).List), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSilences", reflect.TypeOf((*MockAlertManagerSilencer)(nil).ListSilences), arg0, arg1)
 }
 
 // Update mocks base method
-func (m *MockAlertManagerSilencer) Update(arg0 string, arg1 strfmt.DateTime) error {
+func (m *MockAlertManagerSilencer) Update(arg0 context.Context, arg1 string, arg2 strfmt.DateTime) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update
-func (mr *MockAlertManagerSilencerMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAlertManagerSilencerMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAlertManagerSilencer)(nil).Update), arg0, arg1, arg2)
 }
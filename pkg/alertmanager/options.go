@@ -0,0 +1,115 @@
+package alertmanager
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// Option configures an AlertManagerSilenceClient
+type Option func(*AlertManagerSilenceClient)
+
+// NewAlertManagerSilenceClient returns a silence client for the Alertmanager instance defined in transport
+func NewAlertManagerSilenceClient(transport *httptransport.Runtime, opts ...Option) *AlertManagerSilenceClient {
+	ams := &AlertManagerSilenceClient{Transport: transport}
+	for _, opt := range opts {
+		opt(ams)
+	}
+	return ams
+}
+
+// WithTLSConfig sets the TLS configuration used when connecting to Alertmanager
+func WithTLSConfig(config *tls.Config) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.TLSConfig = config
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for all requests, overriding WithTLSConfig
+func WithHTTPClient(client *http.Client) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.HTTPClient = client
+	}
+}
+
+// WithProxy sets the function used to select a proxy for each request
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.Proxy = proxy
+	}
+}
+
+// WithBearerToken sets a static bearer token sent with every request
+func WithBearerToken(token string) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.BearerToken = token
+	}
+}
+
+// WithTokenSource sets a function consulted for the bearer token on every request
+func WithTokenSource(source func() (string, error)) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.TokenSource = source
+	}
+}
+
+// WithRetry sets the retry policy for Create, Delete and Update
+func WithRetry(policy RetryPolicy) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.RetryPolicy = &policy
+	}
+}
+
+// WithLogger sets the logger that silence changes are logged to
+func WithLogger(logger logr.Logger) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.Logger = logger
+	}
+}
+
+// WithMetrics sets the collector that silence operations are recorded to
+func WithMetrics(metrics SilenceMetrics) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.Metrics = metrics
+	}
+}
+
+// WithRequestTimeout bounds each request made to Alertmanager
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.RequestTimeout = timeout
+	}
+}
+
+// WithMaxConcurrency bounds the number of silences CreateBatch creates at once
+func WithMaxConcurrency(max int) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.MaxConcurrency = max
+	}
+}
+
+// WithFormats sets the strfmt registry used when encoding and decoding silences
+func WithFormats(formats strfmt.Registry) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.Formats = formats
+	}
+}
+
+// WithCreator sets the creator recorded on silences created without one
+func WithCreator(creator string) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.Creator = creator
+	}
+}
+
+// WithRedirectPolicy sets the policy deciding which redirects from Alertmanager are followed
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(ams *AlertManagerSilenceClient) {
+		ams.RedirectPolicy = policy
+	}
+}
@@ -0,0 +1,115 @@
+package alertmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewAlertManagerSilenceClient", func() {
+	var (
+		fam       *fakeAlertmanager
+		transport *httptransport.Runtime
+	)
+
+	BeforeEach(func() {
+		fam = newFakeAlertmanager()
+		u, _ := url.Parse(fam.server.URL)
+		transport = httptransport.New(u.Host, "/api/v2/", []string{u.Scheme})
+	})
+
+	AfterEach(func() {
+		fam.Close()
+	})
+
+	It("Should build a usable client without options", func() {
+		ams := NewAlertManagerSilenceClient(transport)
+		Expect(ams.Transport).To(Equal(transport))
+		_, err := ams.ListSilences(context.TODO(), []string{})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("Should apply WithTLSConfig", func() {
+		config := &tls.Config{}
+		Expect(NewAlertManagerSilenceClient(transport, WithTLSConfig(config)).TLSConfig).To(BeIdenticalTo(config))
+	})
+
+	It("Should apply WithHTTPClient", func() {
+		client := &http.Client{}
+		ams := NewAlertManagerSilenceClient(transport, WithHTTPClient(client))
+		Expect(ams.httpClient()).To(BeIdenticalTo(client))
+	})
+
+	It("Should apply WithBearerToken and WithTokenSource", func() {
+		var headers []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = append(headers, r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("[]"))
+		}))
+		defer server.Close()
+		u, _ := url.Parse(server.URL)
+		serverTransport := httptransport.New(u.Host, "/api/v2/", []string{u.Scheme})
+
+		_, err := NewAlertManagerSilenceClient(serverTransport, WithBearerToken("static")).ListSilences(context.TODO(), []string{})
+		Expect(err).ShouldNot(HaveOccurred())
+		source := func() (string, error) { return "rotated", nil }
+		_, err = NewAlertManagerSilenceClient(serverTransport, WithTokenSource(source)).ListSilences(context.TODO(), []string{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(headers).To(Equal([]string{"Bearer static", "Bearer rotated"}))
+	})
+
+	It("Should apply WithRetry", func() {
+		policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+		ams := NewAlertManagerSilenceClient(transport, WithRetry(policy))
+		Expect(ams.retryPolicy()).To(Equal(policy))
+		Expect(NewAlertManagerSilenceClient(transport).retryPolicy()).To(Equal(DefaultRetryPolicy))
+	})
+
+	It("Should apply WithLogger and WithMetrics", func() {
+		lines := []string{}
+		recorder := &recordingSilenceMetrics{observed: map[string]int{}}
+		ams := NewAlertManagerSilenceClient(transport, WithLogger(recordingLogger{lines: &lines}), WithMetrics(recorder))
+
+		now := strfmt.DateTime(time.Now().UTC())
+		end := strfmt.DateTime(time.Now().UTC().Add(time.Hour))
+		matchers := amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+		Expect(ams.Create(context.TODO(), matchers, now, end, "tester", "test comment")).To(Succeed())
+		Expect(lines).To(HaveLen(1))
+		Expect(recorder.observed).To(HaveKeyWithValue("create/success", 1))
+	})
+	It("Should apply WithRequestTimeout", func() {
+		Expect(NewAlertManagerSilenceClient(transport, WithRequestTimeout(time.Second)).RequestTimeout).To(Equal(time.Second))
+	})
+
+	It("Should apply WithFormats", func() {
+		formats := strfmt.NewFormats()
+		ams := NewAlertManagerSilenceClient(transport, WithFormats(formats))
+		Expect(ams.formats()).To(BeIdenticalTo(formats))
+		Expect(NewAlertManagerSilenceClient(transport).formats()).To(BeIdenticalTo(strfmt.Default))
+
+		now := strfmt.DateTime(time.Now().UTC())
+		end := strfmt.DateTime(time.Now().UTC().Add(time.Hour))
+		matchers := amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+		Expect(ams.Create(context.TODO(), matchers, now, end, "tester", "test comment")).To(Succeed())
+		silences, err := ams.ListSilences(context.TODO(), []string{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(silences).To(HaveLen(1))
+	})
+
+	It("Should apply WithCreator", func() {
+		ams := NewAlertManagerSilenceClient(transport, WithCreator("muo-pod"))
+		Expect(ams.creator("")).To(Equal("muo-pod"))
+		Expect(ams.creator("tester")).To(Equal("tester"))
+	})
+})
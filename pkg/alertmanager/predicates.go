@@ -0,0 +1,57 @@
+package alertmanager
+
+import (
+	"strings"
+	"time"
+
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// WithComment matches silences whose comment contains substr
+func WithComment(substr string) SilencePredicate {
+	return func(s *amv2Models.GettableSilence) bool {
+		return s.Comment != nil && strings.Contains(*s.Comment, substr)
+	}
+}
+
+// CreatedBy matches silences created by name
+func CreatedBy(name string) SilencePredicate {
+	return func(s *amv2Models.GettableSilence) bool {
+		return s.CreatedBy != nil && *s.CreatedBy == name
+	}
+}
+
+// IsActive matches silences that are currently active
+func IsActive() SilencePredicate {
+	return hasState(amv2Models.SilenceStatusStateActive)
+}
+
+// IsPending matches silences that have not started yet
+func IsPending() SilencePredicate {
+	return hasState(amv2Models.SilenceStatusStatePending)
+}
+
+// ExpiringBefore matches silences that end before t
+func ExpiringBefore(t time.Time) SilencePredicate {
+	return func(s *amv2Models.GettableSilence) bool {
+		return s.EndsAt != nil && time.Time(*s.EndsAt).Before(t)
+	}
+}
+
+// HasMatcher matches silences with a matcher for the label name and value
+func HasMatcher(name, value string) SilencePredicate {
+	return func(s *amv2Models.GettableSilence) bool {
+		for _, m := range s.Matchers {
+			if m != nil && m.Name != nil && m.Value != nil && *m.Name == name && *m.Value == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func hasState(state string) SilencePredicate {
+	return func(s *amv2Models.GettableSilence) bool {
+		return s.Status != nil && s.Status.State != nil && *s.Status.State == state
+	}
+}
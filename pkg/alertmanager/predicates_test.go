@@ -0,0 +1,89 @@
+package alertmanager
+
+import (
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newTestSilence builds a GettableSilence fixture
+func newTestSilence(id, state, creator, comment string, endsAt time.Time, matchers ...*amv2Models.Matcher) *amv2Models.GettableSilence {
+	end := strfmt.DateTime(endsAt)
+	start := strfmt.DateTime(endsAt.Add(-time.Hour))
+	return &amv2Models.GettableSilence{
+		ID:     &id,
+		Status: &amv2Models.SilenceStatus{State: &state},
+		Silence: amv2Models.Silence{
+			CreatedBy: &creator,
+			Comment:   &comment,
+			StartsAt:  &start,
+			EndsAt:    &end,
+			Matchers:  matchers,
+		},
+	}
+}
+
+func newTestMatcher(name, value string, isRegex bool) *amv2Models.Matcher {
+	return &amv2Models.Matcher{Name: &name, Value: &value, IsRegex: &isRegex}
+}
+
+var _ = Describe("Silence predicates", func() {
+	var (
+		now      = time.Now()
+		active   = newTestSilence("active", amv2Models.SilenceStatusStateActive, "managed-upgrade-operator", "Silence for OSD control plane upgrade", now.Add(time.Hour), newTestMatcher("severity", "warning", false))
+		pending  = newTestSilence("pending", amv2Models.SilenceStatusStatePending, "managed-upgrade-operator", "Silence for OSD worker node upgrade", now.Add(3*time.Hour))
+		expired  = newTestSilence("expired", amv2Models.SilenceStatusStateExpired, "someone", "manual silence", now.Add(-time.Hour), newTestMatcher("alertname", "Watchdog", false))
+		fixtures = []*amv2Models.GettableSilence{active, pending, expired}
+	)
+
+	matching := func(p SilencePredicate) []string {
+		ids := []string{}
+		for _, s := range fixtures {
+			if p(s) {
+				ids = append(ids, *s.ID)
+			}
+		}
+		return ids
+	}
+
+	It("WithComment should match a comment substring", func() {
+		Expect(matching(WithComment("OSD"))).To(Equal([]string{"active", "pending"}))
+		Expect(matching(WithComment("worker"))).To(Equal([]string{"pending"}))
+		Expect(matching(WithComment("nothing"))).To(BeEmpty())
+	})
+
+	It("CreatedBy should match the exact creator", func() {
+		Expect(matching(CreatedBy("managed-upgrade-operator"))).To(Equal([]string{"active", "pending"}))
+		Expect(matching(CreatedBy("some"))).To(BeEmpty())
+	})
+
+	It("IsActive should only match active silences", func() {
+		Expect(matching(IsActive())).To(Equal([]string{"active"}))
+	})
+
+	It("IsPending should only match pending silences", func() {
+		Expect(matching(IsPending())).To(Equal([]string{"pending"}))
+	})
+
+	It("ExpiringBefore should match silences ending before the time", func() {
+		Expect(matching(ExpiringBefore(now))).To(Equal([]string{"expired"}))
+		Expect(matching(ExpiringBefore(now.Add(2 * time.Hour)))).To(Equal([]string{"active", "expired"}))
+	})
+
+	It("HasMatcher should match on the label name and value", func() {
+		Expect(matching(HasMatcher("severity", "warning"))).To(Equal([]string{"active"}))
+		Expect(matching(HasMatcher("alertname", "Watchdog"))).To(Equal([]string{"expired"}))
+		Expect(matching(HasMatcher("severity", "critical"))).To(BeEmpty())
+	})
+
+	It("Should not panic on silences with missing fields", func() {
+		empty := &amv2Models.GettableSilence{}
+		for _, p := range []SilencePredicate{WithComment(""), CreatedBy(""), IsActive(), IsPending(), ExpiringBefore(now), HasMatcher("", "")} {
+			Expect(p(empty)).To(BeFalse())
+		}
+	})
+})
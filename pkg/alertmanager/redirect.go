@@ -0,0 +1,104 @@
+package alertmanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// RedirectPolicy decides whether a redirect from Alertmanager is followed, with the same
+// semantics as http.Client.CheckRedirect. req is the upcoming request and via the requests
+// made so far, oldest first.
+type RedirectPolicy func(req *http.Request, via []*http.Request) error
+
+// maxRedirects matches the limit net/http applies by default
+const maxRedirects = 10
+
+// DefaultRedirectPolicy follows up to 10 redirects
+func DefaultRedirectPolicy(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
+}
+
+func (ams *AlertManagerSilenceClient) redirectPolicy() RedirectPolicy {
+	if ams.RedirectPolicy != nil {
+		return ams.RedirectPolicy
+	}
+	return DefaultRedirectPolicy
+}
+
+// redirectTransport follows 307 and 308 redirects itself, re-sending the request body.
+// The OpenAPI runtime writes the body after building the request, so net/http is left
+// unable to replay it and would send the redirected silence without one.
+type redirectTransport struct {
+	next   http.RoundTripper
+	policy RedirectPolicy
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	via := []*http.Request{}
+	for {
+		out := withBody(req, body)
+		resp, err := t.next.RoundTrip(out)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTemporaryRedirect && resp.StatusCode != http.StatusPermanentRedirect {
+			return resp, nil
+		}
+
+		location, err := resp.Location()
+		if err != nil {
+			// Nowhere to go, so let the caller see the redirect
+			return resp, nil
+		}
+		next := req.Clone(req.Context())
+		next.URL = location
+		next.Host = ""
+		if location.Host != req.URL.Host {
+			// As net/http does, don't hand credentials to a different host
+			next.Header.Del("Authorization")
+		}
+
+		via = append(via, out)
+		if err := t.policy(next, via); err != nil {
+			if err == http.ErrUseLastResponse {
+				return resp, nil
+			}
+			resp.Body.Close()
+			return nil, err
+		}
+
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		req = next
+	}
+}
+
+// withBody returns a copy of req that will send body
+func withBody(req *http.Request, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	if body == nil {
+		return out
+	}
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return out
+}
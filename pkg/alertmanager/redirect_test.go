@@ -0,0 +1,85 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Silence client redirects", func() {
+	var (
+		follower      *httptest.Server
+		leader        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		redirectCode  int
+		leaderBodies  []string
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+	)
+
+	BeforeEach(func() {
+		leaderBodies = []string{}
+		leader = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			leaderBodies = append(leaderBodies, string(body))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"silenceID":"test-id"}`))
+		}))
+		follower = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, leader.URL+r.URL.Path, redirectCode)
+		}))
+		silenceClient = newTestSilenceClient(follower)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	})
+
+	AfterEach(func() {
+		follower.Close()
+		leader.Close()
+	})
+
+	for _, code := range []int{http.StatusTemporaryRedirect, http.StatusPermanentRedirect} {
+		code := code
+
+		It("Should re-send the silence when redirected with "+http.StatusText(code), func() {
+			redirectCode = code
+			Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")).To(Succeed())
+			Expect(leaderBodies).To(HaveLen(1))
+			Expect(leaderBodies[0]).To(ContainSubstring(`"comment":"test comment"`))
+			Expect(leaderBodies[0]).To(ContainSubstring(`"name":"alertname"`))
+		})
+	}
+
+	It("Should not follow a redirect the policy rejects", func() {
+		redirectCode = http.StatusTemporaryRedirect
+		rejected := errors.New("redirects disabled")
+		silenceClient.RedirectPolicy = func(req *http.Request, via []*http.Request) error {
+			return rejected
+		}
+
+		err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+		Expect(errors.Is(err, rejected)).To(BeTrue(), "unexpected error: %v", err)
+		Expect(leaderBodies).To(BeEmpty())
+	})
+
+	It("Should stop after too many redirects", func() {
+		// Redirect back to the same path, forever
+		loop := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, r.URL.Path, http.StatusTemporaryRedirect)
+		}))
+		defer loop.Close()
+		silenceClient = newTestSilenceClient(loop)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+
+		Expect(silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")).NotTo(Succeed())
+	})
+})
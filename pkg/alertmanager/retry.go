@@ -0,0 +1,73 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// RetryPolicy controls how silence operations are retried when Alertmanager is briefly unavailable
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubling on each subsequent retry
+	BaseDelay time.Duration
+	// Jitter is the fraction by which each delay is randomly varied
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used when a client has no RetryPolicy configured
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   2 * time.Second,
+	Jitter:      0.5,
+}
+
+func (ams *AlertManagerSilenceClient) retryPolicy() RetryPolicy {
+	if ams.RetryPolicy != nil {
+		return *ams.RetryPolicy
+	}
+	return DefaultRetryPolicy
+}
+
+// do runs f until it succeeds, returns a non-retryable error, the attempts are exhausted
+// or the context is done
+func (p RetryPolicy) do(ctx context.Context, f func() error) error {
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(withJitter(delay, p.Jitter)):
+		}
+		delay = delay * 2
+	}
+}
+
+// isRetryable returns true for network errors and 5xx responses from Alertmanager
+func isRetryable(err error) bool {
+	if code, ok := statusCode(err); ok {
+		return code >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// withJitter randomly varies t by up to factor of it, never returning a negative delay
+func withJitter(t time.Duration, factor float64) time.Duration {
+	if factor <= 0 || t <= 0 {
+		return t
+	}
+	min := int64(math.Max(0, math.Floor(float64(t)*(1-factor))))
+	max := int64(math.Ceil(float64(t) * (1 + factor)))
+	return time.Duration(rand.Int63n(max-min) + min)
+}
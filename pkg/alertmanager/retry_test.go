@@ -0,0 +1,97 @@
+package alertmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Silence client retries", func() {
+	var (
+		server        *httptest.Server
+		silenceClient *AlertManagerSilenceClient
+		attempts      int
+		failures      int
+		failureCode   int
+		testMatchers  = amv2Models.Matchers{newTestMatcher("alertname", "Watchdog", false)}
+		testNow       = strfmt.DateTime(time.Now().UTC())
+		testEnd       = strfmt.DateTime(time.Now().UTC().Add(90 * time.Minute))
+	)
+
+	BeforeEach(func() {
+		attempts = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Content-Type", "application/json")
+			if attempts <= failures {
+				w.WriteHeader(failureCode)
+				_, _ = w.Write([]byte(`"fake error"`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"silenceID":"test-id"}`))
+		}))
+		silenceClient = newTestSilenceClient(server)
+		silenceClient.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When Alertmanager returns transient errors", func() {
+		It("Should succeed after two failures", func() {
+			failures, failureCode = 2, http.StatusBadGateway
+			err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("Should give up once the attempts are exhausted", func() {
+			failures, failureCode = 3, http.StatusServiceUnavailable
+			err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+			Expect(err).Should(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+	})
+
+	Context("When varying the delay between retries", func() {
+		It("Should keep the delay within the jitter factor of it", func() {
+			for i := 0; i < 100; i++ {
+				delay := withJitter(time.Second, 0.5)
+				Expect(delay).To(BeNumerically(">=", 500*time.Millisecond))
+				Expect(delay).To(BeNumerically("<=", 1500*time.Millisecond))
+			}
+		})
+
+		It("Should never return a negative delay for a factor of one or more", func() {
+			for _, factor := range []float64{1, 1.5, 10} {
+				for i := 0; i < 100; i++ {
+					delay := withJitter(time.Second, factor)
+					Expect(delay).To(BeNumerically(">=", 0), "factor %v", factor)
+					Expect(delay).To(BeNumerically("<=", time.Duration(float64(time.Second)*(1+factor))), "factor %v", factor)
+				}
+			}
+		})
+
+		It("Should not vary the delay without a jitter factor", func() {
+			Expect(withJitter(time.Second, 0)).To(Equal(time.Second))
+			Expect(withJitter(time.Second, -1)).To(Equal(time.Second))
+		})
+	})
+
+	Context("When Alertmanager rejects the request", func() {
+		It("Should not retry on a 4xx response", func() {
+			failures, failureCode = 1, http.StatusBadRequest
+			err := silenceClient.Create(context.TODO(), testMatchers, testNow, testEnd, "tester", "test comment")
+			Expect(err).Should(HaveOccurred())
+			Expect(attempts).To(Equal(1))
+		})
+	})
+})
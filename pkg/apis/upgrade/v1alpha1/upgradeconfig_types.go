@@ -35,6 +35,10 @@ type UpgradeConfigSpec struct {
 
 	// Specify if scaling up an extra node for capacity reservation before upgrade starts is needed
 	CapacityReservation bool `json:"capacityReservation,omitempty"`
+
+	// Priority of this UpgradeConfig over others in the namespace. The UpgradeConfig with the highest priority is acted upon, then the one with the earliest upgradeAt.
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // UpgradeConfigStatus defines the observed state of UpgradeConfig
@@ -52,7 +56,7 @@ type UpgradeHistories []UpgradeHistory
 type UpgradeHistory struct {
 	//Desired version of this upgrade
 	Version string `json:"version,omitempty"`
-	// +kubebuilder:validation:Enum={"New","Pending","Upgrading","Upgraded", "Failed"}
+	// +kubebuilder:validation:Enum={"New","Pending","Upgrading","Upgraded", "Failed", "Cancelled"}
 	// This describe the status of the upgrade process
 	Phase UpgradePhase `json:"phase"`
 
@@ -67,6 +71,23 @@ type UpgradeHistory struct {
 	WorkerStartTime *metav1.Time `json:"workerStartTime,omitempty"`
 
 	WorkerCompleteTime *metav1.Time `json:"workerCompleteTime,omitempty"`
+
+	// Progress reports the step the upgrade is currently performing
+	// +kubebuilder:validation:Optional
+	Progress *UpgradeProgress `json:"progress,omitempty"`
+}
+
+// UpgradeProgress reports the step an upgrade is currently performing out of all the steps it performs
+type UpgradeProgress struct {
+	// Name of the step currently being performed
+	Step UpgradeConditionType `json:"step"`
+	// Position of the step amongst the steps of the upgrade, counting from 1
+	StepIndex int `json:"stepIndex"`
+	// Number of steps the upgrade performs
+	TotalSteps int `json:"totalSteps"`
+	// Time the upgrade reached the step
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
 }
 
 // UpgradeConditionType is a Go string type.
@@ -101,22 +122,35 @@ type UpgradeCondition struct {
 const (
 	SendStartedNotification       UpgradeConditionType = "SendStartedNotification"
 	UpgradeDelayedCheck           UpgradeConditionType = "UpgradeDelayedCheck"
+	MaintenanceWindowPermitted    UpgradeConditionType = "MaintenanceWindowPermitted"
 	UpgradeValidated              UpgradeConditionType = "Validation"
 	UpgradePreHealthCheck         UpgradeConditionType = "PreHealthCheck"
 	ExtDepAvailabilityCheck       UpgradeConditionType = "ExternalDependencyAvailabilityCheck"
+	EtcdBackupVerified            UpgradeConditionType = "EtcdBackupVerified"
+	PodDisruptionBudgetsChecked   UpgradeConditionType = "PodDisruptionBudgetsChecked"
+	PreUpgradeHookApproved        UpgradeConditionType = "PreUpgradeHookApproved"
 	UpgradeScaleUpExtraNodes      UpgradeConditionType = "ScaleUpExtraNodes"
+	WorkerMaxUnavailableSet       UpgradeConditionType = "WorkerMaxUnavailableSet"
+	PauseWorkerPool               UpgradeConditionType = "PauseWorkerPool"
 	ControlPlaneMaintWindow       UpgradeConditionType = "ControlPlaneMaintWindow"
 	CommenceUpgrade               UpgradeConditionType = "CommenceUpgrade"
 	ControlPlaneUpgraded          UpgradeConditionType = "ControlPlaneUpgraded"
+	ResumeWorkerPool              UpgradeConditionType = "ResumeWorkerPool"
 	RemoveControlPlaneMaintWindow UpgradeConditionType = "RemoveControlPlaneMaintWindow"
 	WorkersMaintWindow            UpgradeConditionType = "WorkersMaintWindow"
 	AllWorkerNodesUpgraded        UpgradeConditionType = "AllWorkerNodesUpgraded"
+	WorkerNodesUncordoned         UpgradeConditionType = "WorkerNodesUncordoned"
 	RemoveExtraScaledNodes        UpgradeConditionType = "RemoveExtraScaledNodes"
+	WorkerMaxUnavailableRestored  UpgradeConditionType = "WorkerMaxUnavailableRestored"
 	UpdateSubscriptions           UpgradeConditionType = "UpdateSubscriptions"
 	PostUpgradeVerification       UpgradeConditionType = "PostUpgradeVerification"
+	ClusterOperatorsAvailable     UpgradeConditionType = "ClusterOperatorsAvailable"
 	RemoveMaintWindow             UpgradeConditionType = "RemoveMaintWindow"
 	PostClusterHealthCheck        UpgradeConditionType = "PostClusterHealthCheck"
 	SendCompletedNotification     UpgradeConditionType = "SendCompletedNotification"
+	UpgradeConfigSelected         UpgradeConditionType = "UpgradeConfigSelected"
+	UpgradeCancelled              UpgradeConditionType = "UpgradeCancelled"
+	UpgradeDryRun                 UpgradeConditionType = "UpgradeDryRun"
 )
 
 // UpgradePhase is a Go string type.
@@ -132,6 +166,8 @@ const (
 	UpgradePhaseUpgraded UpgradePhase = "Upgraded"
 	// UpgradePhaseFailed defines a failed upgrade.
 	UpgradePhaseFailed UpgradePhase = "Failed"
+	// UpgradePhaseCancelled defines an upgrade cancelled before it commenced.
+	UpgradePhaseCancelled UpgradePhase = "Cancelled"
 	// UpgradePhaseUnknown defines an unknown upgrade state.
 	UpgradePhaseUnknown UpgradePhase = "Unknown"
 )
@@ -147,6 +183,9 @@ const (
 // +kubebuilder:printcolumn:name="status",type="string",JSONPath=".status.history[0].conditions[0].status"
 // +kubebuilder:printcolumn:name="reason",type="string",JSONPath=".status.history[0].conditions[0].reason"
 // +kubebuilder:printcolumn:name="message",type="string",JSONPath=".status.history[0].conditions[0].message"
+// +kubebuilder:printcolumn:name="step",type="integer",JSONPath=".status.history[0].progress.stepIndex",priority=1
+// +kubebuilder:printcolumn:name="total_steps",type="integer",JSONPath=".status.history[0].progress.totalSteps",priority=1
+// +kubebuilder:printcolumn:name="step_started",type="date",JSONPath=".status.history[0].progress.startTime",priority=1
 type UpgradeConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -16,6 +16,12 @@ import (
 //go:generate mockgen -destination=mocks/cluster_upgrader.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/cluster_upgrader_builder ClusterUpgrader
 type ClusterUpgrader interface {
 	UpgradeCluster(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, *upgradev1alpha1.UpgradeCondition, error)
+	// CleanupUpgrade undoes the changes an upgrade underway has made to the cluster, such as when
+	// its UpgradeConfig is deleted
+	CleanupUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) error
+	// PlanUpgrade returns the ordered steps the upgrade would perform and the results of the
+	// pre-flight checks, without making any changes to the cluster
+	PlanUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) ([]upgradev1alpha1.UpgradeConditionType, upgradev1alpha1.Conditions, error)
 }
 
 //go:generate mockgen -destination=mocks/cluster_upgrader_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/cluster_upgrader_builder ClusterUpgraderBuilder
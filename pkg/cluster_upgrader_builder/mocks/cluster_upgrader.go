@@ -34,6 +34,36 @@ func (m *MockClusterUpgrader) EXPECT() *MockClusterUpgraderMockRecorder {
 	return m.recorder
 }
 
+// CleanupUpgrade mocks base method
+func (m *MockClusterUpgrader) CleanupUpgrade(arg0 *v1alpha1.UpgradeConfig, arg1 logr.Logger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupUpgrade", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupUpgrade indicates an expected call of CleanupUpgrade
+func (mr *MockClusterUpgraderMockRecorder) CleanupUpgrade(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupUpgrade", reflect.TypeOf((*MockClusterUpgrader)(nil).CleanupUpgrade), arg0, arg1)
+}
+
+// PlanUpgrade mocks base method
+func (m *MockClusterUpgrader) PlanUpgrade(arg0 *v1alpha1.UpgradeConfig, arg1 logr.Logger) ([]v1alpha1.UpgradeConditionType, v1alpha1.Conditions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanUpgrade", arg0, arg1)
+	ret0, _ := ret[0].([]v1alpha1.UpgradeConditionType)
+	ret1, _ := ret[1].(v1alpha1.Conditions)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PlanUpgrade indicates an expected call of PlanUpgrade
+func (mr *MockClusterUpgraderMockRecorder) PlanUpgrade(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanUpgrade", reflect.TypeOf((*MockClusterUpgrader)(nil).PlanUpgrade), arg0, arg1)
+}
+
 // UpgradeCluster mocks base method
 func (m *MockClusterUpgrader) UpgradeCluster(arg0 *v1alpha1.UpgradeConfig, arg1 logr.Logger) (v1alpha1.UpgradePhase, *v1alpha1.UpgradeCondition, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +268,49 @@ var _ = Describe("ClusterVersion client and utils", func() {
 					Expect(len(result.Degraded)).To(Equal(2))
 				})
 			})
+
+			Context("When operators are in mixed states", func() {
+				operator := func(name string, conditions ...configv1.ClusterOperatorStatusCondition) configv1.ClusterOperator {
+					return configv1.ClusterOperator{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Status:     configv1.ClusterOperatorStatus{Conditions: conditions},
+					}
+				}
+				available := configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue}
+				unavailable := configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorAvailable, Status: configv1.ConditionFalse}
+				degraded := configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue}
+				notDegraded := configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse}
+
+				It("will report each operator that is not Available and not Degraded", func() {
+					operatorList := configv1.ClusterOperatorList{
+						Items: []configv1.ClusterOperator{
+							operator("healthy", available, notDegraded),
+							operator("degraded", available, degraded),
+							operator("unavailable", unavailable, notDegraded),
+							operator("broken", unavailable, degraded),
+							operator("unreported", available),
+						},
+					}
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, operatorList)
+					result, err := cvClient.GetUnavailableOperators()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Unavailable).To(Equal([]string{
+						"degraded (Degraded=True)",
+						"unavailable (Available=False)",
+						"broken (Available=False, Degraded=True)",
+						"unreported (Degraded=Unknown)",
+					}))
+				})
+				It("will report no operators when all are healthy", func() {
+					operatorList := configv1.ClusterOperatorList{
+						Items: []configv1.ClusterOperator{operator("healthy", available, notDegraded)},
+					}
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, operatorList)
+					result, err := cvClient.GetUnavailableOperators()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Unavailable).To(BeEmpty())
+				})
+			})
 		})
 	})
 })
@@ -3,6 +3,7 @@ package clusterversion
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +24,7 @@ type ClusterVersion interface {
 	EnsureDesiredVersion(uc *upgradev1alpha1.UpgradeConfig) (bool, error)
 	HasUpgradeCompleted(*configv1.ClusterVersion, *upgradev1alpha1.UpgradeConfig) bool
 	HasDegradedOperators() (*HasDegradedOperatorsResult, error)
+	GetUnavailableOperators() (*UnavailableOperatorsResult, error)
 }
 
 //go:generate mockgen -destination=mocks/mockClusterVersionBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/clusterversion ClusterVersionBuilder
@@ -130,6 +132,47 @@ func (c *clusterVersionClient) HasDegradedOperators() (*HasDegradedOperatorsResu
 	}, err
 }
 
+type UnavailableOperatorsResult struct {
+	// Unavailable describes each ClusterOperator that is not Available=True and Degraded=False
+	Unavailable []string
+}
+
+// GetUnavailableOperators lists the ClusterOperators that are not both Available and not
+// Degraded. Unlike HasDegradedOperators, an operator yet to report a condition is unavailable.
+func (c *clusterVersionClient) GetUnavailableOperators() (*UnavailableOperatorsResult, error) {
+	operatorList := &configv1.ClusterOperatorList{}
+	err := c.client.List(context.TODO(), operatorList, []client.ListOption{}...)
+	if err != nil {
+		return nil, err
+	}
+
+	unavailable := []string{}
+	for _, co := range operatorList.Items {
+		available := configv1.ConditionUnknown
+		degraded := configv1.ConditionUnknown
+		for _, condition := range co.Status.Conditions {
+			switch condition.Type {
+			case configv1.OperatorAvailable:
+				available = condition.Status
+			case configv1.OperatorDegraded:
+				degraded = condition.Status
+			}
+		}
+		problems := []string{}
+		if available != configv1.ConditionTrue {
+			problems = append(problems, fmt.Sprintf("Available=%s", available))
+		}
+		if degraded != configv1.ConditionFalse {
+			problems = append(problems, fmt.Sprintf("Degraded=%s", degraded))
+		}
+		if len(problems) > 0 {
+			unavailable = append(unavailable, fmt.Sprintf("%s (%s)", co.Name, strings.Join(problems, ", ")))
+		}
+	}
+
+	return &UnavailableOperatorsResult{Unavailable: unavailable}, nil
+}
+
 func (c *clusterVersionClient) HasUpgradeCompleted(cv *configv1.ClusterVersion, uc *upgradev1alpha1.UpgradeConfig) bool {
 	isCompleted := false
 	for _, c := range cv.Status.History {
@@ -196,3 +239,21 @@ func GetCurrentVersion(clusterVersion *configv1.ClusterVersion) (string, error)
 
 	return gotVersion, nil
 }
+
+// GetCurrentVersionCompletionTime returns when the cluster completed the update to its current version
+func GetCurrentVersionCompletionTime(clusterVersion *configv1.ClusterVersion) (*metav1.Time, error) {
+	var latestCompletionTime *metav1.Time = nil
+	for _, history := range clusterVersion.Status.History {
+		if history.State == configv1.CompletedUpdate && history.CompletionTime != nil {
+			if latestCompletionTime == nil || history.CompletionTime.After(latestCompletionTime.Time) {
+				latestCompletionTime = history.CompletionTime
+			}
+		}
+	}
+
+	if latestCompletionTime == nil {
+		return nil, fmt.Errorf("Failed to get the completion time of the current version")
+	}
+
+	return latestCompletionTime, nil
+}
@@ -65,6 +65,21 @@ func (mr *MockClusterVersionMockRecorder) GetClusterVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterVersion", reflect.TypeOf((*MockClusterVersion)(nil).GetClusterVersion))
 }
 
+// GetUnavailableOperators mocks base method
+func (m *MockClusterVersion) GetUnavailableOperators() (*clusterversion.UnavailableOperatorsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnavailableOperators")
+	ret0, _ := ret[0].(*clusterversion.UnavailableOperatorsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnavailableOperators indicates an expected call of GetUnavailableOperators
+func (mr *MockClusterVersionMockRecorder) GetUnavailableOperators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnavailableOperators", reflect.TypeOf((*MockClusterVersion)(nil).GetUnavailableOperators))
+}
+
 // HasDegradedOperators mocks base method
 func (m *MockClusterVersion) HasDegradedOperators() (*clusterversion.HasDegradedOperatorsResult, error) {
 	m.ctrl.T.Helper()
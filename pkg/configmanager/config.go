@@ -3,14 +3,21 @@ package configmanager
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/openshift/managed-upgrade-operator/config"
 )
 
+var log = logf.Log.WithName("configmanager")
+
 const (
 	CONFIG_MAP_NAME = config.OperatorName + "-config"
 	CONFIG_PATH     = "config.yaml"
@@ -50,6 +57,16 @@ type ConfigValidator interface {
 	IsValid() error
 }
 
+// lastValid holds the last valid configuration read into each type of config, by namespace, so
+// that an invalid change to the config map is rejected in favour of the previous configuration
+var (
+	lastValid      = map[string]string{}
+	lastValidMutex sync.Mutex
+)
+
+// Into reads the operator's configuration into the supplied config. The config map is read on
+// every call so that changes to it apply without a restart. Once a valid configuration has been
+// read, an invalid change is logged and the previous configuration is read instead.
 func (cm *configManager) Into(into ConfigValidator) error {
 	cfgMap := &corev1.ConfigMap{}
 	err := cm.client.Get(context.TODO(), client.ObjectKey{Name: CONFIG_MAP_NAME, Namespace: cm.namespace}, cfgMap)
@@ -57,10 +74,76 @@ func (cm *configManager) Into(into ConfigValidator) error {
 		return err
 	}
 	yml := cfgMap.Data[CONFIG_PATH]
-	err = yaml.Unmarshal([]byte(yml), into)
+
+	key := fmt.Sprintf("%s/%T", cm.namespace, into)
+	lastValidMutex.Lock()
+	defer lastValidMutex.Unlock()
+	previous, loaded := lastValid[key]
+
+	err = load(yml, into)
+	if err != nil {
+		if !loaded {
+			return err
+		}
+		log.Error(err, fmt.Sprintf("Rejected the invalid configuration in config map %s, retaining the previous configuration", CONFIG_MAP_NAME))
+		return load(previous, into)
+	}
+
+	if loaded && previous != yml {
+		changed, err := changedFields(previous, yml, into)
+		if err == nil && len(changed) > 0 {
+			log.Info(fmt.Sprintf("Reloaded the configuration in config map %s, changing %s", CONFIG_MAP_NAME, strings.Join(changed, ", ")))
+		}
+	}
+	lastValid[key] = yml
+	return nil
+}
+
+// load reads the yaml into the config, leaving the config unchanged unless the yaml is valid
+func load(yml string, into ConfigValidator) error {
+	cfg := reflect.New(reflect.TypeOf(into).Elem()).Interface().(ConfigValidator)
+	err := yaml.Unmarshal([]byte(yml), cfg)
 	if err != nil {
 		return fmt.Errorf("Check config map %s for incorrect yaml formatting %s", CONFIG_MAP_NAME, err.Error())
 	}
+	err = cfg.IsValid()
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(into).Elem().Set(reflect.ValueOf(cfg).Elem())
+	return nil
+}
 
-	return into.IsValid()
+// changedFields lists the top-level fields of the config whose values differ between the yaml
+func changedFields(previousYml string, yml string, into ConfigValidator) ([]string, error) {
+	var fields [2]map[string]interface{}
+	for i, y := range []string{previousYml, yml} {
+		cfg := reflect.New(reflect.TypeOf(into).Elem()).Interface()
+		err := yaml.Unmarshal([]byte(y), cfg)
+		if err != nil {
+			return nil, err
+		}
+		out, err := yaml.Marshal(cfg)
+		if err != nil {
+			return nil, err
+		}
+		err = yaml.Unmarshal(out, &fields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changed := []string{}
+	for name, value := range fields[1] {
+		if !reflect.DeepEqual(fields[0][name], value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range fields[0] {
+		if _, ok := fields[1][name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
 }
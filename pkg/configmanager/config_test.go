@@ -0,0 +1,106 @@
+package configmanager
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testConfig struct {
+	Interval int    `yaml:"interval"`
+	Name     string `yaml:"name"`
+}
+
+func (cfg *testConfig) IsValid() error {
+	if cfg.Interval < 0 {
+		return fmt.Errorf("config interval is invalid")
+	}
+	return nil
+}
+
+var _ = Describe("ConfigManager", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		cm             ConfigManager
+	)
+
+	expectConfig := func(yml string) {
+		mockKubeClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: CONFIG_MAP_NAME, Namespace: "test-namespace"}, gomock.Any()).SetArg(2, corev1.ConfigMap{
+			Data: map[string]string{CONFIG_PATH: yml},
+		})
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		cm = NewBuilder().New(mockKubeClient, "test-namespace")
+		lastValid = map[string]string{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("reads a valid configuration", func() {
+		expectConfig("interval: 5\nname: test\n")
+		cfg := &testConfig{}
+		Expect(cm.Into(cfg)).To(Succeed())
+		Expect(cfg).To(Equal(&testConfig{Interval: 5, Name: "test"}))
+	})
+
+	It("rejects an invalid configuration when none has been read before", func() {
+		expectConfig("interval: -1\n")
+		Expect(cm.Into(&testConfig{})).NotTo(Succeed())
+	})
+
+	Context("When a valid configuration has been read", func() {
+		BeforeEach(func() {
+			expectConfig("interval: 5\nname: test\n")
+			Expect(cm.Into(&testConfig{})).To(Succeed())
+		})
+
+		It("reloads a valid change", func() {
+			expectConfig("interval: 10\nname: test\n")
+			cfg := &testConfig{}
+			Expect(cm.Into(cfg)).To(Succeed())
+			Expect(cfg).To(Equal(&testConfig{Interval: 10, Name: "test"}))
+		})
+
+		It("retains the previous configuration when the change is invalid", func() {
+			expectConfig("interval: -1\nname: changed\n")
+			cfg := &testConfig{}
+			Expect(cm.Into(cfg)).To(Succeed())
+			Expect(cfg).To(Equal(&testConfig{Interval: 5, Name: "test"}))
+		})
+
+		It("retains the previous configuration when the change is not valid yaml", func() {
+			expectConfig("interval: [5\n")
+			cfg := &testConfig{}
+			Expect(cm.Into(cfg)).To(Succeed())
+			Expect(cfg).To(Equal(&testConfig{Interval: 5, Name: "test"}))
+		})
+
+		It("retains the previous configuration after a valid change", func() {
+			expectConfig("interval: 10\nname: test\n")
+			Expect(cm.Into(&testConfig{})).To(Succeed())
+			expectConfig("interval: -1\n")
+			cfg := &testConfig{}
+			Expect(cm.Into(cfg)).To(Succeed())
+			Expect(cfg.Interval).To(Equal(10))
+		})
+	})
+
+	It("lists the fields a change affects", func() {
+		changed, err := changedFields("interval: 5\nname: test\n", "interval: 10\nname: test\n", &testConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(Equal([]string{"interval"}))
+	})
+})
@@ -0,0 +1,13 @@
+package configmanager
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ConfigManager Suite")
+}
@@ -14,6 +14,9 @@ func (nkc *nodeKeeperConfig) IsValid() error {
 	if nkc.NodeDrain.Timeout < 0 {
 		return fmt.Errorf("Config nodeDrain timeOut is invalid")
 	}
+	if err := nkc.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,84 @@
+package nodekeeper
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	workerPool          = "worker"
+)
+
+// drainRecord tracks the drain of a cordoned node so that its outcome is recorded once
+type drainRecord struct {
+	pool      string
+	startedAt time.Time
+	escalated bool
+	recorded  bool
+}
+
+// trackDrain returns the record of the node's current drain, starting a new one if the node
+// has been cordoned again since it was last seen
+func (r *ReconcileNodeKeeper) trackDrain(node *corev1.Node, cordonedAt *metav1.Time) *drainRecord {
+	if r.drains == nil {
+		r.drains = map[string]*drainRecord{}
+	}
+	startedAt := time.Now()
+	if cordonedAt != nil {
+		startedAt = cordonedAt.Time
+	}
+	d, ok := r.drains[node.Name]
+	if !ok || (cordonedAt != nil && !d.startedAt.Equal(startedAt)) {
+		d = &drainRecord{pool: nodePool(node), startedAt: startedAt}
+		r.drains[node.Name] = d
+	}
+	return d
+}
+
+// finishDrain records the successful outcome of a drain that was being tracked for the node
+func (r *ReconcileNodeKeeper) finishDrain(node *corev1.Node, metricsClient metrics.Metrics) {
+	d, ok := r.drains[node.Name]
+	if !ok {
+		return
+	}
+	outcome := metrics.DrainOutcomeSuccess
+	if d.escalated {
+		outcome = metrics.DrainOutcomeForceEscalated
+	}
+	d.record(metricsClient, outcome)
+	delete(r.drains, node.Name)
+}
+
+func (d *drainRecord) record(metricsClient metrics.Metrics, outcome string) {
+	if d.recorded {
+		return
+	}
+	metricsClient.UpdateMetricNodeDrainOutcome(d.pool, outcome, time.Since(d.startedAt))
+	d.recorded = true
+}
+
+// nodePool returns the MachineConfigPool a node belongs to, going by its role labels.
+// Nodes in a custom pool carry both the worker role and the role of their pool.
+func nodePool(node *corev1.Node) string {
+	roles := []string{}
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+			role := strings.TrimPrefix(label, nodeRoleLabelPrefix)
+			if role != "" && role != workerPool {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) == 0 {
+		return workerPool
+	}
+	sort.Strings(roles)
+	return roles[0]
+}
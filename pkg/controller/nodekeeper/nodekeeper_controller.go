@@ -87,6 +87,9 @@ type ReconcileNodeKeeper struct {
 	drainstrategyBuilder        drain.NodeDrainStrategyBuilder
 	upgradeConfigManagerBuilder upgradeconfigmanager.UpgradeConfigManagerBuilder
 	scheme                      *runtime.Scheme
+	// drains tracks the drain of each cordoned node by node name. The controller runs a
+	// single worker so the map is never accessed concurrently.
+	drains map[string]*drainRecord
 }
 
 // Note:
@@ -128,6 +131,13 @@ func (r *ReconcileNodeKeeper) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	// A worker cordoned by the upgrade ahead of its turn is left for the machine-config daemon to
+	// drain once it starts updating the worker
+	if machinery.IsCordonedAheadOfDrain(node) {
+		reqLogger.Info(fmt.Sprintf("Node %s is cordoned ahead of its drain, not draining it yet", node.Name))
+		return reconcile.Result{}, nil
+	}
+
 	result := r.machinery.IsNodeCordoned(node)
 	metricsClient, err := r.metricsClientBuilder.NewClient(r.client)
 	if err != nil {
@@ -135,8 +145,10 @@ func (r *ReconcileNodeKeeper) Reconcile(request reconcile.Request) (reconcile.Re
 	}
 	if !result.IsCordoned {
 		metricsClient.ResetMetricNodeDrainFailed(node.Name)
+		r.finishDrain(node, metricsClient)
 		return reconcile.Result{}, nil
 	}
+	drainRecord := r.trackDrain(node, result.AddedAt)
 
 	operatorNamespace, err := util.GetOperatorNamespace()
 	if err != nil {
@@ -157,8 +169,15 @@ func (r *ReconcileNodeKeeper) Reconcile(request reconcile.Request) (reconcile.Re
 	res, err := drainStrategy.Execute(node)
 	for _, r := range res {
 		reqLogger.Info(r.Message)
+		if r.PDBForced {
+			reqLogger.Info(fmt.Sprintf("Forced the drain of node %s past its PodDisruptionBudgets after the PDB force drain timeout of %d minutes", node.Name, uc.Spec.PDBForceDrainTimeout))
+			metricsClient.UpdateMetricNodeDrainPDBForced(node.Name)
+			drainRecord.escalated = true
+		}
 	}
+	// An error executing the drain is retried, so is not an outcome of the drain
 	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Error while draining node %s", node.Name))
 		return reconcile.Result{}, err
 	}
 
@@ -169,6 +188,7 @@ func (r *ReconcileNodeKeeper) Reconcile(request reconcile.Request) (reconcile.Re
 	if hasFailed {
 		reqLogger.Info(fmt.Sprintf("Node drain timed out %s. Alerting.", node.Name))
 		metricsClient.UpdateMetricNodeDrainFailed(node.Name)
+		drainRecord.record(metricsClient, metrics.DrainOutcomeTimeout)
 		return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
 	}
 
@@ -1,10 +1,12 @@
 package nodekeeper
 
 import (
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -15,6 +17,7 @@ import (
 	mockDrain "github.com/openshift/managed-upgrade-operator/pkg/drain/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
 	mockUCMgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
@@ -74,6 +77,7 @@ var _ = Describe("NodeKeeperController", func() {
 			mockDrainStrategyBuilder,
 			mockUpgradeConfigManagerBuilder,
 			runtime.NewScheme(),
+			map[string]*drainRecord{},
 		}
 	})
 
@@ -136,6 +140,7 @@ var _ = Describe("NodeKeeperController", func() {
 					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(true, nil),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainOutcome(workerPool, metrics.DrainOutcomeTimeout, gomock.Any()),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
@@ -143,6 +148,71 @@ var _ = Describe("NodeKeeperController", func() {
 				Expect(result.Requeue).To(BeFalse())
 				Expect(result.RequeueAfter).To(Not(BeNil()))
 			})
+			It("should record when the drain of PDB pods was forced", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{{Message: "PDB pods deleted", HasExecuted: true, PDBForced: true}}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainPDBForced(gomock.Any()),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(false, nil),
+				)
+				_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("should not drain a node cordoned ahead of its drain", func() {
+				node := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name: testNodeName.Name,
+					Annotations: map[string]string{
+						machinery.CordonedByUpgradeAnnotation:             "true",
+						"machineconfiguration.openshift.io/currentConfig": "rendered-worker-old",
+						"machineconfiguration.openshift.io/desiredConfig": "rendered-worker-old",
+					},
+				}}
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+				)
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Times(0)
+				mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(0)
+				result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+			})
+			It("should drain a node cordoned by the upgrade once the machine-config daemon updates it", func() {
+				node := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name: testNodeName.Name,
+					Annotations: map[string]string{
+						machinery.CordonedByUpgradeAnnotation:             "true",
+						"machineconfiguration.openshift.io/currentConfig": "rendered-worker-old",
+						"machineconfiguration.openshift.io/desiredConfig": "rendered-worker-new",
+					},
+				}}
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(false, nil),
+				)
+				_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
 			It("should reset any alerts once node is not cordoned", func() {
 				gomock.InOrder(
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
@@ -160,5 +230,84 @@ var _ = Describe("NodeKeeperController", func() {
 				Expect(result.RequeueAfter).To(BeZero())
 			})
 		})
+
+		Context("Recording node drain outcomes", func() {
+			var uc upgradev1alpha1.UpgradeConfig
+			BeforeEach(func() {
+				uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+				config = nodeKeeperConfig{
+					NodeDrain: drain.NodeDrain{
+						Timeout:               5,
+						ExpectedNodeDrainTime: 8,
+					},
+				}
+			})
+			cordonedAt := &metav1.Time{Time: time.Now().Add(-3 * time.Minute)}
+			expectNodeChecked := func(isCordoned bool) {
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: isCordoned, AddedAt: cordonedAt}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+				)
+			}
+			expectDrainExecuted := func(res []*drain.DrainStrategyResult, err error) {
+				gomock.InOrder(
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return(res, err),
+				)
+			}
+			It("should record a successful drain once the node is uncordoned", func() {
+				expectNodeChecked(true)
+				expectDrainExecuted([]*drain.DrainStrategyResult{}, nil)
+				mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(false, nil)
+				_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectNodeChecked(false)
+				mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any())
+				mockMetricsClient.EXPECT().UpdateMetricNodeDrainOutcome(workerPool, metrics.DrainOutcomeSuccess, gomock.Any())
+				_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("should record a drain that forced PDB pods as escalated", func() {
+				expectNodeChecked(true)
+				expectDrainExecuted([]*drain.DrainStrategyResult{{Message: "PDB pods deleted", HasExecuted: true, PDBForced: true}}, nil)
+				mockMetricsClient.EXPECT().UpdateMetricNodeDrainPDBForced(gomock.Any())
+				mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(false, nil)
+				_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectNodeChecked(false)
+				mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any())
+				mockMetricsClient.EXPECT().UpdateMetricNodeDrainOutcome(workerPool, metrics.DrainOutcomeForceEscalated, gomock.Any())
+				_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("should not record an error executing the drain as its outcome", func() {
+				expectNodeChecked(true)
+				expectDrainExecuted(nil, fmt.Errorf("fake error"))
+				_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).To(HaveOccurred())
+
+				expectNodeChecked(false)
+				mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any())
+				mockMetricsClient.EXPECT().UpdateMetricNodeDrainOutcome(workerPool, metrics.DrainOutcomeSuccess, gomock.Any())
+				_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("should label the drain with the node's custom pool", func() {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+					"node-role.kubernetes.io/infra":  "",
+				}}}
+				Expect(nodePool(node)).To(Equal("infra"))
+				Expect(nodePool(&corev1.Node{})).To(Equal(workerPool))
+			})
+		})
 	})
 })
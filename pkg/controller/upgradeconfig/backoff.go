@@ -0,0 +1,36 @@
+package upgradeconfig
+
+import (
+	"time"
+
+	"github.com/jpillora/backoff"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// upgradingRequeueInterval is how soon an upgrade underway is reconciled again
+const upgradingRequeueInterval = 1 * time.Minute
+
+// upgradeFailures counts the consecutive failed reconciles of each upgrade underway. Reconciles
+// are not concurrent so the count needs no lock.
+var upgradeFailures = map[string]int{}
+
+// recordUpgradeFailure records a failed reconcile of the UpgradeConfig's upgrade and returns the
+// number of consecutive failures and how long to wait before reconciling again. The wait doubles
+// with each consecutive failure, up to max.
+func recordUpgradeFailure(uc *upgradev1alpha1.UpgradeConfig, max time.Duration) (int, time.Duration) {
+	key := uc.Namespace + "/" + uc.Name
+	upgradeFailures[key]++
+	b := &backoff.Backoff{
+		Min:    upgradingRequeueInterval,
+		Max:    max,
+		Factor: 2,
+		Jitter: false,
+	}
+	return upgradeFailures[key], b.ForAttempt(float64(upgradeFailures[key] - 1))
+}
+
+// resetUpgradeFailures clears the count of consecutive failed reconciles of the UpgradeConfig's upgrade
+func resetUpgradeFailures(uc *upgradev1alpha1.UpgradeConfig) {
+	delete(upgradeFailures, uc.Namespace+"/"+uc.Name)
+}
@@ -0,0 +1,68 @@
+package upgradeconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	cub "github.com/openshift/managed-upgrade-operator/pkg/cluster_upgrader_builder"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+)
+
+// CancelUpgradeAnnotation requests that the UpgradeConfig's upgrade is cancelled when set to
+// "true". An upgrade can only be cancelled until the cluster has commenced upgrading.
+const CancelUpgradeAnnotation = "upgrade.managed.openshift.io/cancel"
+
+// isCancelRequested indicates whether the UpgradeConfig carries the cancel annotation
+func isCancelRequested(uc *upgradev1alpha1.UpgradeConfig) bool {
+	return uc.GetAnnotations()[CancelUpgradeAnnotation] == "true"
+}
+
+// cancelUpgrade has the upgrader, if the upgrade is underway, undo the changes made for the upgrade
+// and records the upgrade as cancelled
+func (r *ReconcileUpgradeConfig) cancelUpgrade(upgrader cub.ClusterUpgrader, uc *upgradev1alpha1.UpgradeConfig, history *upgradev1alpha1.UpgradeHistory, logger logr.Logger) (reconcile.Result, error) {
+	logger.Info("Cancelling the upgrade as requested by the UpgradeConfig")
+	if upgrader != nil {
+		err := upgrader.CleanupUpgrade(uc, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	now := &metav1.Time{Time: time.Now()}
+	history.Phase = upgradev1alpha1.UpgradePhaseCancelled
+	history.CompleteTime = now
+	history.Conditions = upgradev1alpha1.Conditions{
+		{
+			Type:               upgradev1alpha1.UpgradeCancelled,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             "UpgradeCancelled",
+			Message:            fmt.Sprintf("The upgrade was cancelled by the %s annotation", CancelUpgradeAnnotation),
+		},
+	}
+	uc.Status.History.SetHistory(*history)
+	err := r.client.Status().Update(context.TODO(), uc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Event(uc, corev1.EventTypeNormal, eventmanager.EVENT_REASON_UPGRADE_CANCELLED, fmt.Sprintf("Upgrade to version %s was cancelled", uc.Spec.Desired.Version))
+
+	return reconcile.Result{}, r.removeFinalizer(uc)
+}
+
+// cancelRejectedCondition explains that the upgrade has gone too far to be cancelled
+func cancelRejectedCondition() upgradev1alpha1.UpgradeCondition {
+	return upgradev1alpha1.UpgradeCondition{
+		Type:    upgradev1alpha1.UpgradeCancelled,
+		Status:  corev1.ConditionFalse,
+		Reason:  "CancelRejected",
+		Message: fmt.Sprintf("The upgrade cannot be cancelled as the cluster has already commenced upgrading, the %s annotation is ignored", CancelUpgradeAnnotation),
+	}
+}
@@ -7,13 +7,34 @@ import (
 
 type config struct {
 	UpgradeWindow upgradeWindow `yaml:"upgradeWindow"`
+	Requeue       requeue       `yaml:"requeue"`
 }
 
 type upgradeWindow struct {
 	TimeOut int `yaml:"timeOut" default:"120"`
 	DelayTrigger int `yaml:"delayTrigger" default:"30"`
+	// Duration is the number of minutes after the upgradeAt time by which the upgrade is expected to complete
+	Duration int `yaml:"duration" default:"480"`
+	// CommenceJitter is the most minutes the commencement of the upgrade is delayed past the upgradeAt
+	// time, by an amount particular to the cluster, to stagger the upgrades of clusters sharing an
+	// upgradeAt time. It must be shorter than the time out, and is disabled when 0.
+	CommenceJitter int `yaml:"commenceJitter" default:"0"`
+	// MinimumUptime is the number of minutes the cluster must have been on its current version
+	// before it commences another upgrade, to let it settle after the last one. Disabled when 0.
+	MinimumUptime int `yaml:"minimumUptime" default:"0"`
 }
 
+type requeue struct {
+	// MaxBackoff is the number of minutes the requeue of an upgrade which fails repeatedly
+	// backs off to
+	MaxBackoff int `yaml:"maxBackoff" default:"30"`
+}
+
+const (
+	defaultUpgradeWindowDuration = 480 * time.Minute
+	defaultMaxRequeueBackoff     = 30 * time.Minute
+)
+
 func (cfg *config) IsValid() error {
 	if cfg.UpgradeWindow.TimeOut < 0 {
 		return fmt.Errorf("Config upgrade window time out is invalid")
@@ -21,6 +42,21 @@ func (cfg *config) IsValid() error {
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("Config upgrade window delay trigger is invalid")
 	}
+	if cfg.UpgradeWindow.Duration < 0 {
+		return fmt.Errorf("Config upgrade window duration is invalid")
+	}
+	if cfg.UpgradeWindow.CommenceJitter < 0 {
+		return fmt.Errorf("Config upgrade window commence jitter is invalid")
+	}
+	if cfg.UpgradeWindow.CommenceJitter > 0 && cfg.UpgradeWindow.CommenceJitter >= cfg.UpgradeWindow.TimeOut {
+		return fmt.Errorf("Config upgrade window commence jitter must be shorter than the time out")
+	}
+	if cfg.UpgradeWindow.MinimumUptime < 0 {
+		return fmt.Errorf("Config upgrade window minimum uptime is invalid")
+	}
+	if cfg.Requeue.MaxBackoff < 0 {
+		return fmt.Errorf("Config requeue max backoff is invalid")
+	}
 	return nil
 }
 
@@ -31,3 +67,33 @@ func (cfg *config) GetUpgradeWindowTimeOutDuration() time.Duration {
 func (cfg *config) GetUpgradeWindowDelayTriggerDuration() time.Duration {
 	return time.Duration(cfg.UpgradeWindow.DelayTrigger) * time.Minute
 }
+
+func (cfg *config) GetUpgradeWindowDuration() time.Duration {
+	if cfg.UpgradeWindow.Duration == 0 {
+		return defaultUpgradeWindowDuration
+	}
+	return time.Duration(cfg.UpgradeWindow.Duration) * time.Minute
+}
+
+// GetCommenceJitterMaxDuration returns the most the commencement of the upgrade is delayed past its upgradeAt time
+func (cfg *config) GetCommenceJitterMaxDuration() time.Duration {
+	return time.Duration(cfg.UpgradeWindow.CommenceJitter) * time.Minute
+}
+
+// GetMinimumUptimeDuration returns how long the cluster must have been on its current version before upgrading
+func (cfg *config) GetMinimumUptimeDuration() time.Duration {
+	return time.Duration(cfg.UpgradeWindow.MinimumUptime) * time.Minute
+}
+
+// GetMaxRequeueBackoff returns the longest delay before requeueing an upgrade which fails
+// repeatedly, which is no shorter than the usual requeue interval
+func (cfg *config) GetMaxRequeueBackoff() time.Duration {
+	if cfg.Requeue.MaxBackoff == 0 {
+		return defaultMaxRequeueBackoff
+	}
+	backoff := time.Duration(cfg.Requeue.MaxBackoff) * time.Minute
+	if backoff < upgradingRequeueInterval {
+		return upgradingRequeueInterval
+	}
+	return backoff
+}
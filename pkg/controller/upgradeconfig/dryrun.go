@@ -0,0 +1,81 @@
+package upgradeconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/validation"
+)
+
+// DryRunAnnotation requests that the UpgradeConfig's upgrade is only planned when set to "true".
+// The UpgradeConfig is validated and the steps and pre-flight checks of its upgrade are reported,
+// but the upgrade does not commence until the annotation is removed.
+const DryRunAnnotation = "upgrade.managed.openshift.io/dry-run"
+
+// isDryRunRequested indicates whether the UpgradeConfig carries the dry-run annotation
+func isDryRunRequested(uc *upgradev1alpha1.UpgradeConfig) bool {
+	return uc.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// dryRunUpgrade records in the UpgradeConfig's history whether it would be accepted and, if so,
+// the steps its upgrade would perform and the results of the pre-flight checks. Nothing but the
+// UpgradeConfig's status is changed.
+func (r *ReconcileUpgradeConfig) dryRunUpgrade(cfm configmanager.ConfigManager, metricsClient metrics.Metrics, eventClient eventmanager.EventManager, uc *upgradev1alpha1.UpgradeConfig, history *upgradev1alpha1.UpgradeHistory, validatorResult validation.ValidatorResult, logger logr.Logger) (reconcile.Result, error) {
+	logger.Info("Planning the upgrade as a dry run was requested by the UpgradeConfig")
+	condition := upgradev1alpha1.UpgradeCondition{
+		Type:   upgradev1alpha1.UpgradeDryRun,
+		Status: corev1.ConditionFalse,
+		Reason: "DryRunRejected",
+	}
+	if !validatorResult.IsValid || !validatorResult.IsAvailableUpdate {
+		condition.Message = fmt.Sprintf("Dry run: the UpgradeConfig would not be accepted: %s", validatorResult.Message)
+	} else {
+		upgrader, err := r.clusterUpgraderBuilder.NewClient(r.client, cfm, metricsClient, eventClient, uc.Spec.Type)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		steps, checks, err := upgrader.PlanUpgrade(uc, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		condition.Status, condition.Reason, condition.Message = describePlan(steps, checks)
+	}
+
+	if !history.Conditions.SetCondition(condition) {
+		return reconcile.Result{}, nil
+	}
+	uc.Status.History.SetHistory(*history)
+	return reconcile.Result{}, r.client.Status().Update(context.TODO(), uc)
+}
+
+// describePlan summarises the planned steps and the results of the pre-flight checks
+func describePlan(steps []upgradev1alpha1.UpgradeConditionType, checks upgradev1alpha1.Conditions) (corev1.ConditionStatus, string, string) {
+	names := []string{}
+	for _, step := range steps {
+		names = append(names, string(step))
+	}
+	status, reason := corev1.ConditionTrue, "DryRunPassed"
+	results := []string{}
+	for _, check := range checks {
+		if check.IsTrue() {
+			results = append(results, check.Reason)
+			continue
+		}
+		status, reason = corev1.ConditionFalse, "DryRunChecksFailed"
+		results = append(results, fmt.Sprintf("%s: %s", check.Reason, check.Message))
+	}
+	message := fmt.Sprintf("Dry run: the upgrade would perform the steps %s", strings.Join(names, ", "))
+	if len(results) > 0 {
+		message = fmt.Sprintf("%s. Pre-flight checks: %s", message, strings.Join(results, "; "))
+	}
+	return status, reason, message
+}
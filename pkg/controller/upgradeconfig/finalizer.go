@@ -0,0 +1,96 @@
+package upgradeconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// UpgradeConfigFinalizer holds up the deletion of an UpgradeConfig whose upgrade is underway
+// until the changes the upgrade has made to the cluster are cleaned up
+const UpgradeConfigFinalizer = "upgrade.managed.openshift.io/finalizer"
+
+// maxCleanupAttempts is how many times the cleanup of a deleted UpgradeConfig's upgrade is
+// attempted before the finalizer is removed regardless, so that the deletion is not stuck
+const maxCleanupAttempts = 3
+
+// cleanupFailures counts the failed cleanups of each deleted UpgradeConfig. Reconciles are not
+// concurrent so the count needs no lock.
+var cleanupFailures = map[string]int{}
+
+// hasFinalizer indicates whether the UpgradeConfig carries the finalizer
+func hasFinalizer(uc *upgradev1alpha1.UpgradeConfig) bool {
+	for _, f := range uc.GetFinalizers() {
+		if f == UpgradeConfigFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureFinalizer adds the finalizer to the UpgradeConfig if it does not carry it yet
+func (r *ReconcileUpgradeConfig) ensureFinalizer(uc *upgradev1alpha1.UpgradeConfig) error {
+	if hasFinalizer(uc) {
+		return nil
+	}
+	uc.SetFinalizers(append(uc.GetFinalizers(), UpgradeConfigFinalizer))
+	return r.client.Update(context.TODO(), uc)
+}
+
+// removeFinalizer removes the finalizer from the UpgradeConfig if it carries it
+func (r *ReconcileUpgradeConfig) removeFinalizer(uc *upgradev1alpha1.UpgradeConfig) error {
+	if !hasFinalizer(uc) {
+		return nil
+	}
+	finalizers := []string{}
+	for _, f := range uc.GetFinalizers() {
+		if f != UpgradeConfigFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	uc.SetFinalizers(finalizers)
+	return r.client.Update(context.TODO(), uc)
+}
+
+// finalize cleans up after the upgrade of a deleted UpgradeConfig, if it is underway, and then
+// removes the finalizer. A failed cleanup is retried with the request, until the attempts are
+// exhausted.
+func (r *ReconcileUpgradeConfig) finalize(uc *upgradev1alpha1.UpgradeConfig, metricsClient metrics.Metrics, eventClient eventmanager.EventManager, logger logr.Logger) (reconcile.Result, error) {
+	if !hasFinalizer(uc) {
+		return reconcile.Result{}, nil
+	}
+
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history != nil && history.Phase == upgradev1alpha1.UpgradePhaseUpgrading {
+		logger.Info("UpgradeConfig deleted while upgrading, cleaning up the upgrade")
+		key := uc.Namespace + "/" + uc.Name
+		err := r.cleanupUpgrade(uc, metricsClient, eventClient, logger)
+		if err != nil {
+			cleanupFailures[key]++
+			if cleanupFailures[key] < maxCleanupAttempts {
+				logger.Error(err, "Failed to clean up the upgrade of the deleted UpgradeConfig, retrying")
+				return reconcile.Result{}, err
+			}
+			logger.Error(err, fmt.Sprintf("Failed to clean up the upgrade of the deleted UpgradeConfig after %d attempts, allowing its deletion", cleanupFailures[key]))
+		}
+		delete(cleanupFailures, key)
+	}
+
+	return reconcile.Result{}, r.removeFinalizer(uc)
+}
+
+// cleanupUpgrade has the UpgradeConfig's upgrader undo the changes its upgrade has made
+func (r *ReconcileUpgradeConfig) cleanupUpgrade(uc *upgradev1alpha1.UpgradeConfig, metricsClient metrics.Metrics, eventClient eventmanager.EventManager, logger logr.Logger) error {
+	cfm := r.configManagerBuilder.New(r.client, uc.Namespace)
+	upgrader, err := r.clusterUpgraderBuilder.NewClient(r.client, cfm, metricsClient, eventClient, uc.Spec.Type)
+	if err != nil {
+		return err
+	}
+	return upgrader.CleanupUpgrade(uc, logger)
+}
@@ -0,0 +1,65 @@
+package upgradeconfig
+
+import (
+	"sort"
+	"time"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// selectUpgradeConfig returns the UpgradeConfig that is acted upon when several exist.
+// An UpgradeConfig whose upgrade has commenced is always selected, so that an upgrade underway
+// is never overtaken by another. Otherwise the highest priority is selected, then the earliest
+// upgradeAt, then the first by name so that every reconcile makes the same selection.
+// UpgradeConfigs whose upgrade has finished are never selected.
+func selectUpgradeConfig(ucs []upgradev1alpha1.UpgradeConfig) *upgradev1alpha1.UpgradeConfig {
+	candidates := []upgradev1alpha1.UpgradeConfig{}
+	for _, uc := range ucs {
+		if !isFinished(&uc) {
+			candidates = append(candidates, uc)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := &candidates[i], &candidates[j]
+		if isUpgrading(a) != isUpgrading(b) {
+			return isUpgrading(a)
+		}
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority > b.Spec.Priority
+		}
+		aAt, aErr := time.Parse(time.RFC3339, a.Spec.UpgradeAt)
+		bAt, bErr := time.Parse(time.RFC3339, b.Spec.UpgradeAt)
+		if (aErr == nil) != (bErr == nil) {
+			// An upgradeAt which cannot be parsed is ordered last
+			return aErr == nil
+		}
+		if !aAt.Equal(bAt) {
+			return aAt.Before(bAt)
+		}
+		return a.Name < b.Name
+	})
+	return &candidates[0]
+}
+
+// isUpgrading indicates whether the upgrade to the UpgradeConfig's desired version has commenced
+func isUpgrading(uc *upgradev1alpha1.UpgradeConfig) bool {
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	return history != nil && history.Phase == upgradev1alpha1.UpgradePhaseUpgrading
+}
+
+// isFinished indicates whether the upgrade to the UpgradeConfig's desired version has been
+// upgraded, failed or cancelled
+func isFinished(uc *upgradev1alpha1.UpgradeConfig) bool {
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history == nil {
+		return false
+	}
+	switch history.Phase {
+	case upgradev1alpha1.UpgradePhaseUpgraded, upgradev1alpha1.UpgradePhaseFailed, upgradev1alpha1.UpgradePhaseCancelled:
+		return true
+	}
+	return false
+}
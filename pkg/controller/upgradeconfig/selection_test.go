@@ -0,0 +1,78 @@
+package upgradeconfig
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpgradeConfig selection", func() {
+	var (
+		first  *upgradev1alpha1.UpgradeConfig
+		second *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		first = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "first", Namespace: "test-namespace"}).GetUpgradeConfig()
+		second = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "second", Namespace: "test-namespace"}).GetUpgradeConfig()
+		first.Spec.UpgradeAt = time.Now().Add(time.Hour).Format(time.RFC3339)
+		second.Spec.UpgradeAt = time.Now().Add(2 * time.Hour).Format(time.RFC3339)
+	})
+
+	selectedName := func(ucs ...*upgradev1alpha1.UpgradeConfig) string {
+		items := []upgradev1alpha1.UpgradeConfig{}
+		for _, uc := range ucs {
+			items = append(items, *uc)
+		}
+		return selectUpgradeConfig(items).Name
+	}
+
+	It("selects nothing when there are no UpgradeConfigs", func() {
+		Expect(selectUpgradeConfig(nil)).To(BeNil())
+	})
+
+	It("selects the UpgradeConfig with the earliest upgradeAt", func() {
+		Expect(selectedName(second, first)).To(Equal("first"))
+	})
+
+	It("selects the UpgradeConfig with the highest priority over an earlier upgradeAt", func() {
+		second.Spec.Priority = 10
+		Expect(selectedName(first, second)).To(Equal("second"))
+	})
+
+	It("selects the UpgradeConfig which is already upgrading over all others", func() {
+		first.Spec.Priority = 10
+		second.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: second.Spec.Desired.Version, Phase: upgradev1alpha1.UpgradePhaseUpgrading}}
+		Expect(selectedName(first, second)).To(Equal("second"))
+	})
+
+	It("does not select an UpgradeConfig whose upgrade has finished", func() {
+		for _, phase := range []upgradev1alpha1.UpgradePhase{upgradev1alpha1.UpgradePhaseUpgraded, upgradev1alpha1.UpgradePhaseFailed, upgradev1alpha1.UpgradePhaseCancelled} {
+			first.Spec.Priority = 10
+			first.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: first.Spec.Desired.Version, Phase: phase}}
+			second.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: second.Spec.Desired.Version, Phase: upgradev1alpha1.UpgradePhasePending}}
+			Expect(selectedName(first, second)).To(Equal("second"), "phase %s", phase)
+		}
+	})
+
+	It("selects nothing when every upgrade has finished", func() {
+		first.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: first.Spec.Desired.Version, Phase: upgradev1alpha1.UpgradePhaseUpgraded}}
+		Expect(selectUpgradeConfig([]upgradev1alpha1.UpgradeConfig{*first})).To(BeNil())
+	})
+
+	It("orders an upgradeAt which cannot be parsed last", func() {
+		first.Spec.UpgradeAt = "tomorrow"
+		Expect(selectedName(first, second)).To(Equal("second"))
+	})
+
+	It("selects by name when the UpgradeConfigs are otherwise equal", func() {
+		second.Spec.UpgradeAt = first.Spec.UpgradeAt
+		Expect(selectedName(second, first)).To(Equal("first"))
+	})
+})
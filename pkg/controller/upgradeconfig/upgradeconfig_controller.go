@@ -2,6 +2,7 @@ package upgradeconfig
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -9,9 +10,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -61,19 +65,27 @@ func newReconciler(mgr manager.Manager, client client.Client) reconcile.Reconcil
 		cvClientBuilder:        cv.NewBuilder(),
 		eventManagerBuilder:    eventmanager.NewBuilder(),
 		ucMgrBuilder:           ucmgr.NewBuilder(),
+		recorder:               eventmanager.NewUpgradeEventRecorder(mgr.GetEventRecorderFor(muocfg.OperatorName)),
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("upgradeconfig-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("upgradeconfig-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
 	if err != nil {
 		return err
 	}
 
-	// Watch for changes to primary resource UpgradeConfig, status change will not trigger a reconcile
-	err = c.Watch(&source.Kind{Type: &upgradev1alpha1.UpgradeConfig{}}, &handler.EnqueueRequestForObject{}, StatusChangedPredicate, OSDUpgradePredicate)
+	// Watch for changes to primary resource UpgradeConfig, status change will not trigger a reconcile.
+	// Every UpgradeConfig is reconciled, as any of them may be selected to act upon.
+	err = c.Watch(&source.Kind{Type: &upgradev1alpha1.UpgradeConfig{}}, &handler.EnqueueRequestForObject{}, StatusChangedPredicate)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the operator's configuration, so that they apply on the next reconcile
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: configMapToUpgradeConfigs(mgr.GetClient())}, OperatorConfigPredicate)
 	if err != nil {
 		return err
 	}
@@ -98,6 +110,7 @@ type ReconcileUpgradeConfig struct {
 	cvClientBuilder        cv.ClusterVersionBuilder
 	eventManagerBuilder    eventmanager.EventManagerBuilder
 	ucMgrBuilder           ucmgr.UpgradeConfigManagerBuilder
+	recorder               eventmanager.UpgradeEventRecorder
 }
 
 // Reconcile reads that state of the cluster for a UpgradeConfig object and makes changes based on the state read
@@ -137,6 +150,11 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 		return reconcile.Result{}, err
 	}
 
+	// Clean up after an upgrade underway before the UpgradeConfig is removed
+	if instance.GetDeletionTimestamp() != nil {
+		return r.finalize(instance, metricsClient, eventClient, reqLogger)
+	}
+
 	history := instance.Status.History.GetHistory(instance.Spec.Desired.Version)
 	if history == nil {
 		history = &upgradev1alpha1.UpgradeHistory{Version: instance.Spec.Desired.Version, Phase: upgradev1alpha1.UpgradePhaseNew}
@@ -146,12 +164,28 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		metricsClient.ResetUpgradeMetrics()
+		reqLogger.Info("Reset the metrics of previous upgrades due to a new desired version.", "version", instance.Spec.Desired.Version)
 	}
 
 	status := history.Phase
 	reqLogger.Info("Current cluster status", "status", status)
 	switch status {
 	case upgradev1alpha1.UpgradePhaseNew, upgradev1alpha1.UpgradePhasePending:
+		// Nothing has been done for the upgrade yet, so there is nothing to clean up
+		if isCancelRequested(instance) {
+			return r.cancelUpgrade(nil, instance, history, reqLogger)
+		}
+
+		// Only one UpgradeConfig is acted upon when there are several
+		selected, err := r.isSelected(instance, history, reqLogger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !selected {
+			return reconcile.Result{}, nil
+		}
+
 		reqLogger.Info("Validating UpgradeConfig")
 
 		// Get current ClusterVersion
@@ -161,8 +195,10 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 			return reconcile.Result{}, err
 		}
 
+		cfm := r.configManagerBuilder.New(r.client, request.Namespace)
+
 		// Build a Validator
-		validator, err := r.validationBuilder.NewClient()
+		validator, err := r.validationBuilder.NewClient(r.client, cfm)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -173,6 +209,9 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 			reqLogger.Info("An error occurred while validating UpgradeConfig")
 			return reconcile.Result{}, err
 		}
+		if isDryRunRequested(instance) {
+			return r.dryRunUpgrade(cfm, metricsClient, eventClient, instance, history, validatorResult, reqLogger)
+		}
 		if !validatorResult.IsValid {
 			reqLogger.Info(validatorResult.Message)
 			metricsClient.UpdateMetricValidationFailed(instance.Name)
@@ -185,7 +224,6 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 		}
 		reqLogger.Info("UpgradeConfig validated and confirmed for upgrade.")
 
-		cfm := r.configManagerBuilder.New(r.client, request.Namespace)
 		cfg := &config{}
 		err = cfm.Into(cfg)
 		if err != nil {
@@ -193,8 +231,25 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 		}
 
 		reqLogger.Info("Checking if cluster can commence upgrade.")
-		schedulerResult := r.scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration())
+		jitter := scheduler.CommenceJitter(string(clusterVersion.Spec.ClusterID), cfg.GetCommenceJitterMaxDuration())
+		schedulerResult := r.scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration(), jitter)
 		if schedulerResult.IsReady {
+			// Let the cluster settle on its current version before upgrading it again
+			untilSettled, err := timeUntilSettled(clusterVersion, cfg.GetMinimumUptimeDuration())
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if untilSettled > 0 {
+				reqLogger.Info("Cluster has not been on its current version for the minimum uptime, deferring the upgrade", "remaining", untilSettled)
+				history.Phase = upgradev1alpha1.UpgradePhasePending
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{RequeueAfter: untilSettled}, nil
+			}
+
 			ucMgr, err := r.ucMgrBuilder.NewManager(r.client)
 			if err != nil {
 				return reconcile.Result{}, err
@@ -220,6 +275,12 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 				return reconcile.Result{}, err
 			}
 
+			// Hold up the deletion of the UpgradeConfig until the upgrade is cleaned up
+			err = r.ensureFinalizer(instance)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
 			now := time.Now()
 			history.Phase = upgradev1alpha1.UpgradePhaseUpgrading
 			history.StartTime = &metav1.Time{Time: now}
@@ -229,8 +290,10 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 				return reconcile.Result{}, err
 			}
 
+			recordUpgradeStart(metricsClient, instance, cfg, now)
+			r.recorder.Event(instance, corev1.EventTypeNormal, eventmanager.EVENT_REASON_UPGRADE_STARTED, fmt.Sprintf("Cluster is commencing its upgrade to version %s", instance.Spec.Desired.Version))
 			reqLogger.Info("Cluster is commencing upgrade.", "time", now)
-			return r.upgradeCluster(upgrader, instance, reqLogger)
+			return r.upgradeCluster(upgrader, metricsClient, cfg, instance, reqLogger)
 		}
 
 		history.Phase = upgradev1alpha1.UpgradePhasePending
@@ -256,13 +319,37 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		return r.upgradeCluster(upgrader, instance, reqLogger)
+		cfg := &config{}
+		err = cfm.Into(cfg)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if isCancelRequested(instance) {
+			cvClient := r.cvClientBuilder.New(r.client)
+			commenced, err := cvClient.HasUpgradeCommenced(instance)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !commenced {
+				return r.cancelUpgrade(upgrader, instance, history, reqLogger)
+			}
+			reqLogger.Info("Cancel requested after the cluster commenced upgrading, continuing the upgrade")
+		}
+		// Upgrades commenced before the finalizer was introduced do not carry it
+		err = r.ensureFinalizer(instance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		return r.upgradeCluster(upgrader, metricsClient, cfg, instance, reqLogger)
 	case upgradev1alpha1.UpgradePhaseUpgraded:
 		reqLogger.Info("Cluster is already upgraded")
 		return reconcile.Result{}, nil
 	case upgradev1alpha1.UpgradePhaseFailed:
 		reqLogger.Info("Cluster has failed to upgrade")
 		return reconcile.Result{}, nil
+	case upgradev1alpha1.UpgradePhaseCancelled:
+		reqLogger.Info("Cluster upgrade has been cancelled")
+		return reconcile.Result{}, nil
 	default:
 		reqLogger.Info("Unknown status")
 	}
@@ -270,41 +357,181 @@ func (r *ReconcileUpgradeConfig) Reconcile(request reconcile.Request) (reconcile
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, uc *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (reconcile.Result, error) {
+// timeUntilSettled returns how long remains until the cluster has been on its current version for
+// the minimum uptime, which is nothing once it has or when there is no minimum
+func timeUntilSettled(clusterVersion *configv1.ClusterVersion, minimumUptime time.Duration) (time.Duration, error) {
+	if minimumUptime <= 0 {
+		return 0, nil
+	}
+	completionTime, err := cv.GetCurrentVersionCompletionTime(clusterVersion)
+	if err != nil {
+		return 0, err
+	}
+	remaining := minimumUptime - time.Since(completionTime.Time)
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// isSelected indicates whether the instance is the UpgradeConfig acted upon amongst those in its
+// namespace, recording in its history why it is not otherwise. Reconciles are not concurrent
+// and read from the cluster rather than a cache, so two UpgradeConfigs cannot both be selected
+// to commence.
+func (r *ReconcileUpgradeConfig) isSelected(instance *upgradev1alpha1.UpgradeConfig, history *upgradev1alpha1.UpgradeHistory, logger logr.Logger) (bool, error) {
+	ucList := &upgradev1alpha1.UpgradeConfigList{}
+	err := r.client.List(context.TODO(), ucList, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return false, err
+	}
+
+	selected := selectUpgradeConfig(ucList.Items)
+	if selected == nil || selected.Name == instance.Name {
+		if history.Conditions.RemoveCondition(upgradev1alpha1.UpgradeConfigSelected) {
+			instance.Status.History.SetHistory(*history)
+			return true, r.client.Status().Update(context.TODO(), instance)
+		}
+		return true, nil
+	}
+
+	message := fmt.Sprintf("UpgradeConfig %s takes precedence, this UpgradeConfig is ignored", selected.Name)
+	logger.Info(message)
+	changed := history.Conditions.SetCondition(upgradev1alpha1.UpgradeCondition{
+		Type:    upgradev1alpha1.UpgradeConfigSelected,
+		Status:  corev1.ConditionFalse,
+		Reason:  "NotSelected",
+		Message: message,
+	})
+	if !changed {
+		return false, nil
+	}
+	instance.Status.History.SetHistory(*history)
+	return false, r.client.Status().Update(context.TODO(), instance)
+}
+
+func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, metricsClient metrics.Metrics, cfg *config, uc *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (reconcile.Result, error) {
 	me := &multierror.Error{}
 
-	phase, condition, err := upgrader.UpgradeCluster(uc, logger)
-	me = multierror.Append(err, me)
+	// A failing upgrade is retried less often the longer it keeps failing, rather than requeued
+	// with the error, so that it does not flood the cluster and logs
+	requeueAfter := upgradingRequeueInterval
+	phase, condition, upgradeErr := upgrader.UpgradeCluster(uc, logger)
+	if upgradeErr != nil {
+		failures, backoff := recordUpgradeFailure(uc, cfg.GetMaxRequeueBackoff())
+		logger.Error(upgradeErr, fmt.Sprintf("Upgrade failed %d consecutive times, retrying in %s", failures, backoff))
+		condition.Message = fmt.Sprintf("%s, retrying in %s", condition.Message, backoff)
+		requeueAfter = backoff
+	} else {
+		resetUpgradeFailures(uc)
+	}
 
 	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	stepStarted := history.Conditions.GetCondition(condition.Type) == nil
 	history.Conditions = upgradev1alpha1.Conditions{*condition}
+	if isCancelRequested(uc) {
+		history.Conditions = append(history.Conditions, cancelRejectedCondition())
+	}
 	history.Phase = phase
 	if phase == upgradev1alpha1.UpgradePhaseUpgraded {
 		history.CompleteTime = &metav1.Time{Time: time.Now()}
 	}
 	uc.Status.History.SetHistory(*history)
-	err = r.client.Status().Update(context.TODO(), uc)
+	err := r.client.Status().Update(context.TODO(), uc)
 	me = multierror.Append(err, me)
 
-	return reconcile.Result{RequeueAfter: 1 * time.Minute}, me.ErrorOrNil()
+	// Only record the end of the upgrade once it has been persisted, so it is recorded once
+	if err == nil {
+		r.recordUpgradeEvents(uc, phase, condition, stepStarted, upgradeErr)
+		switch phase {
+		case upgradev1alpha1.UpgradePhaseUpgraded:
+			if history.StartTime != nil {
+				metricsClient.UpdateMetricUpgradeDuration(uc.Name, history.Version, history.CompleteTime.Sub(history.StartTime.Time))
+			}
+			recordUpgradeCompletion(metricsClient, uc, cfg, history.CompleteTime.Time)
+		case upgradev1alpha1.UpgradePhaseFailed:
+			metricsClient.UpdateMetricUpgradeFailed(uc.Name, string(condition.Type))
+		}
+
+		// Nothing is left to clean up once the upgrade has finished
+		if phase == upgradev1alpha1.UpgradePhaseUpgraded || phase == upgradev1alpha1.UpgradePhaseFailed {
+			me = multierror.Append(r.removeFinalizer(uc), me)
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, me.ErrorOrNil()
+}
+
+// recordUpgradeEvents records the milestones the upgrade reached as Kubernetes events. A step is
+// only reported as started when the upgrade first reaches it, and repeated identical failures are
+// only recorded once.
+func (r *ReconcileUpgradeConfig) recordUpgradeEvents(uc *upgradev1alpha1.UpgradeConfig, phase upgradev1alpha1.UpgradePhase, condition *upgradev1alpha1.UpgradeCondition, stepStarted bool, upgradeErr error) {
+	switch phase {
+	case upgradev1alpha1.UpgradePhaseUpgraded:
+		r.recorder.Event(uc, corev1.EventTypeNormal, eventmanager.EVENT_REASON_UPGRADE_COMPLETED, fmt.Sprintf("Cluster is upgraded to version %s", uc.Spec.Desired.Version))
+		return
+	case upgradev1alpha1.UpgradePhaseFailed:
+		r.recorder.Event(uc, corev1.EventTypeWarning, eventmanager.EVENT_REASON_UPGRADE_FAILED, fmt.Sprintf("Upgrade to version %s failed: %s", uc.Spec.Desired.Version, condition.Message))
+		return
+	}
+
+	if stepStarted && condition.Type != "" {
+		r.recorder.Event(uc, corev1.EventTypeNormal, eventmanager.EVENT_REASON_STEP_STARTED, fmt.Sprintf("Upgrade step %s is in progress", condition.Type))
+	}
+	if upgradeErr != nil {
+		r.recorder.Event(uc, corev1.EventTypeWarning, eventmanager.EVENT_REASON_STEP_FAILED, fmt.Sprintf("Upgrade step %s failed and will be retried: %v", condition.Type, upgradeErr))
+	}
+}
+
+// recordUpgradeStart records whether the upgrade commenced within its upgrade window
+func recordUpgradeStart(metricsClient metrics.Metrics, uc *upgradev1alpha1.UpgradeConfig, cfg *config, startTime time.Time) {
+	upgradeAt, err := time.Parse(time.RFC3339, uc.Spec.UpgradeAt)
+	if err != nil {
+		return
+	}
+	metricsClient.UpdateMetricUpgradeStartedInWindow(uc.Name, !startTime.After(upgradeAt.Add(cfg.GetUpgradeWindowTimeOutDuration())))
+}
+
+// recordUpgradeCompletion records whether the upgrade completed within the expected duration of its window
+func recordUpgradeCompletion(metricsClient metrics.Metrics, uc *upgradev1alpha1.UpgradeConfig, cfg *config, completeTime time.Time) {
+	upgradeAt, err := time.Parse(time.RFC3339, uc.Spec.UpgradeAt)
+	if err != nil {
+		return
+	}
+	metricsClient.UpdateMetricUpgradeWindowExceeded(uc.Name, completeTime.After(upgradeAt.Add(cfg.GetUpgradeWindowDuration())))
 }
 
-var OSDUpgradePredicate = predicate.Funcs{
+// OperatorConfigPredicate only admits updates to the operator's configuration config map
+var OperatorConfigPredicate = predicate.Funcs{
 	UpdateFunc: func(e event.UpdateEvent) bool {
-		return isOsdUpgrade(e.MetaNew.GetName())
+		return e.MetaNew.GetName() == configmanager.CONFIG_MAP_NAME
 	},
-	// Create is required to avoid reconciliation at controller initialisation.
 	CreateFunc: func(e event.CreateEvent) bool {
-		return isOsdUpgrade(e.Meta.GetName())
+		return false
 	},
 	DeleteFunc: func(e event.DeleteEvent) bool {
-		return isOsdUpgrade(e.Meta.GetName())
+		return false
 	},
 	GenericFunc: func(e event.GenericEvent) bool {
-		return isOsdUpgrade(e.Meta.GetName())
+		return false
 	},
 }
 
-func isOsdUpgrade(name string) bool {
-	return name == ucmgr.UPGRADECONFIG_CR_NAME
+// configMapToUpgradeConfigs reconciles the UpgradeConfigs in the namespace of the operator's
+// configuration alongside it, falling back to the default UpgradeConfig if they can not be listed
+func configMapToUpgradeConfigs(c client.Client) handler.ToRequestsFunc {
+	return func(a handler.MapObject) []reconcile.Request {
+		ucList := &upgradev1alpha1.UpgradeConfigList{}
+		err := c.List(context.TODO(), ucList, client.InNamespace(a.Meta.GetNamespace()))
+		if err != nil {
+			log.Error(err, "Failed to list the UpgradeConfigs to reconcile")
+			return []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: a.Meta.GetNamespace(), Name: ucmgr.UPGRADECONFIG_CR_NAME}},
+			}
+		}
+		requests := []reconcile.Request{}
+		for _, uc := range ucList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: uc.Namespace, Name: uc.Name}})
+		}
+		return requests
+	}
 }
@@ -5,20 +5,27 @@ import (
 	"os"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega/gstruct"
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	mockUpgrader "github.com/openshift/managed-upgrade-operator/pkg/cluster_upgrader_builder/mocks"
 	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
 	configMocks "github.com/openshift/managed-upgrade-operator/pkg/configmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	schedulerMocks "github.com/openshift/managed-upgrade-operator/pkg/scheduler/mocks"
 	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
@@ -56,6 +63,7 @@ var _ = Describe("UpgradeConfigController", func() {
 		mockUCMgrBuilder           *ucMgrMocks.MockUpgradeConfigManagerBuilder
 		mockUCMgr                  *ucMgrMocks.MockUpgradeConfigManager
 		testScheme                 *runtime.Scheme
+		fakeRecorder               *record.FakeRecorder
 		cfg                        config
 		upgradingReconcileTime     time.Duration
 	)
@@ -79,6 +87,7 @@ var _ = Describe("UpgradeConfigController", func() {
 		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
 		mockUCMgrBuilder = ucMgrMocks.NewMockUpgradeConfigManagerBuilder(mockCtrl)
 		mockUCMgr = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		fakeRecorder = record.NewFakeRecorder(20)
 		upgradeConfigName = types.NamespacedName{
 			Name:      "osd-upgrade-config",
 			Namespace: "test-namespace",
@@ -90,6 +99,8 @@ var _ = Describe("UpgradeConfigController", func() {
 			},
 		}
 		upgradingReconcileTime = 1 * time.Minute
+		upgradeFailures = map[string]int{}
+		cleanupFailures = map[string]int{}
 		_ = os.Setenv("OPERATOR_NAMESPACE", "test-namespace")
 	})
 
@@ -109,6 +120,7 @@ var _ = Describe("UpgradeConfigController", func() {
 			mockCVClientBuilder,
 			mockEMBuilder,
 			mockUCMgrBuilder,
+			eventmanager.NewUpgradeEventRecorder(fakeRecorder),
 		}
 	})
 
@@ -171,6 +183,7 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fakeError),
+							mockMetricsClient.EXPECT().ResetUpgradeMetrics().Times(0),
 						)
 						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
 						Expect(err).To(Equal(fakeError))
@@ -200,19 +213,23 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+							mockMetricsClient.EXPECT().ResetUpgradeMetrics(),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
 							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
@@ -251,14 +268,173 @@ var _ = Describe("UpgradeConfigController", func() {
 						},
 					}
 				})
+				Context("When cancelling the upgrade is requested", func() {
+					It("cancels the upgrade without validating it", func() {
+						upgradeConfig.Annotations = map[string]string{CancelUpgradeAnnotation: "true"}
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Times(0),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						history := matcher.ActualUpgradeConfig.Status.History.GetHistory(version)
+						Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhaseCancelled))
+						Expect(history.Conditions.GetCondition(upgradev1alpha1.UpgradeCancelled).Status).To(Equal(corev1.ConditionTrue))
+						Expect(fakeRecorder.Events).To(Receive(Equal("Normal UpgradeCancelled Upgrade to version a version was cancelled")))
+					})
+				})
+				Context("When another UpgradeConfig takes precedence", func() {
+					It("records that it is ignored without validating it", func() {
+						other := testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "staged-upgrade-config", Namespace: upgradeConfigName.Namespace}).GetUpgradeConfig()
+						other.Spec.Priority = 1
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig, *other}}),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Times(0)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						condition := matcher.ActualUpgradeConfig.Status.History.GetHistory(version).Conditions.GetCondition(upgradev1alpha1.UpgradeConfigSelected)
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.IsFalse()).To(BeTrue())
+						Expect(condition.Message).To(ContainSubstring("staged-upgrade-config"))
+					})
+					It("acts upon the UpgradeConfig which takes precedence when it is reconciled", func() {
+						other := testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "staged-upgrade-config", Namespace: upgradeConfigName.Namespace}).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+						other.Spec.Priority = 1
+						otherName := types.NamespacedName{Name: other.Name, Namespace: other.Namespace}
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), otherName, gomock.Any()).SetArg(2, *other),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig, *other}}),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: false}, nil),
+							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(other.Name),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: otherName})
+						Expect(err).NotTo(HaveOccurred())
+					})
+					It("reconciles every UpgradeConfig when the operator's configuration changes", func() {
+						other := testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "staged-upgrade-config", Namespace: upgradeConfigName.Namespace}).GetUpgradeConfig()
+						mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig, *other}})
+						configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "managed-upgrade-operator-config", Namespace: upgradeConfigName.Namespace}}
+						requests := configMapToUpgradeConfigs(mockKubeClient)(handler.MapObject{Meta: configMap, Object: configMap})
+						Expect(requests).To(ConsistOf(
+							reconcile.Request{NamespacedName: upgradeConfigName},
+							reconcile.Request{NamespacedName: types.NamespacedName{Name: other.Name, Namespace: other.Namespace}},
+						))
+					})
+				})
+
+				Context("When a dry run is requested", func() {
+					var matcher *testStructs.UpgradeConfigMatcher
+					BeforeEach(func() {
+						upgradeConfig.Annotations = map[string]string{DryRunAnnotation: "true"}
+						matcher = testStructs.NewUpgradeConfigMatcher()
+					})
+					expectValidation := func(result validation.ValidatorResult) []*gomock.Call {
+						return []*gomock.Call{
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(result, nil),
+						}
+					}
+					expectNoChanges := func() {
+						mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+						mockKubeClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+						mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Times(0)
+					}
+
+					It("reports the planned steps and pre-flight checks without upgrading", func() {
+						calls := append(expectValidation(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockClusterUpgrader.EXPECT().PlanUpgrade(gomock.Any(), gomock.Any()).Return(
+								[]upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.UpgradePreHealthCheck, upgradev1alpha1.CommenceUpgrade},
+								upgradev1alpha1.Conditions{{Type: upgradev1alpha1.UpgradePreHealthCheck, Status: corev1.ConditionTrue, Reason: "PreHealthCheck passed"}},
+								nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						gomock.InOrder(calls...)
+						expectNoChanges()
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						history := matcher.ActualUpgradeConfig.Status.History.GetHistory(version)
+						Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhaseNew))
+						condition := history.Conditions.GetCondition(upgradev1alpha1.UpgradeDryRun)
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.IsTrue()).To(BeTrue())
+						Expect(condition.Message).To(ContainSubstring("PreHealthCheck, CommenceUpgrade"))
+						Expect(condition.Message).To(ContainSubstring("PreHealthCheck passed"))
+					})
+
+					It("reports failed pre-flight checks", func() {
+						calls := append(expectValidation(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockClusterUpgrader.EXPECT().PlanUpgrade(gomock.Any(), gomock.Any()).Return(
+								[]upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.UpgradePreHealthCheck},
+								upgradev1alpha1.Conditions{{Type: upgradev1alpha1.UpgradePreHealthCheck, Status: corev1.ConditionFalse, Reason: "PreHealthCheck failed", Message: "there are 2 critical alerts"}},
+								nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						gomock.InOrder(calls...)
+						expectNoChanges()
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						condition := matcher.ActualUpgradeConfig.Status.History.GetHistory(version).Conditions.GetCondition(upgradev1alpha1.UpgradeDryRun)
+						Expect(condition.IsFalse()).To(BeTrue())
+						Expect(condition.Reason).To(Equal("DryRunChecksFailed"))
+						Expect(condition.Message).To(ContainSubstring("PreHealthCheck failed: there are 2 critical alerts"))
+					})
+
+					It("reports that an invalid UpgradeConfig would not be accepted", func() {
+						calls := append(expectValidation(validation.ValidatorResult{IsValid: false, Message: "fake validation failure"}),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						gomock.InOrder(calls...)
+						expectNoChanges()
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						mockMetricsClient.EXPECT().UpdateMetricValidationFailed(gomock.Any()).Times(0)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						condition := matcher.ActualUpgradeConfig.Status.History.GetHistory(version).Conditions.GetCondition(upgradev1alpha1.UpgradeDryRun)
+						Expect(condition.IsFalse()).To(BeTrue())
+						Expect(condition.Reason).To(Equal("DryRunRejected"))
+						Expect(condition.Message).To(ContainSubstring("fake validation failure"))
+					})
+				})
+
 				Context("When the upgradeconfig validation fails", func() {
 					It("should set the validation alert metric", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: false, IsAvailableUpdate: false}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationFailed(gomock.Any()),
 						)
@@ -272,9 +448,11 @@ var _ = Describe("UpgradeConfigController", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: false}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 						)
@@ -288,14 +466,15 @@ var _ = Describe("UpgradeConfigController", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: false}),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: false}),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
 						)
@@ -303,6 +482,28 @@ var _ = Describe("UpgradeConfigController", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(upgradeConfig.Status.History.GetHistory("a version").Phase == upgradev1alpha1.UpgradePhasePending).To(BeTrue())
 					})
+					It("delays the commencement by the cluster's jitter", func() {
+						clusterVersion.Spec.ClusterID = "a-cluster-id"
+						cfg.UpgradeWindow.CommenceJitter = 30
+						jitter := scheduler.CommenceJitter("a-cluster-id", 30*time.Minute)
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
+							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), 60*time.Minute, jitter).Return(scheduler.SchedulerResult{IsReady: false}),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+					})
 				})
 
 				Context("When the cluster is ready to upgrade", func() {
@@ -322,12 +523,13 @@ var _ = Describe("UpgradeConfigController", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).Return(fmt.Errorf("config error")),
 						)
 						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
@@ -338,19 +540,22 @@ var _ = Describe("UpgradeConfigController", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
 							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{Message: "test passed"}, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
@@ -359,18 +564,76 @@ var _ = Describe("UpgradeConfigController", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(result.Requeue).To(BeFalse())
 					})
+					Context("When a minimum uptime on the current version is configured", func() {
+						BeforeEach(func() {
+							cfg.UpgradeWindow.MinimumUptime = 60
+						})
+						completedAgo := func(ago time.Duration) {
+							clusterVersion.Status.History[0].CompletionTime = &metav1.Time{Time: time.Now().Add(-ago)}
+							clusterVersion.Status.History[1].CompletionTime = &metav1.Time{Time: time.Now().Add(-48 * time.Hour)}
+						}
+						expectReady := func() []*gomock.Call {
+							return []*gomock.Call{
+								mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+								mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+								mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
+								mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+								mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+								mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
+								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
+								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
+								mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							}
+						}
+						It("defers the upgrade of a recently upgraded cluster", func() {
+							completedAgo(20 * time.Minute)
+							matcher := testStructs.NewUpgradeConfigMatcher()
+							calls := append(expectReady(),
+								mockKubeClient.EXPECT().Status().Return(mockUpdater),
+								mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+							)
+							gomock.InOrder(calls...)
+							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Times(0)
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Times(0)
+							result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(BeNumerically("~", 40*time.Minute, time.Minute))
+							Expect(matcher.ActualUpgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+						})
+						It("upgrades a cluster which has been on its version for long enough", func() {
+							completedAgo(24 * time.Hour)
+							calls := append(expectReady(),
+								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
+								mockUCMgr.EXPECT().Refresh().Return(false, nil),
+								mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+								mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+								mockKubeClient.EXPECT().Status().Return(mockUpdater),
+								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+								mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
+								mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{Message: "test passed"}, nil),
+								mockKubeClient.EXPECT().Status().Return(mockUpdater),
+								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							)
+							gomock.InOrder(calls...)
+							_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+							Expect(err).NotTo(HaveOccurred())
+						})
+					})
 					It("Remote upgrade policy changed", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(true, nil),
 						)
@@ -383,22 +646,28 @@ var _ = Describe("UpgradeConfigController", func() {
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-							mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
 							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgraded, &upgradev1alpha1.UpgradeCondition{Message: "test passed"}, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeDuration(upgradeConfigName.Name, version, gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowExceeded(upgradeConfigName.Name, false),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 						)
 						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
 						Expect(err).NotTo(HaveOccurred())
@@ -406,6 +675,36 @@ var _ = Describe("UpgradeConfigController", func() {
 						Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
 						Expect(upgradeConfig.Status.History.GetHistory("a version").Phase == upgradev1alpha1.UpgradePhaseUpgraded).To(BeTrue())
 						Expect(upgradeConfig.Status.History.GetHistory("a version").Conditions[0].Message == "test passed").To(BeTrue())
+						Expect(fakeRecorder.Events).To(Receive(Equal("Normal UpgradeStarted Cluster is commencing its upgrade to version a version")))
+						Expect(fakeRecorder.Events).To(Receive(Equal("Normal UpgradeCompleted Cluster is upgraded to version a version")))
+					})
+					It("records that the upgrade started late if the upgrade window has elapsed", func() {
+						upgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
+							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
+							mockUCMgr.EXPECT().Refresh().Return(false, nil),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, false),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
 					})
 				})
 			})
@@ -438,12 +737,13 @@ var _ = Describe("UpgradeConfigController", func() {
 								mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
 								mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(nil, fakeError),
 								mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Times(0),
+								mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 								mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 								mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-								mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+								mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 								mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							)
@@ -471,19 +771,22 @@ var _ = Describe("UpgradeConfigController", func() {
 							gomock.InOrder(
 								mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 								mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+								mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 								mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 								mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-								mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+								mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 								mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 								mockUCMgr.EXPECT().Refresh().Return(false, nil),
 								mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+								mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
 								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+								mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
 								mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
 								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
@@ -514,25 +817,28 @@ var _ = Describe("UpgradeConfigController", func() {
 							gomock.InOrder(
 								mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 								mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+								mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 								mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 								mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-								mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+								mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 								mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
 								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 								mockUCMgr.EXPECT().Refresh().Return(false, nil),
 								mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+								mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
 								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+								mockMetricsClient.EXPECT().UpdateMetricUpgradeStartedInWindow(upgradeConfigName.Name, true),
 								mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, fakeError),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
 								mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
 							)
 							result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
-							Expect(err).To(HaveOccurred())
+							Expect(err).NotTo(HaveOccurred())
 							Expect(result.Requeue).To(BeFalse())
 							Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
 						})
@@ -559,14 +865,15 @@ var _ = Describe("UpgradeConfigController", func() {
 					gomock.InOrder(
 						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, upgradev1alpha1.UpgradeConfigList{Items: []upgradev1alpha1.UpgradeConfig{*upgradeConfig}}),
 						mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
 						mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
-						mockValidationBuilder.EXPECT().NewClient().Return(mockValidator, nil),
+						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+						mockValidationBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any()).Return(mockValidator, nil),
 						mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 						mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
-						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 						mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
-						mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: false}),
+						mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: false}),
 						mockKubeClient.EXPECT().Status().Return(mockUpdater),
 						mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
 					)
@@ -581,6 +888,7 @@ var _ = Describe("UpgradeConfigController", func() {
 			Context("When the upgrade phase is Upgrading", func() {
 				BeforeEach(func() {
 					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgrading
+					upgradeConfig.Finalizers = []string{UpgradeConfigFinalizer}
 				})
 
 				Context("When a cluster upgrade client can't be built", func() {
@@ -607,6 +915,7 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
 							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
 							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
@@ -618,26 +927,435 @@ var _ = Describe("UpgradeConfigController", func() {
 					})
 				})
 
+				Context("When the UpgradeConfig being upgraded is not the default one", func() {
+					It("notifies for that UpgradeConfig", func() {
+						staged := upgradeConfig.DeepCopy()
+						staged.Name = "staged-upgrade-config"
+						stagedName := types.NamespacedName{Name: staged.Name, Namespace: staged.Namespace}
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						var eventClient eventmanager.EventManager
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), stagedName, gomock.Any()).SetArg(2, *staged),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), staged.Spec.Type).Do(
+								func(_, _, _ interface{}, ec eventmanager.EventManager, _ interface{}) {
+									eventClient = ec
+								}).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).DoAndReturn(
+								func(uc *upgradev1alpha1.UpgradeConfig, _ logr.Logger) (upgradev1alpha1.UpgradePhase, *upgradev1alpha1.UpgradeCondition, error) {
+									return upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, eventClient.Notify(uc, notifier.StateStarted)
+								}),
+							mockEMClient.EXPECT().Notify(matcher, notifier.StateStarted),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: stagedName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(matcher.ActualUpgradeConfig.Name).To(Equal(staged.Name))
+					})
+				})
+
+				Context("When the UpgradeConfig does not carry the finalizer", func() {
+					It("adds it before upgrading the cluster", func() {
+						upgradeConfig.Finalizers = nil
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockKubeClient.EXPECT().Update(gomock.Any(), matcher),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(matcher.ActualUpgradeConfig.Finalizers).To(ContainElement(UpgradeConfigFinalizer))
+					})
+				})
+
+				Context("When cancelling the upgrade is requested", func() {
+					BeforeEach(func() {
+						upgradeConfig.Annotations = map[string]string{CancelUpgradeAnnotation: "true"}
+					})
+					It("cleans up and cancels the upgrade if the cluster has not commenced upgrading", func() {
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+							mockClusterUpgrader.EXPECT().CleanupUpgrade(gomock.Any(), gomock.Any()),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Times(0),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						history := matcher.ActualUpgradeConfig.Status.History.GetHistory(version)
+						Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhaseCancelled))
+						Expect(history.CompleteTime).NotTo(BeNil())
+					})
+					It("does not cancel the upgrade if the cleanup fails", func() {
+						fakeError := fmt.Errorf("fake error")
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+							mockClusterUpgrader.EXPECT().CleanupUpgrade(gomock.Any(), gomock.Any()).Return(fakeError),
+							mockKubeClient.EXPECT().Status().Times(0),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).To(Equal(fakeError))
+					})
+					It("continues the upgrade and explains why if the cluster has commenced upgrading", func() {
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil),
+							mockClusterUpgrader.EXPECT().CleanupUpgrade(gomock.Any(), gomock.Any()).Times(0),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.ControlPlaneUpgraded}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						history := matcher.ActualUpgradeConfig.Status.History.GetHistory(version)
+						Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+						condition := history.Conditions.GetCondition(upgradev1alpha1.UpgradeCancelled)
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+						Expect(condition.Message).To(ContainSubstring("already commenced upgrading"))
+					})
+				})
+
+				Context("When the upgrade completes", func() {
+					It("records the duration of the upgrade since it commenced", func() {
+						upgradeConfig.Status.History[0].StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgraded, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeDuration(upgradeConfigName.Name, version, gomock.Any()).Do(
+								func(name, version string, duration time.Duration) {
+									Expect(duration).To(BeNumerically("~", 2*time.Hour, time.Minute))
+								}),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowExceeded(upgradeConfigName.Name, false),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+					})
+					It("does not record the duration if the status cannot be updated", func() {
+						upgradeConfig.Status.History[0].StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgraded, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update failed")),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeDuration(gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowExceeded(gomock.Any(), gomock.Any()).Times(0),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).To(HaveOccurred())
+					})
+					It("records that the upgrade exceeded its window if it completed after the window duration", func() {
+						upgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+						upgradeConfig.Status.History[0].StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+						cfg.UpgradeWindow.Duration = 90
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgraded, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeDuration(upgradeConfigName.Name, version, gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowExceeded(upgradeConfigName.Name, true),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				Context("When the upgrade fails", func() {
+					It("records the reason for the failure", func() {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseFailed, &upgradev1alpha1.UpgradeCondition{Type: "FailedUpgrade"}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeFailed(upgradeConfigName.Name, "FailedUpgrade"),
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
 				Context("When invoking the upgrader fails", func() {
 					var fakeError = fmt.Errorf("the upgrader failed")
+					var failureKey string
+					BeforeEach(func() {
+						failureKey = upgradeConfigName.Namespace + "/" + upgradeConfigName.Name
+					})
 					It("reacts accordingly", func() {
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{Message: "step failed"}, fakeError),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
+						Expect(upgradeFailures[failureKey]).To(Equal(1))
+						Expect(matcher.ActualUpgradeConfig.Status.History.GetHistory(version).Conditions[0].Message).To(Equal("step failed, retrying in 1m0s"))
+					})
+					It("backs off further with each consecutive failure", func() {
+						upgradeFailures[failureKey] = 2
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
 							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
 							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, fakeError),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
 							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
 						)
 						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
-						Expect(err).To(HaveOccurred())
-						Expect(result.Requeue).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(4 * upgradingReconcileTime))
+					})
+					It("does not back off further than the maximum", func() {
+						upgradeFailures[failureKey] = 20
+						cfg.Requeue.MaxBackoff = 10
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, fakeError),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+					})
+					It("resets the backoff once the upgrade succeeds", func() {
+						upgradeFailures[failureKey] = 5
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
 						Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
+						Expect(upgradeFailures).NotTo(HaveKey(failureKey))
+					})
+				})
+
+				Context("When recording the upgrade's events", func() {
+					var fakeError = fmt.Errorf("fake error")
+					reconcileUpgrade := func(phase upgradev1alpha1.UpgradePhase, condition upgradev1alpha1.UpgradeCondition, upgradeErr error) {
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(phase, &condition, upgradeErr),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						if phase == upgradev1alpha1.UpgradePhaseUpgraded {
+							mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowExceeded(upgradeConfigName.Name, gomock.Any())
+							mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any())
+						}
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						upgradeConfig = &matcher.ActualUpgradeConfig
+					}
+					recordedEvents := func() []string {
+						events := []string{}
+						for len(fakeRecorder.Events) > 0 {
+							events = append(events, <-fakeRecorder.Events)
+						}
+						return events
+					}
+
+					It("records each step transition and failure of the upgrade once", func() {
+						controlPlane := upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.ControlPlaneUpgraded, Status: corev1.ConditionFalse}
+						workers := upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.AllWorkerNodesUpgraded, Status: corev1.ConditionFalse}
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, controlPlane, nil)
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, controlPlane, nil)
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, controlPlane, fakeError)
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, controlPlane, fakeError)
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, workers, nil)
+						workers.Status = corev1.ConditionTrue
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgraded, workers, nil)
+						Expect(recordedEvents()).To(Equal([]string{
+							"Normal UpgradeStepStarted Upgrade step ControlPlaneUpgraded is in progress",
+							"Warning UpgradeStepFailed Upgrade step ControlPlaneUpgraded failed and will be retried: fake error",
+							"Normal UpgradeStepStarted Upgrade step AllWorkerNodesUpgraded is in progress",
+							"Normal UpgradeCompleted Cluster is upgraded to version a version",
+						}))
+					})
+					It("records the failure of the upgrade", func() {
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseUpgrading, upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.ControlPlaneUpgraded, Status: corev1.ConditionFalse}, nil)
+						mockMetricsClient.EXPECT().UpdateMetricUpgradeFailed(upgradeConfigName.Name, "FailedUpgrade")
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any())
+						reconcileUpgrade(upgradev1alpha1.UpgradePhaseFailed, upgradev1alpha1.UpgradeCondition{Type: "FailedUpgrade", Status: corev1.ConditionTrue, Message: "the worker pool is degraded"}, nil)
+						Expect(recordedEvents()).To(Equal([]string{
+							"Normal UpgradeStepStarted Upgrade step ControlPlaneUpgraded is in progress",
+							"Warning UpgradeFailed Upgrade to version a version failed: the worker pool is degraded",
+						}))
+					})
+					It("does not record events that could not be persisted", func() {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, &upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.ControlPlaneUpgraded}, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update failed")),
+						)
+						_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).To(HaveOccurred())
+						Expect(recordedEvents()).To(BeEmpty())
 					})
 				})
 			})
 
+			Context("When the UpgradeConfig is being deleted", func() {
+				var cleanupKey string
+				BeforeEach(func() {
+					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgrading
+					upgradeConfig.Finalizers = []string{UpgradeConfigFinalizer}
+					upgradeConfig.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+					cleanupKey = upgradeConfigName.Namespace + "/" + upgradeConfigName.Name
+				})
+
+				It("cleans up the upgrade and removes the finalizer", func() {
+					matcher := testStructs.NewUpgradeConfigMatcher()
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+						mockClusterUpgrader.EXPECT().CleanupUpgrade(gomock.Any(), gomock.Any()),
+						mockKubeClient.EXPECT().Update(gomock.Any(), matcher),
+					)
+					_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(matcher.ActualUpgradeConfig.Finalizers).NotTo(ContainElement(UpgradeConfigFinalizer))
+				})
+
+				It("retries a failed cleanup before removing the finalizer", func() {
+					fakeError := fmt.Errorf("fake error")
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+						mockClusterUpgrader.EXPECT().CleanupUpgrade(gomock.Any(), gomock.Any()).Return(fakeError),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0),
+					)
+					_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).To(Equal(fakeError))
+					Expect(cleanupFailures[cleanupKey]).To(Equal(1))
+				})
+
+				It("removes the finalizer once the cleanup attempts are exhausted", func() {
+					cleanupFailures[cleanupKey] = maxCleanupAttempts - 1
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(nil, fmt.Errorf("fake error")),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+					)
+					_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cleanupFailures).NotTo(HaveKey(cleanupKey))
+				})
+
+				It("removes the finalizer without cleaning up if the upgrade is not underway", func() {
+					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgraded
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+					)
+					_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does nothing if the finalizer has already been removed", func() {
+					upgradeConfig.Finalizers = nil
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0),
+					)
+					_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("When the upgrade phase is Failed", func() {
 				BeforeEach(func() {
 					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseFailed
@@ -655,6 +1373,23 @@ var _ = Describe("UpgradeConfigController", func() {
 				})
 			})
 
+			Context("When the upgrade phase is Cancelled", func() {
+				BeforeEach(func() {
+					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseCancelled
+				})
+				It("does nothing", func() {
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Times(0),
+					)
+					result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					Expect(result.RequeueAfter).To(BeZero())
+				})
+			})
+
 			Context("When the upgrade phase is Unknown", func() {
 				BeforeEach(func() {
 					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUnknown
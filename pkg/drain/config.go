@@ -1,12 +1,79 @@
 package drain
 
 import (
+	"fmt"
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+const defaultStuckPodGracePeriod = 5 * time.Minute
+
 type NodeDrain struct {
 	Timeout               int `yaml:"timeOut"`
 	ExpectedNodeDrainTime int `yaml:"expectedNodeDrainTime" default:"8"`
+	// DisablePDBForceDrain stops pods protected by a PodDisruptionBudget from being forcibly
+	// removed once the UpgradeConfig's PDBForceDrainTimeout has passed
+	DisablePDBForceDrain bool `yaml:"disablePDBForceDrain"`
+	// StuckPodGracePeriod is the number of minutes a pod may remain terminating past its
+	// deletion time before it is force deleted
+	StuckPodGracePeriod int `yaml:"stuckPodGracePeriod"`
+	// Strategy names the registered drain strategy used for the node, defaulting to DefaultStrategyName
+	Strategy string `yaml:"strategy"`
+	// ExcludedNamespaces and ExcludedPodSelectors name pods that the operator never removes
+	// itself, leaving their eviction to the machine-config-operator's own drain
+	ExcludedNamespaces   []string `yaml:"excludedNamespaces"`
+	ExcludedPodSelectors []string `yaml:"excludedPodSelectors"`
+	// DeleteEmptyDirData allows pods using emptyDir volumes to be deleted, losing their data,
+	// in the same way as kubectl drain --delete-emptydir-data. Such pods are skipped when unset.
+	DeleteEmptyDirData bool `yaml:"deleteEmptyDirData"`
+}
+
+func (nd *NodeDrain) IsValid() error {
+	if nd.StuckPodGracePeriod < 0 {
+		return fmt.Errorf("config nodeDrain stuckPodGracePeriod is invalid")
+	}
+	if _, err := getStrategy(nd.GetStrategyName()); err != nil {
+		return fmt.Errorf("config nodeDrain strategy is invalid: %v", err)
+	}
+	for _, ns := range nd.ExcludedNamespaces {
+		if ns == "" {
+			return fmt.Errorf("config nodeDrain excludedNamespaces cannot contain an empty namespace")
+		}
+	}
+	if _, err := nd.GetExcludedPodSelectors(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nd *NodeDrain) GetExcludedPodSelectors() ([]labels.Selector, error) {
+	selectors := []labels.Selector{}
+	for _, s := range nd.ExcludedPodSelectors {
+		if s == "" {
+			return nil, fmt.Errorf("config nodeDrain excludedPodSelectors cannot contain an empty selector")
+		}
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("config nodeDrain excludedPodSelectors contains an invalid selector %q: %v", s, err)
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+func (nd *NodeDrain) GetStuckPodGracePeriodDuration() time.Duration {
+	if nd.StuckPodGracePeriod == 0 {
+		return defaultStuckPodGracePeriod
+	}
+	return time.Duration(nd.StuckPodGracePeriod) * time.Minute
+}
+
+func (nd *NodeDrain) GetStrategyName() string {
+	if nd.Strategy == "" {
+		return DefaultStrategyName
+	}
+	return nd.Strategy
 }
 
 func (nd *NodeDrain) GetTimeOutDuration() time.Duration {
@@ -3,6 +3,7 @@ package drain
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -19,6 +20,7 @@ var (
 	defaultPodFinalizerRemovalName = "DEFAULT-FINALIZER"
 	pdbPodFinalizerRemovalName     = "PDB-FINALIZER"
 	stuckTerminatingPodName        = "POD-STUCK-TERMINATING"
+	pdbStuckTerminatingPodName     = "PDB-POD-STUCK-TERMINATING"
 )
 
 func NewNodeDrainStrategy(c client.Client, cfg *NodeDrain, ts []TimedDrainStrategy) (NodeDrainStrategy, error) {
@@ -48,7 +50,10 @@ func (ds *osdDrainStrategy) Execute(node *corev1.Node) ([]*DrainStrategyResult,
 				r, err := ds.GetStrategy().Execute(node)
 				me = multierror.Append(err, me)
 				if r.HasExecuted {
-					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Drain strategy %s has been executed. %s", ds.GetDescription(), r.Message)})
+					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Drain strategy %s has been executed. %s", ds.GetDescription(), r.Message), PDBForced: r.PDBForced})
+				}
+				if len(r.SkippedPods) > 0 {
+					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Warning: drain strategy %s skipped pod(s) %s", ds.GetDescription(), strings.Join(r.SkippedPods, ", ")), SkippedPods: r.SkippedPods})
 				}
 			}
 		}
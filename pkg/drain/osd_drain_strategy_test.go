@@ -7,7 +7,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
@@ -99,6 +102,46 @@ var _ = Describe("OSD Drain Strategy", func() {
 			Expect(err).To(BeNil())
 			Expect(len(result)).To(Equal(0))
 		})
+		It("should report when the drain of PDB pods was forced", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne},
+			}
+			twoHoursAgo := &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: twoHoursAgo}),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*60),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any()).Return(&DrainStrategyResult{Message: "", HasExecuted: true, PDBForced: true}, nil),
+				mockTimedDrainOne.EXPECT().GetDescription().Return("PDB pod deletion"),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{})
+			Expect(err).To(BeNil())
+			Expect(len(result)).To(Equal(1))
+			Expect(result[0].PDBForced).To(BeTrue())
+		})
+		It("should warn about pods skipped by a strategy", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne},
+			}
+			twoHoursAgo := &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: twoHoursAgo}),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*60),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any()).Return(&DrainStrategyResult{SkippedPods: []string{"default/cache"}}, nil),
+				mockTimedDrainOne.EXPECT().GetDescription().Return("Default pod deletion"),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{})
+			Expect(err).To(BeNil())
+			Expect(len(result)).To(Equal(1))
+			Expect(result[0].Message).To(ContainSubstring("default/cache"))
+		})
 		It("should only execute Time Based Drain Strategy at the correct time if multiple strategies exist", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
@@ -123,6 +166,227 @@ var _ = Describe("OSD Drain Strategy", func() {
 		})
 	})
 
+	Context("Building the node drain strategies", func() {
+		var uc *upgradev1alpha1.UpgradeConfig
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			uc = &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{PDBForceDrainTimeout: 60}}
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		strategyNames := func(nds NodeDrainStrategy) []string {
+			names := []string{}
+			for _, ts := range nds.(*osdDrainStrategy).timedDrainStrategies {
+				names = append(names, ts.GetName())
+			}
+			return names
+		}
+		It("should force the drain of PDB pods after the PDB force drain timeout", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2)
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategyNames(nds)).To(ContainElement(pdbPodDeleteName))
+			Expect(strategyNames(nds)).To(ContainElement(pdbPodFinalizerRemovalName))
+			Expect(strategyNames(nds)).To(ContainElement(pdbStuckTerminatingPodName))
+		})
+		It("should not force the drain of PDB pods when disabled", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2)
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45, DisablePDBForceDrain: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategyNames(nds)).To(Equal([]string{defaultPodDeleteName, defaultPodFinalizerRemovalName, stuckTerminatingPodName}))
+		})
+		It("should use the default strategy when one is selected by name", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2)
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45, Strategy: DefaultStrategyName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategyNames(nds)).To(ContainElement(defaultPodDeleteName))
+		})
+		It("should not remove any pods when the none strategy is selected", func() {
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45, Strategy: NoneStrategyName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategyNames(nds)).To(BeEmpty())
+		})
+		It("should use a registered strategy when it is selected", func() {
+			RegisterStrategy("test", func(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) ([]TimedDrainStrategy, error) {
+				return []TimedDrainStrategy{newTimedStrategy("TEST", "Test strategy", cfg.GetTimeOutDuration(), &podDeletionStrategy{client: c})}, nil
+			})
+			defer delete(strategies, "test")
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45, Strategy: "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategyNames(nds)).To(Equal([]string{"TEST"}))
+		})
+		It("should fail on an unknown strategy", func() {
+			_, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, uc, &NodeDrain{Timeout: 45, Strategy: "unknown"})
+			Expect(err).To(HaveOccurred())
+			Expect((&NodeDrain{Strategy: "unknown"}).IsValid()).NotTo(Succeed())
+			Expect((&NodeDrain{}).IsValid()).To(Succeed())
+		})
+	})
+
+	Context("Excluding pods from the drain", func() {
+		var (
+			node *corev1.Node
+			pods corev1.PodList
+		)
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+			newPod := func(name, namespace string, labels map[string]string) corev1.Pod {
+				return corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+					Spec:       corev1.PodSpec{NodeName: node.Name},
+				}
+			}
+			mirrorPod := newPod("mirror", "openshift-etcd", nil)
+			mirrorPod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}
+			pods = corev1.PodList{Items: []corev1.Pod{
+				newPod("storage", "openshift-storage", nil),
+				newPod("critical", "default", map[string]string{"app": "critical"}),
+				newPod("workload", "default", map[string]string{"app": "workload"}),
+				mirrorPod,
+			}}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx interface{}, list runtime.Object, opts ...client.ListOption) error {
+					if podList, ok := list.(*corev1.PodList); ok {
+						*podList = pods
+					}
+					return nil
+				}).AnyTimes()
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		It("should not delete pods in excluded namespaces or matching excluded selectors", func() {
+			cfg := &NodeDrain{
+				Timeout:              45,
+				ExcludedNamespaces:   []string{"openshift-storage"},
+				ExcludedPodSelectors: []string{"app=critical"},
+			}
+			nds, err := NewBuilder().NewNodeDrainStrategy(mockKubeClient, &upgradev1alpha1.UpgradeConfig{}, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			var podDelete DrainStrategy
+			for _, ts := range nds.(*osdDrainStrategy).timedDrainStrategies {
+				if ts.GetName() == defaultPodDeleteName {
+					podDelete = ts.GetStrategy()
+				}
+			}
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx interface{}, obj runtime.Object, opts ...client.DeleteOption) error {
+					Expect(obj.(*corev1.Pod).Name).To(Equal("workload"))
+					return nil
+				})
+			result, err := podDelete.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+		})
+		It("should reject invalid exclusions", func() {
+			Expect((&NodeDrain{ExcludedNamespaces: []string{""}}).IsValid()).NotTo(Succeed())
+			Expect((&NodeDrain{ExcludedPodSelectors: []string{""}}).IsValid()).NotTo(Succeed())
+			Expect((&NodeDrain{ExcludedPodSelectors: []string{"app in (critical"}}).IsValid()).NotTo(Succeed())
+			Expect((&NodeDrain{ExcludedNamespaces: []string{"openshift-storage"}, ExcludedPodSelectors: []string{"app=critical"}}).IsValid()).To(Succeed())
+		})
+	})
+
+	Context("Deleting pods with emptyDir storage", func() {
+		var (
+			node     *corev1.Node
+			strategy *podDeletionStrategy
+		)
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+			strategy = &podDeletionStrategy{client: mockKubeClient}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "default"},
+					Spec: corev1.PodSpec{
+						NodeName: node.Name,
+						Volumes:  []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+					},
+				},
+			}})
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		It("should skip the pod by default", func() {
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			result, err := strategy.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(result.SkippedPods).To(HaveLen(1))
+			Expect(result.SkippedPods[0]).To(ContainSubstring("default/cache"))
+			Expect(result.SkippedPods[0]).To(ContainSubstring("scratch"))
+		})
+		It("should delete the pod when emptyDir data deletion is enabled", func() {
+			strategy.deleteEmptyDirData = true
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any())
+			result, err := strategy.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(result.SkippedPods).To(BeEmpty())
+		})
+	})
+
+	Context("Force deleting pods stuck terminating", func() {
+		var (
+			node     *corev1.Node
+			strategy *stuckTerminatingStrategy
+		)
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+			strategy = &stuckTerminatingStrategy{client: mockKubeClient, gracePeriod: 5 * time.Minute}
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		terminatingPod := func(name string, deletedAgo time.Duration) corev1.Pod {
+			return corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-deletedAgo)}},
+				Spec:       corev1.PodSpec{NodeName: node.Name},
+			}
+		}
+		It("should leave a pod that is terminating within the grace period", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{terminatingPod("terminating", time.Minute)}})
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			result, err := strategy.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeFalse())
+		})
+		It("should force delete a pod still terminating after the grace period", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{
+				terminatingPod("terminating", time.Minute),
+				terminatingPod("stuck", 10*time.Minute),
+			}})
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx interface{}, obj runtime.Object, opts ...client.DeleteOption) error {
+					Expect(obj.(*corev1.Pod).Name).To(Equal("stuck"))
+					deleteOpts := &client.DeleteOptions{}
+					deleteOpts.ApplyOptions(opts)
+					Expect(*deleteOpts.GracePeriodSeconds).To(Equal(int64(0)))
+					return nil
+				})
+			result, err := strategy.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(result.PDBForced).To(BeFalse())
+		})
+		It("should report when a stuck PDB pod was force deleted", func() {
+			strategy.forcesPDB = true
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{terminatingPod("stuck", 10*time.Minute)}})
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any())
+			result, err := strategy.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.PDBForced).To(BeTrue())
+		})
+	})
+
 	Context("Node Drain Strategies failures", func() {
 		Context("When there are no strategies", func() {
 			BeforeEach(func() {
@@ -443,6 +707,17 @@ var _ = Describe("OSD Drain Strategy", func() {
 				filteredPods := pod.FilterPods(podList, isTerminating)
 				Expect(len(filteredPods.Items)).To(Equal(0))
 			})
+			It("should only return pods terminating past the grace period", func() {
+				podList = &corev1.PodList{
+					Items: []corev1.Pod{
+						{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
+						{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}},
+						{},
+					},
+				}
+				filteredPods := pod.FilterPods(podList, isTerminatingLongerThan(5*time.Minute))
+				Expect(len(filteredPods.Items)).To(Equal(1))
+			})
 		})
 
 	})
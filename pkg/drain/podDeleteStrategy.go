@@ -2,6 +2,8 @@ package drain
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -10,12 +12,14 @@ import (
 )
 
 type podDeletionStrategy struct {
-	client  client.Client
-	filters []pod.PodPredicate
+	client             client.Client
+	filters            []pod.PodPredicate
+	forcesPDB          bool
+	deleteEmptyDirData bool
 }
 
 func (pds *podDeletionStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
-	podsToDelete, err := pds.getPodList(node)
+	podsToDelete, skippedPods, err := pds.getPodList(node)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +33,13 @@ func (pds *podDeletionStrategy) Execute(node *corev1.Node) (*DrainStrategyResult
 	return &DrainStrategyResult{
 		Message:     res.Message,
 		HasExecuted: res.NumMarkedForDeletion > 0,
+		PDBForced:   pds.forcesPDB && res.NumMarkedForDeletion > 0,
+		SkippedPods: skippedPods,
 	}, nil
 }
 
 func (pds *podDeletionStrategy) IsValid(node *corev1.Node) (bool, error) {
-	targetPods, err := pds.getPodList(node)
+	targetPods, _, err := pds.getPodList(node)
 	if err != nil {
 		return false, err
 	}
@@ -41,13 +47,29 @@ func (pds *podDeletionStrategy) IsValid(node *corev1.Node) (bool, error) {
 	return len(targetPods.Items) > 0, nil
 }
 
-func (pds *podDeletionStrategy) getPodList(node *corev1.Node) (*corev1.PodList, error) {
+// getPodList returns the pods to delete, along with a description of any pods skipped because
+// deleting them would lose their emptyDir data
+func (pds *podDeletionStrategy) getPodList(node *corev1.Node) (*corev1.PodList, []string, error) {
 	allPods := &corev1.PodList{}
 	err := pds.client.List(context.TODO(), allPods)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	filters := append([]pod.PodPredicate{isOnNode(node)}, pds.filters...)
-	return pod.FilterPods(allPods, filters...), nil
+	targetPods := pod.FilterPods(allPods, filters...)
+	if pds.deleteEmptyDirData {
+		return targetPods, nil, nil
+	}
+
+	podsToDelete := &corev1.PodList{}
+	var skippedPods []string
+	for _, p := range targetPods.Items {
+		if volumes := emptyDirVolumes(p); len(volumes) > 0 {
+			skippedPods = append(skippedPods, fmt.Sprintf("%s/%s (deleting it would lose the data in its emptyDir volumes %s, which needs nodeDrain deleteEmptyDirData)", p.Namespace, p.Name, strings.Join(volumes, ",")))
+			continue
+		}
+		podsToDelete.Items = append(podsToDelete.Items, p)
+	}
+	return podsToDelete, skippedPods, nil
 }
@@ -1,8 +1,11 @@
 package drain
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
 )
@@ -42,6 +45,42 @@ func isNotDaemonSet(pod corev1.Pod) bool {
 	return !isDaemonSet(pod)
 }
 
+// isMirrorPod matches static pods, whose API objects are mirrors managed by the kubelet
+func isMirrorPod(p corev1.Pod) bool {
+	_, ok := p.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func isNotMirrorPod(p corev1.Pod) bool {
+	return !isMirrorPod(p)
+}
+
+func emptyDirVolumes(p corev1.Pod) []string {
+	var volumes []string
+	for _, v := range p.Spec.Volumes {
+		if v.EmptyDir != nil {
+			volumes = append(volumes, v.Name)
+		}
+	}
+	return volumes
+}
+
+func isNotExcluded(namespaces []string, selectors []labels.Selector) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		for _, ns := range namespaces {
+			if p.Namespace == ns {
+				return false
+			}
+		}
+		for _, s := range selectors {
+			if s.Matches(labels.Set(p.Labels)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func containsMatchLabel(p corev1.Pod, pdbList *policyv1beta1.PodDisruptionBudgetList) bool {
 	isPdbPod := false
 	for _, pdb := range pdbList.Items {
@@ -69,3 +108,9 @@ func isTerminating(p corev1.Pod) bool {
 	return p.DeletionTimestamp != nil
 }
 
+// isTerminatingLongerThan matches pods still present the given duration after their deletion time
+func isTerminatingLongerThan(d time.Duration) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		return isTerminating(p) && p.DeletionTimestamp.Add(d).Before(time.Now())
+	}
+}
@@ -0,0 +1,52 @@
+package drain
+
+import (
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+const (
+	// DefaultStrategyName escalates through pod deletion, finalizer removal and,
+	// once the PDB force drain timeout has passed, the removal of PDB protected pods
+	DefaultStrategyName = "default"
+	// NoneStrategyName leaves the drain entirely to the machine-config-operator
+	NoneStrategyName = "none"
+)
+
+// StrategyFunc builds the timed drain strategies that make up a named drain strategy
+type StrategyFunc func(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) ([]TimedDrainStrategy, error)
+
+var strategies = map[string]StrategyFunc{
+	DefaultStrategyName: defaultStrategy,
+	NoneStrategyName:    noneStrategy,
+}
+
+// RegisterStrategy makes a drain strategy available for selection by name in the node drain config
+func RegisterStrategy(name string, f StrategyFunc) {
+	strategies[name] = f
+}
+
+func getStrategy(name string) (StrategyFunc, error) {
+	f, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown drain strategy %q (valid strategies: %v)", name, strategyNames())
+	}
+	return f, nil
+}
+
+func strategyNames() []string {
+	names := []string{}
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func noneStrategy(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) ([]TimedDrainStrategy, error) {
+	return []TimedDrainStrategy{}, nil
+}
@@ -10,8 +10,9 @@ import (
 )
 
 type removeFinalizersStrategy struct {
-	client  client.Client
-	filters []pod.PodPredicate
+	client    client.Client
+	filters   []pod.PodPredicate
+	forcesPDB bool
 }
 
 func (rfs *removeFinalizersStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
@@ -28,6 +29,7 @@ func (rfs *removeFinalizersStrategy) Execute(node *corev1.Node) (*DrainStrategyR
 	return &DrainStrategyResult{
 		Message:     res.Message,
 		HasExecuted: res.NumRemoved > 0,
+		PDBForced:   rfs.forcesPDB && res.NumRemoved > 0,
 	}, nil
 }
 
@@ -53,6 +53,20 @@ func newTimedStrategy(name string, description string, waitDuration time.Duratio
 }
 
 func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error) {
+	strategy, err := getStrategy(cfg.GetStrategyName())
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := strategy(c, uc, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNodeDrainStrategy(c, cfg, ts)
+}
+
+func defaultStrategy(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) ([]TimedDrainStrategy, error) {
 	pdbList := &policyv1beta1.PodDisruptionBudgetList{}
 	err := c.List(context.TODO(), pdbList)
 	if err != nil {
@@ -65,38 +79,62 @@ func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, uc *upgra
 		return nil, err
 	}
 
-	defaultOsdPodPredicates := []pod.PodPredicate{isNotDaemonSet}
+	excludedPodSelectors, err := cfg.GetExcludedPodSelectors()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultOsdPodPredicates := []pod.PodPredicate{isNotDaemonSet, isNotMirrorPod, isNotExcluded(cfg.ExcludedNamespaces, excludedPodSelectors)}
 	isNotPdbPod := isNotPdbPod(pdbList)
 	isPdbPod := isPdbPod(pdbList)
 	defaultDuration := cfg.GetTimeOutDuration()
 	pdbDuration := uc.GetPDBDrainTimeoutDuration()
 	ts := []TimedDrainStrategy{
 		newTimedStrategy(defaultPodDeleteName, "Default pod deletion", defaultDuration, &podDeletionStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod),
+			client:             c,
+			filters:            append(defaultOsdPodPredicates, isNotPdbPod),
+			deleteEmptyDirData: cfg.DeleteEmptyDirData,
 		}),
 		newTimedStrategy(defaultPodFinalizerRemovalName, "Default pod finalizer removal", defaultDuration, &removeFinalizersStrategy{
 			client:  c,
 			filters: append(defaultOsdPodPredicates, isNotPdbPod),
 		}),
 		newTimedStrategy(stuckTerminatingPodName, "Pod stuck terminating removal", defaultDuration, &stuckTerminatingStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod),
-		}),
-		newTimedStrategy(pdbPodDeleteName, "PDB pod deletion", pdbDuration, &podDeletionStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod),
-		}),
-		newTimedStrategy(pdbPodFinalizerRemovalName, "PDB Pod finalizer removal", pdbDuration, &removeFinalizersStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod),
+			client:      c,
+			filters:     append(defaultOsdPodPredicates, isNotPdbPod),
+			gracePeriod: cfg.GetStuckPodGracePeriodDuration(),
 		}),
 	}
+	if !cfg.DisablePDBForceDrain {
+		ts = append(ts,
+			newTimedStrategy(pdbPodDeleteName, "PDB pod deletion", pdbDuration, &podDeletionStrategy{
+				client:             c,
+				filters:            append(defaultOsdPodPredicates, isPdbPod),
+				forcesPDB:          true,
+				deleteEmptyDirData: cfg.DeleteEmptyDirData,
+			}),
+			newTimedStrategy(pdbPodFinalizerRemovalName, "PDB Pod finalizer removal", pdbDuration, &removeFinalizersStrategy{
+				client:    c,
+				filters:   append(defaultOsdPodPredicates, isPdbPod),
+				forcesPDB: true,
+			}),
+			newTimedStrategy(pdbStuckTerminatingPodName, "PDB pod stuck terminating removal", pdbDuration, &stuckTerminatingStrategy{
+				client:      c,
+				filters:     append(defaultOsdPodPredicates, isPdbPod),
+				gracePeriod: cfg.GetStuckPodGracePeriodDuration(),
+				forcesPDB:   true,
+			}),
+		)
+	}
 
-	return NewNodeDrainStrategy(c, cfg, ts)
+	return ts, nil
 }
 
 type DrainStrategyResult struct {
 	Message     string
 	HasExecuted bool
+	// PDBForced is set when pods protected by a PodDisruptionBudget were forcibly removed
+	PDBForced bool
+	// SkippedPods describes the pods the strategy left in place, and why
+	SkippedPods []string
 }
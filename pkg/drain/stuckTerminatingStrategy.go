@@ -2,6 +2,8 @@ package drain
 
 import (
 	"context"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -9,8 +11,10 @@ import (
 )
 
 type stuckTerminatingStrategy struct {
-	client  client.Client
-	filters []pod.PodPredicate
+	client      client.Client
+	filters     []pod.PodPredicate
+	gracePeriod time.Duration
+	forcesPDB   bool
 }
 
 func (sts *stuckTerminatingStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
@@ -28,6 +32,7 @@ func (sts *stuckTerminatingStrategy) Execute(node *corev1.Node) (*DrainStrategyR
 	return &DrainStrategyResult{
 		Message:     res.Message,
 		HasExecuted: res.NumMarkedForDeletion > 0,
+		PDBForced:   sts.forcesPDB && res.NumMarkedForDeletion > 0,
 	}, nil
 }
 
@@ -47,6 +52,6 @@ func (sts *stuckTerminatingStrategy) getPodList(node *corev1.Node) (*corev1.PodL
 		return nil, err
 	}
 
-	filters := append([]pod.PodPredicate{isOnNode(node), hasNoFinalizers, isTerminating}, sts.filters...)
+	filters := append([]pod.PodPredicate{isOnNode(node), hasNoFinalizers, isTerminatingLongerThan(sts.gracePeriod)}, sts.filters...)
 	return pod.FilterPods(allPods, filters...), nil
 }
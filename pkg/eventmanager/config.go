@@ -0,0 +1,101 @@
+package eventmanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"text/template"
+	"time"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+)
+
+// defaultTemplates are the messages sent for each state when no template is configured.
+// Delayed and failed upgrades are described according to the step that held them up.
+var defaultTemplates = map[notifier.NotifyState]string{
+	notifier.StateStarted:        "Cluster is currently being upgraded to version {{.Version}}",
+	notifier.StateWorkersStarted: "Cluster control plane has been upgraded to version {{.Version}} and its worker nodes are now being upgraded",
+	notifier.StateDelayed:        "{{.Description}}",
+	notifier.StateCompleted:      "Cluster has been successfully upgraded to version {{.Version}}",
+	notifier.StateFailed:         "{{.Description}}",
+}
+
+type EventManagerConfig struct {
+	Notifications NotificationsConfig `yaml:"notifications"`
+}
+
+type NotificationsConfig struct {
+	// Templates are Go templates of the message sent for a state, keyed by the state
+	Templates map[string]string `yaml:"templates"`
+	// Attempts is how many times a notification is sent before it is given up on
+	Attempts int `yaml:"attempts" default:"3"`
+	// RetryDelay is the number of seconds to wait before the first retry. The delay doubles
+	// with each further retry.
+	RetryDelay int `yaml:"retryDelay" default:"1"`
+	// NotifyOnReentry notifies a state each time the upgrade enters it, such as when it is
+	// delayed again after recovering. By default a state is only notified once per version.
+	NotifyOnReentry bool `yaml:"notifyOnReentry"`
+}
+
+// NotificationData is the upgrade metadata available to notification templates
+type NotificationData struct {
+	Name      string
+	Version   string
+	Channel   string
+	UpgradeAt string
+	State     string
+	// Reason is the step that delayed or failed the upgrade, if there is one
+	Reason string
+	// Description is the operator's standard description of the state
+	Description string
+}
+
+func (cfg *EventManagerConfig) IsValid() error {
+	if cfg.Notifications.Attempts < 0 {
+		return fmt.Errorf("config notification attempts is invalid")
+	}
+	if cfg.Notifications.RetryDelay < 0 {
+		return fmt.Errorf("config notification retry delay is invalid")
+	}
+	_, err := cfg.GetTemplates()
+	return err
+}
+
+func (cfg *EventManagerConfig) GetAttempts() int {
+	if cfg.Notifications.Attempts == 0 {
+		return 3
+	}
+	return cfg.Notifications.Attempts
+}
+
+func (cfg *EventManagerConfig) GetRetryDelay() time.Duration {
+	if cfg.Notifications.RetryDelay == 0 {
+		return time.Second
+	}
+	return time.Duration(cfg.Notifications.RetryDelay) * time.Second
+}
+
+// GetTemplates parses the configured templates over the defaults
+func (cfg *EventManagerConfig) GetTemplates() (map[notifier.NotifyState]*template.Template, error) {
+	for state := range cfg.Notifications.Templates {
+		if _, ok := defaultTemplates[notifier.NotifyState(state)]; !ok {
+			return nil, fmt.Errorf("config notification template for unknown state %s", state)
+		}
+	}
+
+	templates := make(map[notifier.NotifyState]*template.Template, len(defaultTemplates))
+	for state, text := range defaultTemplates {
+		if configured, ok := cfg.Notifications.Templates[string(state)]; ok {
+			text = configured
+		}
+		t, err := template.New(string(state)).Parse(text)
+		if err == nil {
+			// Catch references to unknown fields now rather than when the upgrade is underway
+			err = t.Execute(ioutil.Discard, NotificationData{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config notification template for state %s is invalid: %v", state, err)
+		}
+		templates[state] = t
+	}
+	return templates, nil
+}
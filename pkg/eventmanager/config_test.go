@@ -0,0 +1,32 @@
+package eventmanager
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event manager config", func() {
+	It("accepts the default templates", func() {
+		Expect((&EventManagerConfig{}).IsValid()).To(Succeed())
+	})
+
+	It("rejects templates which do not parse", func() {
+		cfg := &EventManagerConfig{Notifications: NotificationsConfig{Templates: map[string]string{"started": "{{.Version"}}}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates which refer to unknown upgrade metadata", func() {
+		cfg := &EventManagerConfig{Notifications: NotificationsConfig{Templates: map[string]string{"completed": "{{.ClusterName}}"}}}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects negative retry settings", func() {
+		Expect((&EventManagerConfig{Notifications: NotificationsConfig{Attempts: -1}}).IsValid()).NotTo(Succeed())
+		Expect((&EventManagerConfig{Notifications: NotificationsConfig{RetryDelay: -1}}).IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates for unknown states", func() {
+		cfg := &EventManagerConfig{Notifications: NotificationsConfig{Templates: map[string]string{"workers-begun": "{{.Version}}"}}}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+})
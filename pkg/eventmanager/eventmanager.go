@@ -1,15 +1,22 @@
 package eventmanager
 
 import (
+	"bytes"
 	"fmt"
+	"text/template"
+	"time"
+
 	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
-	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+	"github.com/openshift/managed-upgrade-operator/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("event-manager")
+
 const (
 	UPGRADE_PRECHECK_FAILED_DESC       = "Cluster upgrade to version %s was cancelled as the cluster did not pass its pre-upgrade verification checks. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
 	UPGRADE_PREHEALTHCHECK_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the Pre-Health Check step. Health alerts are firing in the cluster which could impact the upgrade's operation, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
@@ -24,7 +31,8 @@ const (
 
 //go:generate mockgen -destination=mocks/eventmanager.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/eventmanager EventManager
 type EventManager interface {
-	Notify(state notifier.NotifyState) error
+	// Notify notifies of the state of the upgrade the UpgradeConfig describes
+	Notify(uc *v1alpha1.UpgradeConfig, state notifier.NotifyState) error
 }
 
 //go:generate mockgen -destination=mocks/eventmanager_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/eventmanager EventManagerBuilder
@@ -42,80 +50,165 @@ type eventManager struct {
 	client               client.Client
 	notifier             notifier.Notifier
 	metrics              metrics.Metrics
-	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
 	configManagerBuilder configmanager.ConfigManagerBuilder
+	templates            map[notifier.NotifyState]*template.Template
+	attempts             int
+	retryDelay           time.Duration
+	notifyOnReentry      bool
 }
 
 func (emb *eventManagerBuilder) NewManager(client client.Client) (EventManager, error) {
 	cmBuilder := configmanager.NewBuilder()
-	ucb := upgradeconfigmanager.NewBuilder()
-	ucm, err := upgradeconfigmanager.NewBuilder().NewManager(client)
+	metricsClient, err := metrics.NewBuilder().NewClient(client)
 	if err != nil {
 		return nil, err
 	}
-	metricsClient, err := metrics.NewBuilder().NewClient(client)
+	notifier, err := notifier.NewBuilder().New(client, cmBuilder)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := readEventManagerConfig(client, cmBuilder)
 	if err != nil {
 		return nil, err
 	}
-	notifier, err := notifier.NewBuilder().New(client, cmBuilder, ucb)
+	templates, err := cfg.GetTemplates()
 	if err != nil {
 		return nil, err
 	}
 
 	return &eventManager{
 		client:               client,
-		upgradeConfigManager: ucm,
 		metrics:              metricsClient,
 		notifier:             notifier,
 		configManagerBuilder: cmBuilder,
+		templates:            templates,
+		attempts:             cfg.GetAttempts(),
+		retryDelay:           cfg.GetRetryDelay(),
+		notifyOnReentry:      cfg.Notifications.NotifyOnReentry,
 	}, nil
 }
 
-func (s *eventManager) Notify(state notifier.NotifyState) error {
-	// Get the current UpgradeConfig
-	uc, err := s.upgradeConfigManager.Get()
+// Read the event manager configuration
+func readEventManagerConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*EventManagerConfig, error) {
+	ns, err := util.GetOperatorNamespace()
 	if err != nil {
-		if err == upgradeconfigmanager.ErrUpgradeConfigNotFound {
-			return nil
-		} else {
-			return fmt.Errorf("unable to find UpgradeConfig: %v", err)
-		}
+		return nil, err
 	}
+	cfm := cfb.New(client, ns)
+	cfg := &EventManagerConfig{}
+	err = cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.IsValid()
+}
 
-	// Check if a notification for it has been sent successfully - if so, nothing to do
-	isNotified, err := s.metrics.IsMetricNotificationEventSentSet(uc.Name, string(state), uc.Spec.Desired.Version)
+func (s *eventManager) Notify(uc *v1alpha1.UpgradeConfig, state notifier.NotifyState) error {
+	// Repeated observations of the last notified state are not a transition, so there is nothing to do
+	notified, err := s.getNotifiedStates()
 	if err != nil {
-		return fmt.Errorf("can't check cluster metric NotificationSent: %v", err)
+		return fmt.Errorf("can't determine the last notified state: %v", err)
 	}
-	if isNotified {
+	if notified.Data[uc.Name] == notifiedStateKey(uc, state) {
 		return nil
 	}
 
+	// Check if a notification for it has been sent successfully - if so, nothing to do
+	// unless re-entering a state is to be notified
+	if !s.notifyOnReentry {
+		isNotified, err := s.metrics.IsMetricNotificationEventSentSet(uc.Name, string(state), uc.Spec.Desired.Version)
+		if err != nil {
+			return fmt.Errorf("can't check cluster metric NotificationSent: %v", err)
+		}
+		if isNotified {
+			return nil
+		}
+	}
+
 	// Customize the state description
-	var description string
-	switch state {
-	case notifier.StateStarted:
-		description = fmt.Sprintf("Cluster is currently being upgraded to version %s", uc.Spec.Desired.Version)
-	case notifier.StateDelayed:
-		description = createDelayedDescription(uc)
-	case notifier.StateCompleted:
-		description = fmt.Sprintf("Cluster has been successfully upgraded to version %s", uc.Spec.Desired.Version)
-	case notifier.StateFailed:
-		description = createFailureDescription(uc)
-	default:
-		return fmt.Errorf("state %v not yet implemented", state)
+	description, err := s.renderDescription(state, uc)
+	if err != nil {
+		return err
 	}
 
 	// Send the notification
-	err = s.notifier.NotifyState(state, description)
+	err = s.send(uc, state, description)
 	if err != nil {
 		return fmt.Errorf("can't send notification '%s': %v", state, err)
 	}
 	s.metrics.UpdateMetricNotificationEventSent(uc.Name, string(state), uc.Spec.Desired.Version)
 
+	err = s.setNotifiedState(notified, uc, state)
+	if err != nil {
+		return fmt.Errorf("notification '%s' was sent but could not be recorded: %v", state, err)
+	}
+
 	return nil
 }
 
+// Sends the notification, retrying with backoff to ride out transient failures
+func (s *eventManager) send(uc *v1alpha1.UpgradeConfig, state notifier.NotifyState, description string) error {
+	delay := s.retryDelay
+	for attempt := 1; ; attempt++ {
+		s.metrics.UpdateMetricNotificationSendAttempt(string(state))
+		err := s.notifier.NotifyState(uc, state, description)
+		if err == nil {
+			return nil
+		}
+		if attempt >= s.attempts {
+			s.metrics.UpdateMetricNotificationSendFailed(string(state))
+			log.Error(err, "Failed to send notification, giving up", "state", state, "attempts", attempt)
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// Renders the state's notification template for the UpgradeConfig
+func (s *eventManager) renderDescription(state notifier.NotifyState, uc *v1alpha1.UpgradeConfig) (string, error) {
+	t, ok := s.templates[state]
+	if !ok {
+		return "", fmt.Errorf("state %v not yet implemented", state)
+	}
+
+	data := NotificationData{
+		Name:      uc.Name,
+		Version:   uc.Spec.Desired.Version,
+		Channel:   uc.Spec.Desired.Channel,
+		UpgradeAt: uc.Spec.UpgradeAt,
+		State:     string(state),
+	}
+	switch state {
+	case notifier.StateDelayed:
+		data.Description = createDelayedDescription(uc)
+		data.Reason = incompleteConditionType(uc)
+	case notifier.StateFailed:
+		data.Description = createFailureDescription(uc)
+		data.Reason = incompleteConditionType(uc)
+	}
+
+	description := &bytes.Buffer{}
+	if err := t.Execute(description, data); err != nil {
+		return "", fmt.Errorf("can't render notification '%s': %v", state, err)
+	}
+	return description.String(), nil
+}
+
+// Returns the type of the UpgradeConfig's first incomplete condition, if it has one
+func incompleteConditionType(uc *v1alpha1.UpgradeConfig) string {
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history == nil {
+		return ""
+	}
+	for _, condition := range history.Conditions {
+		if condition.IsFalse() {
+			return string(condition.Type)
+		}
+	}
+	return ""
+}
+
 // Generates a Failure notification description based on the UpgradeConfig's last failed state
 func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 	// Default failure message
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"os"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/golang/mock/gomock"
@@ -12,7 +16,6 @@ import (
 	metricsMock "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	notifierMock "github.com/openshift/managed-upgrade-operator/pkg/notifier/mocks"
-	ucMgrMock "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 
@@ -31,31 +34,43 @@ var _ = Describe("OCM Notifier", func() {
 	var (
 		mockCtrl                 *gomock.Controller
 		mockKubeClient           *mocks.MockClient
-		mockUpgradeConfigManager *ucMgrMock.MockUpgradeConfigManager
 		mockConfigManagerBuilder *configMock.MockConfigManagerBuilder
 		mockNotifier             *notifierMock.MockNotifier
 		mockMetricsClient        *metricsMock.MockMetrics
 		manager                  *eventManager
 		upgradeConfigName        types.NamespacedName
+		cfg                      *EventManagerConfig
+		attempts                 int
+		notifyOnReentry          bool
+		notifiedStatesName       types.NamespacedName
+		notFound                 error
 	)
 
 	BeforeEach(func() {
 		_ = os.Setenv("OPERATOR_NAMESPACE", TEST_OPERATOR_NAMESPACE)
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
-		mockUpgradeConfigManager = ucMgrMock.NewMockUpgradeConfigManager(mockCtrl)
 		mockConfigManagerBuilder = configMock.NewMockConfigManagerBuilder(mockCtrl)
 		mockNotifier = notifierMock.NewMockNotifier(mockCtrl)
 		mockMetricsClient = metricsMock.NewMockMetrics(mockCtrl)
+		cfg = &EventManagerConfig{}
+		attempts = 1
+		notifyOnReentry = false
+		notifiedStatesName = types.NamespacedName{Namespace: TEST_OPERATOR_NAMESPACE, Name: NOTIFIED_STATE_CONFIGMAP}
+		notFound = errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, NOTIFIED_STATE_CONFIGMAP)
 	})
 
 	JustBeforeEach(func() {
+		templates, err := cfg.GetTemplates()
+		Expect(err).NotTo(HaveOccurred())
 		manager = &eventManager{
 			client:               mockKubeClient,
-			upgradeConfigManager: mockUpgradeConfigManager,
 			notifier:             mockNotifier,
 			metrics:              mockMetricsClient,
 			configManagerBuilder: mockConfigManagerBuilder,
+			templates:            templates,
+			attempts:             attempts,
+			notifyOnReentry:      notifyOnReentry,
 		}
 	})
 
@@ -80,34 +95,97 @@ var _ = Describe("OCM Notifier", func() {
 		Context("when a notification has already been sent", func() {
 			It("does no action", func() {
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(true, nil),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
 		Context("when a notification has not been sent", func() {
 			It("sends a correct notification", func() {
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
+		Context("when the UpgradeConfig is not the default one", func() {
+			It("notifies for the UpgradeConfig it is given", func() {
+				staged := uc.DeepCopy()
+				staged.Name = "staged-upgrade-config"
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(staged.Name, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(staged, testState, gomock.Any()),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(staged.Name, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
+				)
+				err := manager.Notify(staged, testState)
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when a notification fails to send and then succeeds", func() {
+			var fakeError = fmt.Errorf("fake error")
+			BeforeEach(func() {
+				attempts = 3
+			})
+			It("retries the notification", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
+				)
+				err := manager.Notify(&uc, testState)
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when a notification keeps failing to send", func() {
+			var fakeError = fmt.Errorf("fake error")
+			BeforeEach(func() {
+				attempts = 3
+			})
+			It("gives up once its attempts are exhausted", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(string(testState)),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendFailed(string(testState)),
+				)
+				err := manager.Notify(&uc, testState)
+				Expect(err).NotTo(BeNil())
+			})
+		})
 		Context("when a notification can't be sent", func() {
 			var fakeError = fmt.Errorf("fake error")
 			It("returns an error", func() {
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendFailed(string(testState)),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).NotTo(BeNil())
 			})
 		})
@@ -140,12 +218,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_PREHEALTHCHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -162,12 +242,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_EXTDEPCHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -184,12 +266,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_SCALE_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -206,12 +290,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_PRECHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -244,12 +330,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_PREHEALTHCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -266,12 +354,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_EXTDEPCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -288,12 +378,14 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_SCALE_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, testState)
 				Expect(err).To(BeNil())
 			})
 		})
@@ -310,16 +402,154 @@ var _ = Describe("OCM Notifier", func() {
 				}
 				expectedDescription := fmt.Sprintf(UPGRADE_DEFAULT_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
 					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
-					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
+				)
+				err := manager.Notify(&uc, testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+	})
+
+	Context("When the state has been notified before", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var notified corev1.ConfigMap
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+			notified = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: TEST_OPERATOR_NAMESPACE, Name: NOTIFIED_STATE_CONFIGMAP, ResourceVersion: "1"},
+				Data:       map[string]string{TEST_UPGRADECONFIG_CR: TEST_UPGRADE_VERSION + "/" + string(notifier.StateDelayed)},
+			}
+		})
+
+		It("does not notify a repeated observation of the same state", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).SetArg(2, notified),
+				mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+				mockNotifier.EXPECT().NotifyState(gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+			)
+			err := manager.Notify(&uc, notifier.StateDelayed)
+			Expect(err).To(BeNil())
+		})
+
+		It("notifies a transition to another state and records it", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).SetArg(2, notified),
+				mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(notifier.StateCompleted), TEST_UPGRADE_VERSION).Return(false, nil),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+				mockNotifier.EXPECT().NotifyState(&uc, notifier.StateCompleted, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.StateCompleted), TEST_UPGRADE_VERSION),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Do(
+					func(ctx interface{}, cm *corev1.ConfigMap) {
+						Expect(cm.Data[TEST_UPGRADECONFIG_CR]).To(Equal(TEST_UPGRADE_VERSION + "/" + string(notifier.StateCompleted)))
+					}),
+			)
+			err := manager.Notify(&uc, notifier.StateCompleted)
+			Expect(err).To(BeNil())
+		})
+
+		Context("when re-entering a state is to be notified", func() {
+			BeforeEach(func() {
+				notifyOnReentry = true
+				notified.Data[TEST_UPGRADECONFIG_CR] = TEST_UPGRADE_VERSION + "/" + string(notifier.StateStarted)
+			})
+
+			It("notifies a state that was notified before another", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).SetArg(2, notified),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(gomock.Any(), gomock.Any(), gomock.Any()).Times(0),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, notifier.StateDelayed, gomock.Any()),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.StateDelayed), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
 				)
-				err := manager.Notify(testState)
+				err := manager.Notify(&uc, notifier.StateDelayed)
 				Expect(err).To(BeNil())
 			})
 		})
+	})
+
+	Context("When rendering notification templates", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+			uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+				{
+					Type:   upgradev1alpha1.UpgradeScaleUpExtraNodes,
+					Status: "False",
+				},
+			}
+		})
+
+		Context("when no templates are configured", func() {
+			It("renders the default message of each state", func() {
+				expected := map[notifier.NotifyState]string{
+					notifier.StateStarted:        "Cluster is currently being upgraded to version " + TEST_UPGRADE_VERSION,
+					notifier.StateWorkersStarted: "Cluster control plane has been upgraded to version " + TEST_UPGRADE_VERSION + " and its worker nodes are now being upgraded",
+					notifier.StateCompleted:      "Cluster has been successfully upgraded to version " + TEST_UPGRADE_VERSION,
+					notifier.StateDelayed:        fmt.Sprintf(UPGRADE_SCALE_DELAY_DESC, TEST_UPGRADE_VERSION),
+					notifier.StateFailed:         fmt.Sprintf(UPGRADE_SCALE_FAILED_DESC, TEST_UPGRADE_VERSION),
+				}
+				for state, description := range expected {
+					Expect(manager.renderDescription(state, &uc)).To(Equal(description))
+				}
+			})
+		})
+
+		Context("when templates are configured", func() {
+			BeforeEach(func() {
+				cfg.Notifications.Templates = map[string]string{
+					"started":         "{{.Name}} is upgrading to {{.Version}} as scheduled for {{.UpgradeAt}}",
+					"workers_started": "{{.Name}} workers are upgrading to {{.Version}}",
+					"completed":       "{{.Name}} is now running {{.Version}}",
+					"failed":          "{{.Name}} failed to upgrade to {{.Version}} at step {{.Reason}}",
+				}
+			})
+
+			It("renders the configured message of each state", func() {
+				expected := map[notifier.NotifyState]string{
+					notifier.StateStarted:        TEST_UPGRADECONFIG_CR + " is upgrading to " + TEST_UPGRADE_VERSION + " as scheduled for " + TEST_UPGRADE_TIME,
+					notifier.StateWorkersStarted: TEST_UPGRADECONFIG_CR + " workers are upgrading to " + TEST_UPGRADE_VERSION,
+					notifier.StateCompleted:      TEST_UPGRADECONFIG_CR + " is now running " + TEST_UPGRADE_VERSION,
+					notifier.StateFailed:         TEST_UPGRADECONFIG_CR + " failed to upgrade to " + TEST_UPGRADE_VERSION + " at step " + string(upgradev1alpha1.UpgradeScaleUpExtraNodes),
+				}
+				for state, description := range expected {
+					Expect(manager.renderDescription(state, &uc)).To(Equal(description))
+				}
+			})
 
+			It("sends the rendered message", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), notifiedStatesName, gomock.Any()).Return(notFound),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(notifier.StateCompleted), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationSendAttempt(gomock.Any()),
+					mockNotifier.EXPECT().NotifyState(&uc, notifier.StateCompleted, TEST_UPGRADECONFIG_CR+" is now running "+TEST_UPGRADE_VERSION),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.StateCompleted), TEST_UPGRADE_VERSION),
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()),
+				)
+				err := manager.Notify(&uc, notifier.StateCompleted)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 })
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	reflect "reflect"
 )
@@ -34,15 +35,15 @@ func (m *MockEventManager) EXPECT() *MockEventManagerMockRecorder {
 }
 
 // Notify mocks base method
-func (m *MockEventManager) Notify(arg0 notifier.NotifyState) error {
+func (m *MockEventManager) Notify(arg0 *v1alpha1.UpgradeConfig, arg1 notifier.NotifyState) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Notify", arg0)
+	ret := m.ctrl.Call(m, "Notify", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Notify indicates an expected call of Notify
-func (mr *MockEventManagerMockRecorder) Notify(arg0 interface{}) *gomock.Call {
+func (mr *MockEventManagerMockRecorder) Notify(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockEventManager)(nil).Notify), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockEventManager)(nil).Notify), arg0, arg1)
 }
@@ -0,0 +1,61 @@
+package eventmanager
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// NOTIFIED_STATE_CONFIGMAP records the last state notified for each UpgradeConfig, so that
+// repeated observations of a state are not notified again after the operator restarts.
+// It is kept apart from the UpgradeConfig so that recording it does not conflict with the
+// controller's updates to the UpgradeConfig's status.
+const NOTIFIED_STATE_CONFIGMAP = "managed-upgrade-operator-notified-states"
+
+// notifiedStateKey identifies a state of an upgrade to a particular version
+func notifiedStateKey(uc *v1alpha1.UpgradeConfig, state notifier.NotifyState) string {
+	return fmt.Sprintf("%s/%s", uc.Spec.Desired.Version, state)
+}
+
+// getNotifiedStates returns the record of notified states, or a new, unsaved record if
+// there is none
+func (s *eventManager) getNotifiedStates() (*corev1.ConfigMap, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	err = s.client.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: NOTIFIED_STATE_CONFIGMAP}, cm)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      NOTIFIED_STATE_CONFIGMAP,
+			},
+		}
+	}
+	return cm, nil
+}
+
+// setNotifiedState records the state as the last notified for the UpgradeConfig
+func (s *eventManager) setNotifiedState(cm *corev1.ConfigMap, uc *v1alpha1.UpgradeConfig, state notifier.NotifyState) error {
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[uc.Name] = notifiedStateKey(uc, state)
+	if cm.ResourceVersion == "" {
+		return s.client.Create(context.TODO(), cm)
+	}
+	return s.client.Update(context.TODO(), cm)
+}
@@ -0,0 +1,57 @@
+package eventmanager
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// Reasons of the Kubernetes events recorded against an UpgradeConfig for the milestones of its upgrade.
+// They are stable, so that the events can be filtered on them.
+const (
+	EVENT_REASON_UPGRADE_STARTED   = "UpgradeStarted"
+	EVENT_REASON_STEP_STARTED      = "UpgradeStepStarted"
+	EVENT_REASON_STEP_FAILED       = "UpgradeStepFailed"
+	EVENT_REASON_UPGRADE_COMPLETED = "UpgradeCompleted"
+	EVENT_REASON_UPGRADE_FAILED    = "UpgradeFailed"
+	EVENT_REASON_UPGRADE_CANCELLED = "UpgradeCancelled"
+)
+
+//go:generate mockgen -destination=mocks/upgrade_event_recorder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/eventmanager UpgradeEventRecorder
+type UpgradeEventRecorder interface {
+	Event(uc *v1alpha1.UpgradeConfig, eventtype, reason, message string)
+}
+
+// NewUpgradeEventRecorder records the milestones of upgrades through the supplied recorder.
+// It should be kept for the life of the controller, as it remembers the events it has recorded.
+func NewUpgradeEventRecorder(recorder record.EventRecorder) UpgradeEventRecorder {
+	return &upgradeEventRecorder{
+		recorder: recorder,
+		recorded: map[string]string{},
+	}
+}
+
+type upgradeEventRecorder struct {
+	recorder record.EventRecorder
+	mutex    sync.Mutex
+	// recorded holds the last event recorded against each UpgradeConfig
+	recorded map[string]string
+}
+
+// Event records an event against the UpgradeConfig, unless it is identical to the last event
+// recorded against it, so that an upgrade stuck retrying a step does not flood the event stream
+func (r *upgradeEventRecorder) Event(uc *v1alpha1.UpgradeConfig, eventtype, reason, message string) {
+	key := fmt.Sprintf("%s/%s", uc.Namespace, uc.Name)
+	event := fmt.Sprintf("%s %s %s", eventtype, reason, message)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.recorded[key] == event {
+		return
+	}
+	r.recorded[key] = event
+	r.recorder.Event(uc, eventtype, reason, message)
+}
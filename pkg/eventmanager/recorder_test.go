@@ -0,0 +1,52 @@
+package eventmanager
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade event recorder", func() {
+	var (
+		fakeRecorder *record.FakeRecorder
+		recorder     UpgradeEventRecorder
+		uc           *v1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(10)
+		recorder = NewUpgradeEventRecorder(fakeRecorder)
+		uc = &v1alpha1.UpgradeConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-uc", Namespace: "test-namespace"}}
+	})
+
+	It("records an event against the UpgradeConfig", func() {
+		recorder.Event(uc, corev1.EventTypeNormal, EVENT_REASON_STEP_STARTED, "step started")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Normal UpgradeStepStarted step started")))
+	})
+
+	It("does not record an event identical to the last one again", func() {
+		recorder.Event(uc, corev1.EventTypeWarning, EVENT_REASON_STEP_FAILED, "step failed")
+		recorder.Event(uc, corev1.EventTypeWarning, EVENT_REASON_STEP_FAILED, "step failed")
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+	})
+
+	It("records an event again once a different event has been recorded", func() {
+		recorder.Event(uc, corev1.EventTypeWarning, EVENT_REASON_STEP_FAILED, "step failed")
+		recorder.Event(uc, corev1.EventTypeNormal, EVENT_REASON_STEP_STARTED, "step started")
+		recorder.Event(uc, corev1.EventTypeWarning, EVENT_REASON_STEP_FAILED, "step failed")
+		Expect(fakeRecorder.Events).To(HaveLen(3))
+	})
+
+	It("records identical events against different UpgradeConfigs", func() {
+		other := uc.DeepCopy()
+		other.Name = "other-uc"
+		recorder.Event(uc, corev1.EventTypeNormal, EVENT_REASON_UPGRADE_STARTED, "upgrade started")
+		recorder.Event(other, corev1.EventTypeNormal, EVENT_REASON_UPGRADE_STARTED, "upgrade started")
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+	})
+})
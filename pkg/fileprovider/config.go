@@ -0,0 +1,52 @@
+package fileprovider
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CONFIGMAP reads the UpgradeConfig spec from a ConfigMap in the operator's namespace
+	CONFIGMAP = "CONFIGMAP"
+	// FILE reads the UpgradeConfig spec from a file, such as one mounted into the operator's pod
+	FILE = "FILE"
+
+	defaultConfigMapKey = "upgradeconfig.yaml"
+)
+
+type FileProviderConfig struct {
+	ConfigManager ConfigManager `yaml:"configManager"`
+}
+
+type ConfigManager struct {
+	Source string `yaml:"source"`
+	// ConfigMapName is the ConfigMap holding the spec, when the source is CONFIGMAP
+	ConfigMapName string `yaml:"configMapName"`
+	// ConfigMapKey is the key of the spec in the ConfigMap
+	ConfigMapKey string `yaml:"configMapKey" default:"upgradeconfig.yaml"`
+	// SpecPath is the path of the file holding the spec, when the source is FILE
+	SpecPath string `yaml:"specPath"`
+}
+
+func (cfg *FileProviderConfig) IsValid() error {
+	switch strings.ToUpper(cfg.ConfigManager.Source) {
+	case CONFIGMAP:
+		if cfg.ConfigManager.ConfigMapName == "" {
+			return fmt.Errorf("config manager configMapName must be set for the %s source", CONFIGMAP)
+		}
+	case FILE:
+		if cfg.ConfigManager.SpecPath == "" {
+			return fmt.Errorf("config manager specPath must be set for the %s source", FILE)
+		}
+	default:
+		return fmt.Errorf("config manager source %s is not read from a file", cfg.ConfigManager.Source)
+	}
+	return nil
+}
+
+func (cfg *FileProviderConfig) GetConfigMapKey() string {
+	if cfg.ConfigManager.ConfigMapKey == "" {
+		return defaultConfigMapKey
+	}
+	return cfg.ConfigManager.ConfigMapKey
+}
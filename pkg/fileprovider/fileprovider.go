@@ -0,0 +1,101 @@
+package fileprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+var log = logf.Log.WithName("upgradeconfig-fileprovider")
+
+// Errors
+var (
+	ErrInvalidSpec = fmt.Errorf("upgrade config spec is invalid")
+)
+
+// NewConfigMapProvider returns a provider of the spec held under the key of the named ConfigMap
+// in the operator's namespace
+func NewConfigMapProvider(c client.Client, name string, key string) (*fileProvider, error) {
+	return &fileProvider{
+		source: fmt.Sprintf("ConfigMap %s", name),
+		read: func() ([]byte, error) {
+			ns, err := util.GetOperatorNamespace()
+			if err != nil {
+				return nil, err
+			}
+			cm := &corev1.ConfigMap{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: name}, cm)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return nil, nil
+				}
+				return nil, err
+			}
+			return []byte(cm.Data[key]), nil
+		},
+	}, nil
+}
+
+// NewFileProvider returns a provider of the spec held in the file at path
+func NewFileProvider(path string) (*fileProvider, error) {
+	return &fileProvider{
+		source: fmt.Sprintf("file %s", path),
+		read: func() ([]byte, error) {
+			data, err := ioutil.ReadFile(path)
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return data, err
+		},
+	}, nil
+}
+
+// A provider of an UpgradeConfig spec written by the cluster's administrator, for clusters
+// which are not managed by OCM
+type fileProvider struct {
+	// Describes where the spec is read from
+	source string
+	// Reads the spec, returning no data if it has been removed
+	read func() ([]byte, error)
+}
+
+// Get returns the spec, or no specs if none has been written
+func (f *fileProvider) Get() ([]upgradev1alpha1.UpgradeConfigSpec, error) {
+	log.Info(fmt.Sprintf("Reading the upgrade config spec from %s", f.source))
+	data, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return parseSpecs(data)
+}
+
+// parseSpecs parses a YAML or JSON UpgradeConfig spec
+func parseSpecs(data []byte) ([]upgradev1alpha1.UpgradeConfigSpec, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return []upgradev1alpha1.UpgradeConfigSpec{}, nil
+	}
+	spec := upgradev1alpha1.UpgradeConfigSpec{}
+	err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), len(data)).Decode(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrInvalidSpec, err)
+	}
+	if spec.Desired.Version == "" || spec.UpgradeAt == "" {
+		return nil, fmt.Errorf("%v: desired version and upgradeAt must be set", ErrInvalidSpec)
+	}
+	if spec.Type == "" {
+		spec.Type = upgradev1alpha1.OSD
+	}
+	return []upgradev1alpha1.UpgradeConfigSpec{spec}, nil
+}
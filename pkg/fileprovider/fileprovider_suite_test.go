@@ -0,0 +1,13 @@
+package fileprovider
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFileProvider(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FileProvider Suite")
+}
@@ -0,0 +1,132 @@
+package fileprovider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	TEST_OPERATOR_NAMESPACE = "test-namespace"
+	TEST_CONFIGMAP_NAME     = "upgrade-spec"
+	TEST_SPEC               = `
+desired:
+  version: 4.5.16
+  channel: stable-4.5
+upgradeAt: "2020-11-01T12:00:00Z"
+PDBForceDrainTimeout: 60
+type: OSD
+`
+)
+
+var _ = Describe("FileProvider", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		expected       upgradev1alpha1.UpgradeConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		_ = os.Setenv("OPERATOR_NAMESPACE", TEST_OPERATOR_NAMESPACE)
+		expected = upgradev1alpha1.UpgradeConfigSpec{
+			Desired:              upgradev1alpha1.Update{Version: "4.5.16", Channel: "stable-4.5"},
+			UpgradeAt:            "2020-11-01T12:00:00Z",
+			PDBForceDrainTimeout: 60,
+			Type:                 upgradev1alpha1.OSD,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the spec is read from a ConfigMap", func() {
+		It("returns the spec held in the ConfigMap", func() {
+			cm := corev1.ConfigMap{Data: map[string]string{defaultConfigMapKey: TEST_SPEC}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: TEST_OPERATOR_NAMESPACE, Name: TEST_CONFIGMAP_NAME}, gomock.Any()).SetArg(2, cm)
+			provider, _ := NewConfigMapProvider(mockKubeClient, TEST_CONFIGMAP_NAME, defaultConfigMapKey)
+			specs, err := provider.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(Equal([]upgradev1alpha1.UpgradeConfigSpec{expected}))
+		})
+
+		It("returns no specs if the ConfigMap does not exist", func() {
+			notFound := errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, TEST_CONFIGMAP_NAME)
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			provider, _ := NewConfigMapProvider(mockKubeClient, TEST_CONFIGMAP_NAME, defaultConfigMapKey)
+			specs, err := provider.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(BeEmpty())
+		})
+
+		It("returns an error if the spec is invalid", func() {
+			cm := corev1.ConfigMap{Data: map[string]string{defaultConfigMapKey: "desired:\n  channel: stable-4.5\n"}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, cm)
+			provider, _ := NewConfigMapProvider(mockKubeClient, TEST_CONFIGMAP_NAME, defaultConfigMapKey)
+			_, err := provider.Get()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When the spec is read from a file", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "fileprovider")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(dir)
+		})
+
+		It("returns the spec held in the file", func() {
+			path := filepath.Join(dir, "upgradeconfig.yaml")
+			Expect(ioutil.WriteFile(path, []byte(TEST_SPEC), 0600)).To(Succeed())
+			provider, _ := NewFileProvider(path)
+			specs, err := provider.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(Equal([]upgradev1alpha1.UpgradeConfigSpec{expected}))
+		})
+
+		It("defaults the upgrade type", func() {
+			path := filepath.Join(dir, "upgradeconfig.json")
+			Expect(ioutil.WriteFile(path, []byte(`{"desired": {"version": "4.5.16", "channel": "stable-4.5"}, "upgradeAt": "2020-11-01T12:00:00Z", "PDBForceDrainTimeout": 60}`), 0600)).To(Succeed())
+			provider, _ := NewFileProvider(path)
+			specs, err := provider.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(Equal([]upgradev1alpha1.UpgradeConfigSpec{expected}))
+		})
+
+		It("returns no specs if the file does not exist", func() {
+			provider, _ := NewFileProvider(filepath.Join(dir, "missing.yaml"))
+			specs, err := provider.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(BeEmpty())
+		})
+	})
+
+	Context("When validating the config", func() {
+		It("requires the location of the spec for the source", func() {
+			Expect((&FileProviderConfig{ConfigManager: ConfigManager{Source: CONFIGMAP}}).IsValid()).NotTo(Succeed())
+			Expect((&FileProviderConfig{ConfigManager: ConfigManager{Source: FILE}}).IsValid()).NotTo(Succeed())
+			Expect((&FileProviderConfig{ConfigManager: ConfigManager{Source: "configmap", ConfigMapName: TEST_CONFIGMAP_NAME}}).IsValid()).To(Succeed())
+			Expect((&FileProviderConfig{ConfigManager: ConfigManager{Source: FILE, SpecPath: "/etc/upgradeconfig.yaml"}}).IsValid()).To(Succeed())
+		})
+	})
+})
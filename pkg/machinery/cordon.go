@@ -0,0 +1,116 @@
+package machinery
+
+import (
+	"context"
+
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type CordonResult struct {
+	// Batch names the nodes cordoned by the upgrade which are yet to be upgraded
+	Batch []string
+}
+
+// CordonNodes cordons nodes of the nodeType pool which are yet to be upgraded, ahead of the
+// machine-config daemon draining them, so that at most batchSize nodes are cordoned by the upgrade
+// at once. Nodes are released from the batch once upgraded, as the machine-config daemon uncordons
+// them itself. Nodes which are already unschedulable are left to whoever cordoned them.
+func (m *machinery) CordonNodes(c client.Client, nodeType string, batchSize int) (*CordonResult, error) {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := &corev1.NodeList{}
+	err = c.List(context.TODO(), nodes, client.MatchingLabels{nodeRoleLabelPrefix + nodeType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	target := configPool.Spec.Configuration.Name
+	result := &CordonResult{Batch: []string{}}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !isCordonedByUpgrade(node) {
+			continue
+		}
+		if !nodeUpgraded(node, target) {
+			result.Batch = append(result.Batch, node.Name)
+			continue
+		}
+		annotations := node.GetAnnotations()
+		delete(annotations, CordonedByUpgradeAnnotation)
+		node.SetAnnotations(annotations)
+		err = c.Update(context.TODO(), node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range nodes.Items {
+		if len(result.Batch) >= batchSize {
+			break
+		}
+		node := &nodes.Items[i]
+		if isCordonedByUpgrade(node) || node.Spec.Unschedulable || nodeUpgraded(node, target) {
+			continue
+		}
+		annotations := node.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[CordonedByUpgradeAnnotation] = "true"
+		node.SetAnnotations(annotations)
+		node.Spec.Unschedulable = true
+		err = c.Update(context.TODO(), node)
+		if err != nil {
+			return nil, err
+		}
+		result.Batch = append(result.Batch, node.Name)
+	}
+	return result, nil
+}
+
+// UncordonNodes uncordons the nodes of the nodeType pool cordoned by CordonNodes. A node the
+// machine-config daemon is part way through updating is left for the daemon to uncordon.
+func (m *machinery) UncordonNodes(c client.Client, nodeType string) error {
+	nodes := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodes, client.MatchingLabels{nodeRoleLabelPrefix + nodeType: ""})
+	if err != nil {
+		return err
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !isCordonedByUpgrade(node) {
+			continue
+		}
+		annotations := node.GetAnnotations()
+		if annotations[currentConfigAnnotation] == annotations[desiredConfigAnnotation] {
+			node.Spec.Unschedulable = false
+		}
+		delete(annotations, CordonedByUpgradeAnnotation)
+		node.SetAnnotations(annotations)
+		err = c.Update(context.TODO(), node)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsCordonedAheadOfDrain reports whether the node was cordoned by CordonNodes ahead of its drain
+// and the machine-config daemon has yet to start updating it, so it is not being drained yet
+func IsCordonedAheadOfDrain(node *corev1.Node) bool {
+	annotations := node.GetAnnotations()
+	return isCordonedByUpgrade(node) && annotations[currentConfigAnnotation] == annotations[desiredConfigAnnotation]
+}
+
+func isCordonedByUpgrade(node *corev1.Node) bool {
+	_, ok := node.GetAnnotations()[CordonedByUpgradeAnnotation]
+	return ok
+}
@@ -2,20 +2,30 @@ package machinery
 
 import (
 	"context"
+	"time"
 
 	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type UpgradingResult struct {
-	IsUpgrading  bool
-	UpdatedCount int32
-	MachineCount int32
+	IsUpgrading bool
+	// IsUpdated is true once the pool reports every machine updated to its target configuration
+	IsUpdated bool
+	// IsDegraded is true while the pool reports it is failing to render or apply its
+	// configuration, as described by DegradedReason, since DegradedSince
+	IsDegraded     bool
+	DegradedReason string
+	DegradedSince  time.Time
+	UpdatedCount   int32
+	MachineCount   int32
 }
 
-// IsUpgrading determines if machines are currently upgrading by comparing
-// MachineCount and UpdatedMachineCount
+// IsUpgrading determines if machines are currently upgrading from the conditions and machine
+// counts of the nodeType pool, and whether they have all updated to the pool's target configuration
 func (m *machinery) IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error) {
 	configPool := &machineconfigapi.MachineConfigPool{}
 	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
@@ -23,9 +33,182 @@ func (m *machinery) IsUpgrading(c client.Client, nodeType string) (*UpgradingRes
 		return nil, err
 	}
 
-	return &UpgradingResult{
-		IsUpgrading:  configPool.Status.MachineCount != configPool.Status.UpdatedMachineCount,
+	result := &UpgradingResult{
+		IsUpgrading:  m.IsPoolUpdating(configPool),
+		IsUpdated:    m.IsPoolUpdated(configPool, configPool.Spec.Configuration.Name),
 		UpdatedCount: configPool.Status.UpdatedMachineCount,
 		MachineCount: configPool.Status.MachineCount,
-	}, nil
+	}
+	if degraded := poolCondition(configPool, machineconfigapi.MachineConfigPoolDegraded); degraded != nil && degraded.Status == corev1.ConditionTrue {
+		result.IsDegraded = true
+		result.DegradedReason = degradedReason(configPool, degraded)
+		result.DegradedSince = degraded.LastTransitionTime.Time
+	}
+	return result, nil
+}
+
+// IsPoolUpdating reports whether the pool is rolling out a configuration, either by its Updating
+// condition or by machines yet to be updated
+func (m *machinery) IsPoolUpdating(pool *machineconfigapi.MachineConfigPool) bool {
+	return isPoolConditionTrue(pool, machineconfigapi.MachineConfigPoolUpdating) ||
+		pool.Status.UpdatedMachineCount != pool.Status.MachineCount
+}
+
+// IsPoolUpdated reports whether every machine of the pool is updated to the targetConfig. The
+// pool's status must have observed its latest spec and report the Updated condition, so a pool
+// which has yet to start rolling out the targetConfig, or is degraded, is not updated.
+func (m *machinery) IsPoolUpdated(pool *machineconfigapi.MachineConfigPool, targetConfig string) bool {
+	if pool.Status.ObservedGeneration < pool.Generation {
+		return false
+	}
+	if pool.Status.Configuration.Name != targetConfig {
+		return false
+	}
+	if isPoolConditionTrue(pool, machineconfigapi.MachineConfigPoolDegraded) || m.IsPoolUpdating(pool) {
+		return false
+	}
+	return isPoolConditionTrue(pool, machineconfigapi.MachineConfigPoolUpdated)
+}
+
+func poolCondition(pool *machineconfigapi.MachineConfigPool, conditionType machineconfigapi.MachineConfigPoolConditionType) *machineconfigapi.MachineConfigPoolCondition {
+	for i := range pool.Status.Conditions {
+		if pool.Status.Conditions[i].Type == conditionType {
+			return &pool.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func isPoolConditionTrue(pool *machineconfigapi.MachineConfigPool, conditionType machineconfigapi.MachineConfigPoolConditionType) bool {
+	condition := poolCondition(pool, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// degradedReason describes why the pool is degraded, preferring the more specific render or
+// node update error to the pool's Degraded condition
+func degradedReason(pool *machineconfigapi.MachineConfigPool, degraded *machineconfigapi.MachineConfigPoolCondition) string {
+	for _, conditionType := range []machineconfigapi.MachineConfigPoolConditionType{
+		machineconfigapi.MachineConfigPoolRenderDegraded,
+		machineconfigapi.MachineConfigPoolNodeDegraded,
+	} {
+		if condition := poolCondition(pool, conditionType); condition != nil && condition.Status == corev1.ConditionTrue {
+			degraded = condition
+			break
+		}
+	}
+	if degraded.Message != "" {
+		return degraded.Message
+	}
+	return degraded.Reason
+}
+
+// SetMaxUnavailable sets the maxUnavailable of the nodeType pool, recording the value it
+// replaces on the pool the first time so that it can later be restored
+func (m *machinery) SetMaxUnavailable(c client.Client, nodeType string, maxUnavailable intstr.IntOrString) error {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return err
+	}
+
+	annotations := configPool.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	_, recorded := annotations[OriginalMaxUnavailableAnnotation]
+	if recorded && configPool.Spec.MaxUnavailable != nil && *configPool.Spec.MaxUnavailable == maxUnavailable {
+		return nil
+	}
+	if !recorded {
+		original := ""
+		if configPool.Spec.MaxUnavailable != nil {
+			original = configPool.Spec.MaxUnavailable.String()
+		}
+		annotations[OriginalMaxUnavailableAnnotation] = original
+		configPool.SetAnnotations(annotations)
+	}
+
+	configPool.Spec.MaxUnavailable = &maxUnavailable
+	return c.Update(context.TODO(), configPool)
+}
+
+// RestoreMaxUnavailable restores the maxUnavailable of the nodeType pool recorded by
+// SetMaxUnavailable. Pools without a recorded value are left unchanged.
+func (m *machinery) RestoreMaxUnavailable(c client.Client, nodeType string) error {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return err
+	}
+
+	annotations := configPool.GetAnnotations()
+	original, recorded := annotations[OriginalMaxUnavailableAnnotation]
+	if !recorded {
+		return nil
+	}
+
+	if original == "" {
+		configPool.Spec.MaxUnavailable = nil
+	} else {
+		restored := intstr.Parse(original)
+		configPool.Spec.MaxUnavailable = &restored
+	}
+	delete(annotations, OriginalMaxUnavailableAnnotation)
+	configPool.SetAnnotations(annotations)
+	return c.Update(context.TODO(), configPool)
+}
+
+type PauseResult struct {
+	// PausedByUpgrade is true when the pool is paused by the upgrade. A pool an admin paused
+	// is left untouched and reported as false.
+	PausedByUpgrade bool
+}
+
+// PausePool pauses the nodeType pool, marking it as paused by the upgrade so that ResumePool
+// only unpauses pools the upgrade paused
+func (m *machinery) PausePool(c client.Client, nodeType string) (*PauseResult, error) {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := configPool.GetAnnotations()
+	if _, ok := annotations[PausedByUpgradeAnnotation]; ok {
+		return &PauseResult{PausedByUpgrade: true}, nil
+	}
+	if configPool.Spec.Paused {
+		return &PauseResult{PausedByUpgrade: false}, nil
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[PausedByUpgradeAnnotation] = "true"
+	configPool.SetAnnotations(annotations)
+	configPool.Spec.Paused = true
+	err = c.Update(context.TODO(), configPool)
+	if err != nil {
+		return nil, err
+	}
+	return &PauseResult{PausedByUpgrade: true}, nil
+}
+
+// ResumePool unpauses the nodeType pool if it was paused by PausePool
+func (m *machinery) ResumePool(c client.Client, nodeType string) error {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return err
+	}
+
+	annotations := configPool.GetAnnotations()
+	if _, ok := annotations[PausedByUpgradeAnnotation]; !ok {
+		return nil
+	}
+
+	delete(annotations, PausedByUpgradeAnnotation)
+	configPool.SetAnnotations(annotations)
+	configPool.Spec.Paused = false
+	return c.Update(context.TODO(), configPool)
 }
@@ -2,18 +2,39 @@
 package machinery
 
 import (
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	MasterLabel = "node-role.kubernetes.io/master"
+	// OriginalMaxUnavailableAnnotation records a pool's maxUnavailable from before the upgrade
+	// changed it. An empty value records that it was unset.
+	OriginalMaxUnavailableAnnotation = "upgrade.managed.openshift.io/original-max-unavailable"
+	// PausedByUpgradeAnnotation marks a pool paused by the upgrade, as opposed to by an admin
+	PausedByUpgradeAnnotation = "upgrade.managed.openshift.io/paused"
+	// NodeMaintenanceAnnotation marks a node intentionally cordoned or taken down for maintenance
+	NodeMaintenanceAnnotation = "upgrade.managed.openshift.io/maintenance"
+	// CordonedByUpgradeAnnotation marks a node cordoned by the upgrade ahead of its drain, as
+	// opposed to by an admin
+	CordonedByUpgradeAnnotation = "upgrade.managed.openshift.io/cordoned"
 )
 
 //go:generate mockgen -destination=mocks/machinery.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/machinery Machinery
 type Machinery interface {
 	IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error)
+	IsPoolUpdating(pool *machineconfigapi.MachineConfigPool) bool
+	IsPoolUpdated(pool *machineconfigapi.MachineConfigPool, targetConfig string) bool
+	AreNodesUpgraded(c client.Client, nodeType string, concurrency int) (*NodesUpgradedResult, error)
+	SetMaxUnavailable(c client.Client, nodeType string, maxUnavailable intstr.IntOrString) error
+	RestoreMaxUnavailable(c client.Client, nodeType string) error
+	PausePool(c client.Client, nodeType string) (*PauseResult, error)
+	ResumePool(c client.Client, nodeType string) error
 	IsNodeCordoned(node *corev1.Node) *IsCordonedResult
+	CordonNodes(c client.Client, nodeType string, batchSize int) (*CordonResult, error)
+	UncordonNodes(c client.Client, nodeType string) error
 }
 
 type machinery struct{}
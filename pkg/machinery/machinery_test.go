@@ -2,6 +2,7 @@ package machinery
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -9,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -75,6 +77,125 @@ var _ = Describe("Machinery client and utils", func() {
 		})
 	})
 
+	Context("When assessing the update state of a pool", func() {
+		var (
+			target     = "rendered-worker-new"
+			configPool *machineconfigapi.MachineConfigPool
+		)
+		makePool := func(config string, updated int32, conditions ...machineconfigapi.MachineConfigPoolConditionType) *machineconfigapi.MachineConfigPool {
+			pool := &machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec: machineconfigapi.MachineConfigPoolSpec{
+					Configuration: machineconfigapi.MachineConfigPoolStatusConfiguration{
+						ObjectReference: corev1.ObjectReference{Name: target},
+					},
+				},
+				Status: machineconfigapi.MachineConfigPoolStatus{
+					ObservedGeneration: 2,
+					Configuration: machineconfigapi.MachineConfigPoolStatusConfiguration{
+						ObjectReference: corev1.ObjectReference{Name: config},
+					},
+					MachineCount:        3,
+					UpdatedMachineCount: updated,
+				},
+			}
+			for _, conditionType := range []machineconfigapi.MachineConfigPoolConditionType{
+				machineconfigapi.MachineConfigPoolUpdated,
+				machineconfigapi.MachineConfigPoolUpdating,
+				machineconfigapi.MachineConfigPoolDegraded,
+			} {
+				status := corev1.ConditionFalse
+				for _, c := range conditions {
+					if c == conditionType {
+						status = corev1.ConditionTrue
+					}
+				}
+				pool.Status.Conditions = append(pool.Status.Conditions, machineconfigapi.MachineConfigPoolCondition{Type: conditionType, Status: status})
+			}
+			return pool
+		}
+
+		Context("When the pool is updating", func() {
+			BeforeEach(func() {
+				configPool = makePool("rendered-worker-old", 1, machineconfigapi.MachineConfigPoolUpdating)
+			})
+			It("Reports the pool as updating and not updated", func() {
+				Expect(machineryClient.IsPoolUpdating(configPool)).To(BeTrue())
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeFalse())
+			})
+			It("Reports the pool as updating while the last machine finishes", func() {
+				configPool = makePool(target, 3, machineconfigapi.MachineConfigPoolUpdating)
+				Expect(machineryClient.IsPoolUpdating(configPool)).To(BeTrue())
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeFalse())
+			})
+		})
+
+		Context("When the pool is updated", func() {
+			BeforeEach(func() {
+				configPool = makePool(target, 3, machineconfigapi.MachineConfigPoolUpdated)
+			})
+			It("Reports the pool as updated to the target configuration", func() {
+				Expect(machineryClient.IsPoolUpdating(configPool)).To(BeFalse())
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeTrue())
+			})
+			It("Does not report the pool as updated to another configuration", func() {
+				Expect(machineryClient.IsPoolUpdated(configPool, "rendered-worker-newer")).To(BeFalse())
+			})
+			It("Does not report the pool as updated until its status observes the latest spec", func() {
+				configPool.Generation = 3
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeFalse())
+			})
+			It("Reports the pool as upgraded", func() {
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "worker"}, gomock.Any()).SetArg(2, *configPool)
+				result, err := machineryClient.IsUpgrading(mockKubeClient, "worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsUpgrading).To(BeFalse())
+				Expect(result.IsUpdated).To(BeTrue())
+			})
+		})
+
+		Context("When the pool is degraded", func() {
+			BeforeEach(func() {
+				configPool = makePool("rendered-worker-old", 2, machineconfigapi.MachineConfigPoolUpdating, machineconfigapi.MachineConfigPoolDegraded)
+			})
+			It("Does not report the pool as updated", func() {
+				Expect(machineryClient.IsPoolUpdating(configPool)).To(BeTrue())
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeFalse())
+			})
+			It("Does not report the pool as updated even once its machine counts match", func() {
+				configPool = makePool(target, 3, machineconfigapi.MachineConfigPoolDegraded)
+				Expect(machineryClient.IsPoolUpdated(configPool, target)).To(BeFalse())
+			})
+			It("Reports the render or update error degrading the pool", func() {
+				degradedAt := metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Second))
+				for i := range configPool.Status.Conditions {
+					if configPool.Status.Conditions[i].Type == machineconfigapi.MachineConfigPoolDegraded {
+						configPool.Status.Conditions[i].LastTransitionTime = degradedAt
+						configPool.Status.Conditions[i].Message = "Failed to render configuration for pool worker"
+					}
+				}
+				configPool.Status.Conditions = append(configPool.Status.Conditions, machineconfigapi.MachineConfigPoolCondition{
+					Type:    machineconfigapi.MachineConfigPoolRenderDegraded,
+					Status:  corev1.ConditionTrue,
+					Message: "Failed to render configuration for pool worker: machineconfig 99-worker-bad is invalid",
+				})
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "worker"}, gomock.Any()).SetArg(2, *configPool)
+				result, err := machineryClient.IsUpgrading(mockKubeClient, "worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsDegraded).To(BeTrue())
+				Expect(result.DegradedReason).To(ContainSubstring("machineconfig 99-worker-bad is invalid"))
+				Expect(result.DegradedSince).To(BeTemporally("==", degradedAt.Time))
+			})
+			It("Does not report a pool which has recovered as degraded", func() {
+				configPool = makePool(target, 3, machineconfigapi.MachineConfigPoolUpdated)
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "worker"}, gomock.Any()).SetArg(2, *configPool)
+				result, err := machineryClient.IsUpgrading(mockKubeClient, "worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsDegraded).To(BeFalse())
+			})
+		})
+	})
+
 	Context("When assessing if a node is cordoned", func() {
 		It("Reports if the node is draining", func() {
 			testNode := &corev1.Node{
@@ -111,4 +232,336 @@ var _ = Describe("Machinery client and utils", func() {
 			Expect(result.AddedAt).To(Equal(startTime))
 		})
 	})
+
+	Context("When changing a pool's maxUnavailable", func() {
+		var (
+			nodeType    = "worker"
+			original    = intstr.FromInt(1)
+			upgradeTime = intstr.FromString("20%")
+			updated     *machineconfigapi.MachineConfigPool
+		)
+		captureUpdate := func(_ interface{}, obj interface{}, _ ...interface{}) error {
+			updated = obj.(*machineconfigapi.MachineConfigPool)
+			return nil
+		}
+
+		It("Records the original value and sets the new one", func() {
+			configPool := machineconfigapi.MachineConfigPool{Spec: machineconfigapi.MachineConfigPoolSpec{MaxUnavailable: &original}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate),
+			)
+			err := machineryClient.SetMaxUnavailable(mockKubeClient, nodeType, upgradeTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.Spec.MaxUnavailable).To(Equal(upgradeTime))
+			Expect(updated.Annotations).To(HaveKeyWithValue(OriginalMaxUnavailableAnnotation, "1"))
+		})
+		It("Does not overwrite a recorded original value", func() {
+			configPool := machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{OriginalMaxUnavailableAnnotation: "1"}},
+				Spec:       machineconfigapi.MachineConfigPoolSpec{MaxUnavailable: &upgradeTime},
+			}
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool)
+			err := machineryClient.SetMaxUnavailable(mockKubeClient, nodeType, upgradeTime)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("Restores the original value", func() {
+			configPool := machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{OriginalMaxUnavailableAnnotation: "1"}},
+				Spec:       machineconfigapi.MachineConfigPoolSpec{MaxUnavailable: &upgradeTime},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate),
+			)
+			err := machineryClient.RestoreMaxUnavailable(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.Spec.MaxUnavailable).To(Equal(original))
+			Expect(updated.Annotations).NotTo(HaveKey(OriginalMaxUnavailableAnnotation))
+		})
+		It("Restores an originally unset value", func() {
+			configPool := machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{OriginalMaxUnavailableAnnotation: ""}},
+				Spec:       machineconfigapi.MachineConfigPoolSpec{MaxUnavailable: &upgradeTime},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate),
+			)
+			err := machineryClient.RestoreMaxUnavailable(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.MaxUnavailable).To(BeNil())
+		})
+		It("Leaves a pool the upgrade did not change", func() {
+			configPool := machineconfigapi.MachineConfigPool{Spec: machineconfigapi.MachineConfigPoolSpec{MaxUnavailable: &original}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool)
+			err := machineryClient.RestoreMaxUnavailable(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When pausing and resuming a pool", func() {
+		var (
+			nodeType = "worker"
+			updated  *machineconfigapi.MachineConfigPool
+		)
+		captureUpdate := func(_ interface{}, obj interface{}, _ ...interface{}) error {
+			updated = obj.(*machineconfigapi.MachineConfigPool)
+			return nil
+		}
+
+		It("Pauses a running pool and marks it as paused by the upgrade", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, machineconfigapi.MachineConfigPool{}),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate),
+			)
+			result, err := machineryClient.PausePool(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.PausedByUpgrade).To(BeTrue())
+			Expect(updated.Spec.Paused).To(BeTrue())
+			Expect(updated.Annotations).To(HaveKey(PausedByUpgradeAnnotation))
+		})
+		It("Resumes a pool it paused", func() {
+			configPool := machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PausedByUpgradeAnnotation: "true"}},
+				Spec:       machineconfigapi.MachineConfigPoolSpec{Paused: true},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate),
+			)
+			err := machineryClient.ResumePool(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.Paused).To(BeFalse())
+			Expect(updated.Annotations).NotTo(HaveKey(PausedByUpgradeAnnotation))
+		})
+		It("Does not touch a pool an admin paused", func() {
+			configPool := machineconfigapi.MachineConfigPool{Spec: machineconfigapi.MachineConfigPoolSpec{Paused: true}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool).Times(2)
+			result, err := machineryClient.PausePool(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.PausedByUpgrade).To(BeFalse())
+			err = machineryClient.ResumePool(mockKubeClient, nodeType)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When assessing whether all nodes are upgraded", func() {
+		var (
+			nodeType   = "worker"
+			target     = "rendered-worker-new"
+			configPool = machineconfigapi.MachineConfigPool{
+				Spec: machineconfigapi.MachineConfigPoolSpec{
+					Configuration: machineconfigapi.MachineConfigPoolStatusConfiguration{
+						ObjectReference: corev1.ObjectReference{Name: target},
+					},
+				},
+			}
+			makeNode = func(name string, config string, state string, ready corev1.ConditionStatus) corev1.Node {
+				return corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+						Annotations: map[string]string{
+							currentConfigAnnotation: config,
+							desiredConfigAnnotation: target,
+							stateAnnotation:         state,
+						},
+					},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+					},
+				}
+			}
+		)
+
+		AfterEach(func() {
+			isNodeUpgraded = nodeUpgraded
+		})
+
+		It("Reports the nodes that are not ready on the target configuration", func() {
+			nodes := corev1.NodeList{Items: []corev1.Node{
+				makeNode("upgraded", target, stateDone, corev1.ConditionTrue),
+				makeNode("old-config", "rendered-worker-old", stateDone, corev1.ConditionTrue),
+				makeNode("working", target, "Working", corev1.ConditionTrue),
+				makeNode("not-ready", target, stateDone, corev1.ConditionFalse),
+			}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes),
+			)
+			result, err := machineryClient.AreNodesUpgraded(mockKubeClient, nodeType, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.AllUpgraded()).To(BeFalse())
+			Expect(result.UpgradedCount).To(Equal(int32(1)))
+			Expect(result.NodeCount).To(Equal(int32(4)))
+			Expect(result.NotUpgraded).To(Equal([]string{"old-config", "working", "not-ready"}))
+		})
+
+		It("Evaluates many nodes with no more than the concurrency limit at once", func() {
+			nodes := corev1.NodeList{}
+			for i := 0; i < 100; i++ {
+				nodes.Items = append(nodes.Items, makeNode(fmt.Sprintf("worker-%d", i), target, stateDone, corev1.ConditionTrue))
+			}
+			var inFlight, maxInFlight int32
+			isNodeUpgraded = func(node *corev1.Node, target string) bool {
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return nodeUpgraded(node, target)
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes),
+			)
+			result, err := machineryClient.AreNodesUpgraded(mockKubeClient, nodeType, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.AllUpgraded()).To(BeTrue())
+			Expect(result.UpgradedCount).To(Equal(int32(100)))
+			Expect(maxInFlight).To(BeNumerically("<=", 5))
+			Expect(maxInFlight).To(BeNumerically(">", 1))
+		})
+
+		It("Reports an error listing the nodes", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			result, err := machineryClient.AreNodesUpgraded(mockKubeClient, nodeType, 5)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Context("When cordoning nodes in batches", func() {
+		var (
+			nodeType   = "worker"
+			target     = "rendered-worker-new"
+			configPool = machineconfigapi.MachineConfigPool{
+				Spec: machineconfigapi.MachineConfigPoolSpec{
+					Configuration: machineconfigapi.MachineConfigPoolStatusConfiguration{
+						ObjectReference: corev1.ObjectReference{Name: target},
+					},
+				},
+			}
+			updated map[string]*corev1.Node
+		)
+		makeNode := func(name string, config string, cordonedByUpgrade bool, unschedulable bool) corev1.Node {
+			node := corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						currentConfigAnnotation: config,
+						desiredConfigAnnotation: config,
+						stateAnnotation:         stateDone,
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			if cordonedByUpgrade {
+				node.Annotations[CordonedByUpgradeAnnotation] = "true"
+			}
+			return node
+		}
+		captureUpdate := func(_ interface{}, obj interface{}, _ ...interface{}) error {
+			node := obj.(*corev1.Node)
+			updated[node.Name] = node
+			return nil
+		}
+
+		BeforeEach(func() {
+			updated = map[string]*corev1.Node{}
+		})
+
+		It("Cordons nodes yet to be upgraded up to the batch size", func() {
+			nodes := corev1.NodeList{Items: []corev1.Node{
+				makeNode("upgraded", target, false, false),
+				makeNode("worker-1", "rendered-worker-old", false, false),
+				makeNode("worker-2", "rendered-worker-old", false, false),
+				makeNode("worker-3", "rendered-worker-old", false, false),
+			}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes),
+			)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate).Times(2)
+			result, err := machineryClient.CordonNodes(mockKubeClient, nodeType, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Batch).To(Equal([]string{"worker-1", "worker-2"}))
+			Expect(updated).To(HaveLen(2))
+			for _, node := range updated {
+				Expect(node.Spec.Unschedulable).To(BeTrue())
+				Expect(node.Annotations).To(HaveKey(CordonedByUpgradeAnnotation))
+			}
+		})
+
+		It("Moves on to the next batch as nodes are upgraded", func() {
+			nodes := corev1.NodeList{Items: []corev1.Node{
+				makeNode("worker-1", target, true, false),
+				makeNode("worker-2", "rendered-worker-old", true, true),
+				makeNode("worker-3", "rendered-worker-old", false, false),
+				makeNode("worker-4", "rendered-worker-old", false, false),
+			}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes),
+			)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate).Times(2)
+			result, err := machineryClient.CordonNodes(mockKubeClient, nodeType, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Batch).To(Equal([]string{"worker-2", "worker-3"}))
+			Expect(updated["worker-1"].Annotations).NotTo(HaveKey(CordonedByUpgradeAnnotation))
+			Expect(updated["worker-3"].Spec.Unschedulable).To(BeTrue())
+		})
+
+		It("Leaves nodes cordoned by an admin alone", func() {
+			nodes := corev1.NodeList{Items: []corev1.Node{
+				makeNode("admin-cordoned", "rendered-worker-old", false, true),
+			}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, configPool),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes),
+			)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			result, err := machineryClient.CordonNodes(mockKubeClient, nodeType, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Batch).To(BeEmpty())
+
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes)
+			Expect(machineryClient.UncordonNodes(mockKubeClient, nodeType)).To(Succeed())
+		})
+
+		It("Uncordons every node it cordoned", func() {
+			updating := makeNode("updating", "rendered-worker-old", true, true)
+			updating.Annotations[desiredConfigAnnotation] = target
+			nodes := corev1.NodeList{Items: []corev1.Node{
+				makeNode("worker-1", "rendered-worker-old", true, true),
+				updating,
+				makeNode("admin-cordoned", "rendered-worker-old", false, true),
+			}}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(captureUpdate).Times(2)
+			Expect(machineryClient.UncordonNodes(mockKubeClient, nodeType)).To(Succeed())
+			Expect(updated["worker-1"].Spec.Unschedulable).To(BeFalse())
+			Expect(updated["worker-1"].Annotations).NotTo(HaveKey(CordonedByUpgradeAnnotation))
+			// The machine-config daemon uncordons the node it is updating once it is done
+			Expect(updated["updating"].Spec.Unschedulable).To(BeTrue())
+			Expect(updated["updating"].Annotations).NotTo(HaveKey(CordonedByUpgradeAnnotation))
+		})
+
+		It("Reports an error uncordoning a node", func() {
+			nodes := corev1.NodeList{Items: []corev1.Node{makeNode("worker-1", "rendered-worker-old", true, true)}}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, nodes)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			Expect(machineryClient.UncordonNodes(mockKubeClient, nodeType)).NotTo(Succeed())
+		})
+	})
 })
@@ -6,8 +6,10 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	v1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	machinery "github.com/openshift/managed-upgrade-operator/pkg/machinery"
-	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/api/core/v1"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	reflect "reflect"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -35,8 +37,38 @@ func (m *MockMachinery) EXPECT() *MockMachineryMockRecorder {
 	return m.recorder
 }
 
+// AreNodesUpgraded mocks base method
+func (m *MockMachinery) AreNodesUpgraded(arg0 client.Client, arg1 string, arg2 int) (*machinery.NodesUpgradedResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreNodesUpgraded", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*machinery.NodesUpgradedResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AreNodesUpgraded indicates an expected call of AreNodesUpgraded
+func (mr *MockMachineryMockRecorder) AreNodesUpgraded(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreNodesUpgraded", reflect.TypeOf((*MockMachinery)(nil).AreNodesUpgraded), arg0, arg1, arg2)
+}
+
+// CordonNodes mocks base method
+func (m *MockMachinery) CordonNodes(arg0 client.Client, arg1 string, arg2 int) (*machinery.CordonResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CordonNodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*machinery.CordonResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CordonNodes indicates an expected call of CordonNodes
+func (mr *MockMachineryMockRecorder) CordonNodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CordonNodes", reflect.TypeOf((*MockMachinery)(nil).CordonNodes), arg0, arg1, arg2)
+}
+
 // IsNodeCordoned mocks base method
-func (m *MockMachinery) IsNodeCordoned(arg0 *v1.Node) *machinery.IsCordonedResult {
+func (m *MockMachinery) IsNodeCordoned(arg0 *v10.Node) *machinery.IsCordonedResult {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsNodeCordoned", arg0)
 	ret0, _ := ret[0].(*machinery.IsCordonedResult)
@@ -49,6 +81,34 @@ func (mr *MockMachineryMockRecorder) IsNodeCordoned(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNodeCordoned", reflect.TypeOf((*MockMachinery)(nil).IsNodeCordoned), arg0)
 }
 
+// IsPoolUpdated mocks base method
+func (m *MockMachinery) IsPoolUpdated(arg0 *v1.MachineConfigPool, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPoolUpdated", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPoolUpdated indicates an expected call of IsPoolUpdated
+func (mr *MockMachineryMockRecorder) IsPoolUpdated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPoolUpdated", reflect.TypeOf((*MockMachinery)(nil).IsPoolUpdated), arg0, arg1)
+}
+
+// IsPoolUpdating mocks base method
+func (m *MockMachinery) IsPoolUpdating(arg0 *v1.MachineConfigPool) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPoolUpdating", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPoolUpdating indicates an expected call of IsPoolUpdating
+func (mr *MockMachineryMockRecorder) IsPoolUpdating(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPoolUpdating", reflect.TypeOf((*MockMachinery)(nil).IsPoolUpdating), arg0)
+}
+
 // IsUpgrading mocks base method
 func (m *MockMachinery) IsUpgrading(arg0 client.Client, arg1 string) (*machinery.UpgradingResult, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +123,74 @@ func (mr *MockMachineryMockRecorder) IsUpgrading(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpgrading", reflect.TypeOf((*MockMachinery)(nil).IsUpgrading), arg0, arg1)
 }
+
+// PausePool mocks base method
+func (m *MockMachinery) PausePool(arg0 client.Client, arg1 string) (*machinery.PauseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PausePool", arg0, arg1)
+	ret0, _ := ret[0].(*machinery.PauseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PausePool indicates an expected call of PausePool
+func (mr *MockMachineryMockRecorder) PausePool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PausePool", reflect.TypeOf((*MockMachinery)(nil).PausePool), arg0, arg1)
+}
+
+// RestoreMaxUnavailable mocks base method
+func (m *MockMachinery) RestoreMaxUnavailable(arg0 client.Client, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMaxUnavailable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMaxUnavailable indicates an expected call of RestoreMaxUnavailable
+func (mr *MockMachineryMockRecorder) RestoreMaxUnavailable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMaxUnavailable", reflect.TypeOf((*MockMachinery)(nil).RestoreMaxUnavailable), arg0, arg1)
+}
+
+// ResumePool mocks base method
+func (m *MockMachinery) ResumePool(arg0 client.Client, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumePool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumePool indicates an expected call of ResumePool
+func (mr *MockMachineryMockRecorder) ResumePool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumePool", reflect.TypeOf((*MockMachinery)(nil).ResumePool), arg0, arg1)
+}
+
+// SetMaxUnavailable mocks base method
+func (m *MockMachinery) SetMaxUnavailable(arg0 client.Client, arg1 string, arg2 intstr.IntOrString) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxUnavailable", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxUnavailable indicates an expected call of SetMaxUnavailable
+func (mr *MockMachineryMockRecorder) SetMaxUnavailable(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxUnavailable", reflect.TypeOf((*MockMachinery)(nil).SetMaxUnavailable), arg0, arg1, arg2)
+}
+
+// UncordonNodes mocks base method
+func (m *MockMachinery) UncordonNodes(arg0 client.Client, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UncordonNodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UncordonNodes indicates an expected call of UncordonNodes
+func (mr *MockMachineryMockRecorder) UncordonNodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UncordonNodes", reflect.TypeOf((*MockMachinery)(nil).UncordonNodes), arg0, arg1)
+}
@@ -0,0 +1,99 @@
+package machinery
+
+import (
+	"context"
+	"sync"
+
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	currentConfigAnnotation = "machineconfiguration.openshift.io/currentConfig"
+	desiredConfigAnnotation = "machineconfiguration.openshift.io/desiredConfig"
+	stateAnnotation         = "machineconfiguration.openshift.io/state"
+	stateDone               = "Done"
+	nodeRoleLabelPrefix     = "node-role.kubernetes.io/"
+)
+
+type NodesUpgradedResult struct {
+	UpgradedCount int32
+	NodeCount     int32
+	// NotUpgraded names the nodes that are not Ready or not on the pool's target configuration
+	NotUpgraded []string
+}
+
+// AllUpgraded reports whether every node is Ready and on the pool's target configuration
+func (r *NodesUpgradedResult) AllUpgraded() bool {
+	return len(r.NotUpgraded) == 0
+}
+
+// isNodeUpgraded is replaced in tests to observe how many nodes are evaluated at once
+var isNodeUpgraded = nodeUpgraded
+
+// AreNodesUpgraded evaluates whether the nodes of the nodeType pool are Ready and running the
+// pool's target configuration, with at most concurrency nodes evaluated at once
+func (m *machinery) AreNodesUpgraded(c client.Client, nodeType string, concurrency int) (*NodesUpgradedResult, error) {
+	configPool := &machineconfigapi.MachineConfigPool{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := &corev1.NodeList{}
+	err = c.List(context.TODO(), nodes, client.MatchingLabels{nodeRoleLabelPrefix + nodeType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	target := configPool.Spec.Configuration.Name
+	upgraded := make([]bool, len(nodes.Items))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(nodes.Items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				upgraded[i] = isNodeUpgraded(&nodes.Items[i], target)
+			}
+		}()
+	}
+	for i := range nodes.Items {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	result := &NodesUpgradedResult{NodeCount: int32(len(nodes.Items)), NotUpgraded: []string{}}
+	for i, ok := range upgraded {
+		if ok {
+			result.UpgradedCount++
+		} else {
+			result.NotUpgraded = append(result.NotUpgraded, nodes.Items[i].Name)
+		}
+	}
+	return result, nil
+}
+
+// nodeUpgraded reports whether the machine-config daemon has finished applying the target
+// configuration to the node and the node is Ready
+func nodeUpgraded(node *corev1.Node, target string) bool {
+	annotations := node.GetAnnotations()
+	if annotations[currentConfigAnnotation] != target ||
+		annotations[desiredConfigAnnotation] != target ||
+		annotations[stateAnnotation] != stateDone {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -1,9 +1,13 @@
 package maintenance
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/go-openapi/runtime"
@@ -13,6 +17,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/managed-upgrade-operator/config"
 	"github.com/openshift/managed-upgrade-operator/pkg/alertmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,34 +31,198 @@ var (
 	alertManagerBasePath           = "/api/v2/"
 	controlPlaneSilenceCommentId   = "OSD control plane"
 	workerSilenceCommentId         = "OSD worker node"
+	criticalSilenceCommentId       = "critical alerts during OSD control plane"
+	alertsSilenceCommentId         = "configured alerts during OSD"
+	defaultCommentTemplate         = "Silence for {{.Description}} upgrade to version {{.Version}}"
 )
 
-type alertManagerMaintenanceBuilder struct{}
+// Option configures the maintenances built by a MaintenanceBuilder
+type Option func(*alertManagerMaintenanceBuilder)
+
+// WithCreator sets the creator recorded on maintenance silences, identifying them as this operator's
+func WithCreator(creator string) Option {
+	return func(ammb *alertManagerMaintenanceBuilder) {
+		ammb.creator = creator
+	}
+}
+
+// WithCommentTemplate sets the text/template used to render maintenance silence comments.
+// The template is given the maintenance .Description, the upgrade .Version and the .Cluster
+// and must include the description and version.
+func WithCommentTemplate(commentTemplate string) Option {
+	return func(ammb *alertManagerMaintenanceBuilder) {
+		ammb.commentTemplate = commentTemplate
+	}
+}
+
+// WithCluster sets the cluster identifier available to the comment template
+func WithCluster(cluster string) Option {
+	return func(ammb *alertManagerMaintenanceBuilder) {
+		ammb.cluster = cluster
+	}
+}
+
+// WithAlertManagerURL sets the base URL of the Alertmanager holding the maintenance silences,
+// in place of the in-cluster Alertmanager route. It should be checked with ParseAlertManagerURL.
+// The Alertmanager is sent no credentials.
+func WithAlertManagerURL(alertManagerURL string) Option {
+	return func(ammb *alertManagerMaintenanceBuilder) {
+		ammb.alertManagerURL = alertManagerURL
+	}
+}
+
+// WithWorkerAlerts narrows the worker maintenance silence to the named alerts, such as those of
+// nodes, in place of the warning and info alerts of platform namespaces
+func WithWorkerAlerts(alertNames []string) Option {
+	return func(ammb *alertManagerMaintenanceBuilder) {
+		ammb.workerAlerts = alertNames
+	}
+}
+
+type alertManagerMaintenanceBuilder struct {
+	creator         string
+	commentTemplate string
+	cluster         string
+	alertManagerURL string
+	workerAlerts    []string
+}
 
 func (ammb *alertManagerMaintenanceBuilder) NewClient(client client.Client) (Maintenance, error) {
-	transport, err := getTransport(client)
+	commentTemplate, err := ParseCommentTemplate(ammb.commentTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	transport.DefaultAuthentication, err = getAuthentication(client)
+	alertManagerURL, err := ParseAlertManagerURL(ammb.alertManagerURL)
 	if err != nil {
 		return nil, err
 	}
 
+	transport, err := getTransport(client, alertManagerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The in-cluster service account's token must not leave the cluster, so an overridden
+	// Alertmanager is sent no credentials
+	if alertManagerURL == nil {
+		transport.DefaultAuthentication, err = getAuthentication(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &alertManagerMaintenance{
-		client: &alertmanager.AlertManagerSilenceClient{
-			Transport: transport,
-		},
+		client:          alertmanager.NewAlertManagerSilenceClient(transport, alertmanager.WithCreator(ammb.creator)),
+		creator:         ammb.creator,
+		commentTemplate: commentTemplate,
+		cluster:         ammb.cluster,
+		workerAlerts:    ammb.workerAlerts,
 	}, nil
 }
 
 type alertManagerMaintenance struct {
 	//	client alertManagerSilenceClient
 	client alertmanager.AlertManagerSilencer
+	// creator identifies the silences owned by the operator. config.OperatorName is used when unset.
+	creator string
+	// commentTemplate renders silence comments. defaultCommentTemplate is used when unset.
+	commentTemplate *template.Template
+	cluster         string
+	// workerAlerts are the only alerts silenced by the worker maintenance when set
+	workerAlerts []string
+}
+
+var defaultCommentTmpl = template.Must(template.New("comment").Parse(defaultCommentTemplate))
+
+// commentData is made available to the comment template
+type commentData struct {
+	Description string
+	Version     string
+	Cluster     string
+}
+
+// ParseCommentTemplate parses a maintenance comment template, checking that the comments it
+// renders identify the maintenance and version so that they can be found again later.
+// An empty template selects the default.
+func ParseCommentTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = defaultCommentTemplate
+	}
+	t, err := template.New("comment").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance comment template: %v", err)
+	}
+
+	sample := commentData{Description: "maintenance-description", Version: "0.0.0-version", Cluster: "cluster"}
+	rendered, err := renderComment(t, sample)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance comment template: %v", err)
+	}
+	if !strings.Contains(rendered, sample.Description) || !strings.Contains(rendered, sample.Version) {
+		return nil, fmt.Errorf("maintenance comment template must include {{.Description}} and {{.Version}}")
+	}
+	return t, nil
 }
 
-func getTransport(c client.Client) (*httptransport.Runtime, error) {
+func renderComment(t *template.Template, data commentData) (string, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// comment renders the silence comment for a maintenance of the upgrade to version
+func (amm *alertManagerMaintenance) comment(description string, version string) (string, error) {
+	t := amm.commentTemplate
+	if t == nil {
+		t = defaultCommentTmpl
+	}
+	return renderComment(t, commentData{Description: description, Version: version, Cluster: amm.cluster})
+}
+
+// creatorName returns the creator recorded on, and used to find, the operator's silences
+func (amm *alertManagerMaintenance) creatorName() string {
+	if amm.creator == "" {
+		return config.OperatorName
+	}
+	return amm.creator
+}
+
+// ParseAlertManagerURL parses the base URL of an Alertmanager to use in place of the in-cluster
+// one, which must be an absolute http or https URL. An empty URL selects the in-cluster Alertmanager.
+func ParseAlertManagerURL(text string) (*url.URL, error) {
+	if text == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Alertmanager URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid Alertmanager URL: scheme must be http or https")
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid Alertmanager URL: host is missing")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid Alertmanager URL: must not have a query or fragment")
+	}
+	return u, nil
+}
+
+// getTransport returns the transport to the Alertmanager at alertManagerURL, or to the
+// in-cluster Alertmanager through its route when alertManagerURL is nil
+func getTransport(c client.Client, alertManagerURL *url.URL) (*httptransport.Runtime, error) {
+	if alertManagerURL != nil {
+		return httptransport.New(
+			alertManagerURL.Host,
+			strings.TrimSuffix(alertManagerURL.Path, "/")+alertManagerBasePath,
+			[]string{alertManagerURL.Scheme},
+		), nil
+	}
+
 	amRoute := &routev1.Route{}
 	err := c.Get(
 		context.TODO(),
@@ -95,15 +264,23 @@ func getAuthentication(c client.Client) (runtime.ClientAuthInfoWriter, error) {
 // Start a control plane maintenance in Alertmanager for version
 // Time is converted to UTC
 func (amm *alertManagerMaintenance) StartControlPlane(endsAt time.Time, version string, ignoredCriticalAlerts []string) error {
-	defaultComment := fmt.Sprintf("Silence for %s upgrade to version %s", controlPlaneSilenceCommentId, version)
-	defaultSilence, err := amm.client.Filter(equalsComment(defaultComment))
+	defaultComment, err := amm.comment(controlPlaneSilenceCommentId, version)
+	if err != nil {
+		return err
+	}
+	defaultSilence, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeOrPendingSilences, equalsComment(defaultComment), hasMatchers(createDefaultMatchers()))
 	if err != nil {
 		return err
 	}
 	defaultExists := len(*defaultSilence) > 0
 
-	criticalAlertComment := fmt.Sprintf("Silence for critical alerts during %s upgrade to version %s", controlPlaneSilenceCommentId, version)
-	criticalSilence, err := amm.client.Filter(equalsComment(criticalAlertComment))
+	criticalAlertComment, err := amm.comment(criticalSilenceCommentId, version)
+	if err != nil {
+		return err
+	}
+	icRegex := "(" + strings.Join(ignoredCriticalAlerts, "|") + ")"
+	criticalMatchers := alertmanager.Matchers(alertmanager.RegexMatcher("alertname", icRegex))
+	criticalSilence, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeOrPendingSilences, equalsComment(criticalAlertComment), hasMatchers(criticalMatchers))
 	if err != nil {
 		return err
 	}
@@ -116,7 +293,7 @@ func (amm *alertManagerMaintenance) StartControlPlane(endsAt time.Time, version
 	now := strfmt.DateTime(time.Now().UTC())
 	end := strfmt.DateTime(endsAt.UTC())
 	if !defaultExists {
-		err = amm.client.Create(createDefaultMatchers(), now, end, config.OperatorName, defaultComment)
+		err = amm.client.Create(context.TODO(), createDefaultMatchers(), now, end, amm.creatorName(), defaultComment)
 		if err != nil {
 			return err
 		}
@@ -124,9 +301,7 @@ func (amm *alertManagerMaintenance) StartControlPlane(endsAt time.Time, version
 
 	if !criticalExists {
 		if len(ignoredCriticalAlerts) > 0 {
-			icRegex := "(" + strings.Join(ignoredCriticalAlerts, "|") + ")"
-			matchers := []*amv2Models.Matcher{createMatcher("alertname", icRegex, true)}
-			err = amm.client.Create(matchers, now, end, config.OperatorName, criticalAlertComment)
+			err = amm.client.Create(context.TODO(), criticalMatchers, now, end, amm.creatorName(), criticalAlertComment)
 			if err != nil {
 				return err
 			}
@@ -139,9 +314,13 @@ func (amm *alertManagerMaintenance) StartControlPlane(endsAt time.Time, version
 // Start a worker node maintenance in Alertmanager for version
 // Time is converted to UTC
 func (amm *alertManagerMaintenance) SetWorker(endsAt time.Time, version string, count int32) error {
-	comment := fmt.Sprintf("Silence for %s upgrade to version %s", workerSilenceCommentId, version)
+	comment, err := amm.comment(workerSilenceCommentId, version)
+	if err != nil {
+		return err
+	}
 	fullComment := fmt.Sprintf("%s with remaining %d nodes", comment, count)
-	silenceList, err := amm.client.Filter(equalsComment(fullComment))
+	matchers := amm.workerMatchers()
+	silenceList, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeOrPendingSilences, equalsComment(fullComment), hasMatchers(matchers))
 	if err != nil {
 		return err
 	}
@@ -150,20 +329,20 @@ func (amm *alertManagerMaintenance) SetWorker(endsAt time.Time, version string,
 
 	end := strfmt.DateTime(endsAt.UTC())
 	if !exists {
-		oldSilenceList, err := amm.client.Filter(activeSilences, containsComment(comment))
+		oldSilenceList, err := amm.client.Filter(context.TODO(), activeSilences, containsComment(comment))
 		if err != nil {
 			return err
 		}
 		if len(*oldSilenceList) > 0 {
 			oldSl := *oldSilenceList
 			oldSilence := oldSl[0]
-			err = amm.client.Delete(*oldSilence.ID)
+			err = amm.client.Delete(context.TODO(), *oldSilence.ID)
 			if err != nil {
 				return err
 			}
 		}
 		now := strfmt.DateTime(time.Now().UTC())
-		err = amm.client.Create(createDefaultMatchers(), now, end, config.OperatorName, fullComment)
+		err = amm.client.Create(context.TODO(), matchers, now, end, amm.creatorName(), fullComment)
 		if err != nil {
 			return err
 		}
@@ -171,6 +350,43 @@ func (amm *alertManagerMaintenance) SetWorker(endsAt time.Time, version string,
 	return nil
 }
 
+// Silence the named alerts in Alertmanager during the upgrade to version, extending the
+// silence if it already exists and ends before endsAt
+// Time is converted to UTC
+func (amm *alertManagerMaintenance) SetAlerts(endsAt time.Time, version string, alertNames []string) error {
+	if len(alertNames) == 0 {
+		return nil
+	}
+
+	comment, err := amm.comment(alertsSilenceCommentId, version)
+	if err != nil {
+		return err
+	}
+	matchers := createAlertNameMatchers(alertNames)
+	silenceList, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeOrPendingSilences, equalsComment(comment), hasMatchers(matchers))
+	if err != nil {
+		return err
+	}
+
+	end := strfmt.DateTime(endsAt.UTC())
+	if len(*silenceList) == 0 {
+		now := strfmt.DateTime(time.Now().UTC())
+		return amm.client.Create(context.TODO(), matchers, now, end, amm.creatorName(), comment)
+	}
+
+	// A silence without an end cannot be told to outlast the maintenance, so it is updated
+	existing := (*silenceList)[0]
+	if existing.EndsAt == nil || time.Time(*existing.EndsAt).Before(endsAt) {
+		return amm.client.Update(context.TODO(), *existing.ID, end)
+	}
+	return nil
+}
+
+// End all active alert silences created by managed-upgrade-operator in Alertmanager
+func (amm *alertManagerMaintenance) EndAlerts() error {
+	return amm.EndSilences(alertsSilenceCommentId)
+}
+
 // End all active control plane maintenances created by managed-upgrade-operator in Alertmanager
 func (amm *alertManagerMaintenance) EndControlPlane() error {
 	return amm.EndSilences(controlPlaneSilenceCommentId)
@@ -184,14 +400,14 @@ func (amm *alertManagerMaintenance) EndWorker() error {
 // End all active control plane maintenances created by managed-upgrade-operator in Alertmanager
 // that have a comment field containing the supplied value
 func (amm *alertManagerMaintenance) EndSilences(comment string) error {
-	silences, err := amm.client.Filter(createdByOperator, activeSilences, containsComment(comment))
+	silences, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeSilences, containsComment(comment))
 	if err != nil {
 		return err
 	}
 
 	var deleteErrors *multierror.Error
 	for _, s := range *silences {
-		err := amm.client.Delete(*s.ID)
+		err := amm.client.Delete(context.TODO(), *s.ID)
 		if err != nil {
 			deleteErrors = multierror.Append(deleteErrors, err)
 		}
@@ -199,24 +415,61 @@ func (amm *alertManagerMaintenance) EndSilences(comment string) error {
 	return deleteErrors.ErrorOrNil()
 }
 
-func createMatcher(alertMatchKey string, alertValue string, isRegex bool) *amv2Models.Matcher {
-	return &amv2Models.Matcher{
-		Name:    &alertMatchKey,
-		IsRegex: &isRegex,
-		Value:   &alertValue,
+// Extend pushes back the end of the active maintenances for version by duration, keeping the
+// existing silences in place so that alerts are never left unsilenced while they are replaced
+func (amm *alertManagerMaintenance) Extend(duration time.Duration, version string) error {
+	silences, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeSilences, forVersion(version))
+	if err != nil {
+		return err
+	}
+	if len(*silences) == 0 {
+		return fmt.Errorf("no active maintenance found for version %s", version)
+	}
+
+	var updateErrors *multierror.Error
+	for _, s := range *silences {
+		end := strfmt.DateTime(time.Time(*s.EndsAt).Add(duration).UTC())
+		err := amm.client.Update(context.TODO(), *s.ID, end)
+		if err != nil {
+			updateErrors = multierror.Append(updateErrors, err)
+		}
 	}
+	return updateErrors.ErrorOrNil()
 }
 
 func createDefaultMatchers() []*amv2Models.Matcher {
 	// Upgrades can impact some availability which may trigger info/warning alerts. ignore those.
-	nonCriticalAlertMatcher := createMatcher("severity", "(warning|info)", true)
+	nonCriticalAlertMatcher := alertmanager.RegexMatcher("severity", "(warning|info)")
+
+	inNamespaceAlertMatcher := alertmanager.RegexMatcher("namespace", "(^openshift.*|^kube.*|^redhat.*|^default$)")
+	return alertmanager.Matchers(nonCriticalAlertMatcher, inNamespaceAlertMatcher)
+}
 
-	inNamespaceAlertMatcher := createMatcher("namespace", "(^openshift.*|^kube.*|^redhat.*|^default$)", true)
-	return amv2Models.Matchers{nonCriticalAlertMatcher, inNamespaceAlertMatcher}
+// workerMatchers returns the matchers of the worker maintenance silence
+func (amm *alertManagerMaintenance) workerMatchers() []*amv2Models.Matcher {
+	if len(amm.workerAlerts) == 0 {
+		return createDefaultMatchers()
+	}
+	return createAlertNameMatchers(amm.workerAlerts)
 }
 
+// createAlertNameMatchers matches any of the named alerts, whatever their severity or namespace
+func createAlertNameMatchers(alertNames []string) []*amv2Models.Matcher {
+	quoted := make([]string, 0, len(alertNames))
+	for _, name := range alertNames {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	return alertmanager.Matchers(alertmanager.RegexMatcher("alertname", strings.Join(quoted, "|")))
+}
+
+// IsActive reports whether a control plane or worker maintenance window is currently silencing
+// alerts. Pending maintenances, which have yet to start, are not active.
 func (amm *alertManagerMaintenance) IsActive() (bool, error) {
-	silences, err := amm.client.Filter(activeSilences, createdByOperator)
+	defaultMatchers := hasMatchers(createDefaultMatchers())
+	workerMatchers := hasMatchers(amm.workerMatchers())
+	silences, err := amm.client.Filter(context.TODO(), activeSilences, createdBy(amm.creatorName()), isMaintenanceWindow, func(s *amv2Models.GettableSilence) bool {
+		return defaultMatchers(s) || workerMatchers(s)
+	})
 	if err != nil {
 		return false, err
 	}
@@ -224,12 +477,63 @@ func (amm *alertManagerMaintenance) IsActive() (bool, error) {
 	return len(*silences) > 0, nil
 }
 
+// VerifyEnded checks that no active silences created by the operator remain once the
+// maintenances have been ended, as a deletion Alertmanager accepted may not have taken effect
+func (amm *alertManagerMaintenance) VerifyEnded() error {
+	silences, err := amm.client.Filter(context.TODO(), createdBy(amm.creatorName()), activeSilences)
+	if err != nil {
+		return fmt.Errorf("unable to verify maintenance silences have ended: %v", err)
+	}
+	if len(*silences) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(*silences))
+	for _, s := range *silences {
+		ids = append(ids, *s.ID)
+	}
+	return fmt.Errorf("%d maintenance silences are still active: %s", len(ids), strings.Join(ids, ", "))
+}
+
+// RecordActiveSilences updates the metric counting the operator's active silences, so that
+// silences left behind by an upgrade can be alerted on
+func (amm *alertManagerMaintenance) RecordActiveSilences(metricsClient metrics.Metrics, upgradeConfigName string) error {
+	count, err := amm.client.Count(context.TODO(), activeSilences, createdBy(amm.creatorName()))
+	if err != nil {
+		return err
+	}
+
+	metricsClient.UpdateMetricMaintenanceSilencesActive(upgradeConfigName, count)
+	return nil
+}
+
 var activeSilences = func(s *amv2Models.GettableSilence) bool {
-	return *s.Status.State == amv2Models.AlertStatusStateActive
+	return s.Status != nil && s.Status.State != nil && *s.Status.State == amv2Models.SilenceStatusStateActive
+}
+
+// activeOrPendingSilences matches silences that are silencing alerts or will start to. An
+// existing pending silence stops a duplicate being created.
+var activeOrPendingSilences = func(s *amv2Models.GettableSilence) bool {
+	if s.Status == nil || s.Status.State == nil {
+		return false
+	}
+	return *s.Status.State == amv2Models.SilenceStatusStateActive || *s.Status.State == amv2Models.SilenceStatusStatePending
+}
+
+var hasMatchers = func(matchers amv2Models.Matchers) func(s *amv2Models.GettableSilence) bool {
+	return func(s *amv2Models.GettableSilence) bool {
+		return alertmanager.MatchersEqual(s.Matchers, matchers)
+	}
 }
 
-var createdByOperator = func(s *amv2Models.GettableSilence) bool {
-	return *s.CreatedBy == config.OperatorName
+var isMaintenanceWindow = func(s *amv2Models.GettableSilence) bool {
+	return s.Comment != nil && (strings.Contains(*s.Comment, controlPlaneSilenceCommentId) || strings.Contains(*s.Comment, workerSilenceCommentId))
+}
+
+var createdBy = func(creator string) func(s *amv2Models.GettableSilence) bool {
+	return func(s *amv2Models.GettableSilence) bool {
+		return *s.CreatedBy == creator
+	}
 }
 
 var equalsComment = func(comment string) func(s *amv2Models.GettableSilence) bool {
@@ -243,3 +547,12 @@ var containsComment = func(comment string) func(s *amv2Models.GettableSilence) b
 		return strings.Contains(*s.Comment, comment)
 	}
 }
+
+// forVersion matches silences whose comment names version, and not a version that merely
+// starts or ends with it
+var forVersion = func(version string) func(s *amv2Models.GettableSilence) bool {
+	versionRegexp := regexp.MustCompile(`(^|[^\w.-])` + regexp.QuoteMeta(version) + `($|[^\w.-])`)
+	return func(s *amv2Models.GettableSilence) bool {
+		return versionRegexp.MatchString(*s.Comment)
+	}
+}
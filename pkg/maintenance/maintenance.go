@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 )
 
 //go:generate mockgen -destination=mocks/maintenance.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/maintenance Maintenance
@@ -12,8 +14,13 @@ type Maintenance interface {
 	SetWorker(endsAt time.Time, version string, count int32) error
 	EndControlPlane() error
 	EndWorker() error
+	SetAlerts(endsAt time.Time, version string, alertNames []string) error
+	EndAlerts() error
 	EndSilences(comment string) error
+	Extend(duration time.Duration, version string) error
 	IsActive() (bool, error)
+	VerifyEnded() error
+	RecordActiveSilences(metricsClient metrics.Metrics, upgradeConfigName string) error
 }
 
 //go:generate mockgen -destination=mocks/maintenanceBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/maintenance MaintenanceBuilder
@@ -21,6 +28,10 @@ type MaintenanceBuilder interface {
 	NewClient(client client.Client) (Maintenance, error)
 }
 
-func NewBuilder() MaintenanceBuilder {
-	return &alertManagerMaintenanceBuilder{}
+func NewBuilder(opts ...Option) MaintenanceBuilder {
+	ammb := &alertManagerMaintenanceBuilder{}
+	for _, opt := range opts {
+		opt(ammb)
+	}
+	return ammb
 }
@@ -3,13 +3,18 @@ package maintenance
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/golang/mock/gomock"
 	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/managed-upgrade-operator/pkg/alertmanager"
 	ammocks "github.com/openshift/managed-upgrade-operator/pkg/alertmanager/mocks"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,8 +85,8 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 	Context("Creating a Control Plane silence", func() {
 		It("Should not error on successfull maintenance start", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
-				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.StartControlPlane(end, testVersion, ignoredControlPlaneCriticals)
@@ -89,8 +94,8 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 		})
 		It("Should error on failing to start maintenance", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
-				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.StartControlPlane(end, testVersion, ignoredControlPlaneCriticals)
@@ -102,8 +107,8 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 	Context("Creating a worker silence", func() {
 		It("Should not error on successfull maintenance start", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
-				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.SetWorker(end, testVersion, testWorkerCount)
@@ -111,20 +116,54 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 		})
 		It("Should error on failing to start maintenance", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
-				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.SetWorker(end, testVersion, testWorkerCount)
 			Expect(err).Should(HaveOccurred())
 		})
+		It("Should only silence the configured node alerts", func() {
+			maintenance.workerAlerts = []string{"KubeNodeNotReady", "KubeNodeUnreachable"}
+			var created amv2Models.Matchers
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, matchers amv2Models.Matchers, _ strfmt.DateTime, _ strfmt.DateTime, _ string, _ string) error {
+						created = matchers
+						return nil
+					}),
+			)
+			end := time.Now().Add(90 * time.Minute)
+			err := maintenance.SetWorker(end, testVersion, testWorkerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(created).To(HaveLen(1))
+			Expect(*created[0].Name).To(Equal("alertname"))
+			Expect(*created[0].IsRegex).To(BeTrue())
+			Expect(*created[0].Value).To(Equal("KubeNodeNotReady|KubeNodeUnreachable"))
+		})
+		It("Should not treat a broad silence as the node alerts silence", func() {
+			maintenance.workerAlerts = []string{"KubeNodeNotReady"}
+			comment := fmt.Sprintf("Silence for OSD worker node upgrade to version %s with remaining %d nodes", testVersion, testWorkerCount)
+			broad := testActiveSilences[0]
+			broad.Comment = &comment
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures([]amv2Models.GettableSilence{broad})),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&[]amv2Models.GettableSilence{broad}, nil),
+				silenceClient.EXPECT().Delete(gomock.Any(), activeSilenceId),
+				silenceClient.EXPECT().Create(gomock.Any(), amv2Models.Matchers(createAlertNameMatchers([]string{"KubeNodeNotReady"})), gomock.Any(), gomock.Any(), gomock.Any(), comment).Return(nil),
+			)
+			end := time.Now().Add(90 * time.Minute)
+			err := maintenance.SetWorker(end, testVersion, testWorkerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
 	})
 
 	// Do not update if worker count unchanged
 	Context("Do not create new silence", func() {
 		It("Should not create new silence if one already exists with same comment", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.SetWorker(end, testVersion, testWorkerCount)
@@ -136,10 +175,10 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 	Context("Recreate silence", func() {
 		It("Should create new silence when the worker count changed", func() {
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testNoActiveSilences, nil),
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&testActiveSilences, nil),
-				silenceClient.EXPECT().Delete(gomock.Any()),
-				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Delete(gomock.Any(), gomock.Any()),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 			)
 			end := time.Now().Add(90 * time.Minute)
 			err := maintenance.SetWorker(end, testVersion, testNewWorkerCount)
@@ -162,7 +201,7 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			testSilenceNotOwned := testSilence
 			testSilenceNotOwned.CreatedBy = &testCreatedByTest
 
-			silenceClient.EXPECT().Filter(gomock.Any()).Return(&[]amv2Models.GettableSilence{}, nil)
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&[]amv2Models.GettableSilence{}, nil)
 			err := maintenance.EndSilences("")
 			Expect(err).Should(Not(HaveOccurred()))
 		})
@@ -182,13 +221,295 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			activeSilences = append(activeSilences, gettableSilence)
 
 			gomock.InOrder(
-				silenceClient.EXPECT().Filter(gomock.Any()).Return(&activeSilences, nil),
-				silenceClient.EXPECT().Delete(testId).Return(nil),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&activeSilences, nil),
+				silenceClient.EXPECT().Delete(gomock.Any(), testId).Return(nil),
 			)
 			err := maintenance.EndSilences("")
 			Expect(err).Should(Not(HaveOccurred()))
 		})
 	})
+	// Silencing the configured alerts for the upgrade
+	Context("Silencing configured alerts", func() {
+		alertNames := []string{"KubeNodeUnreachable", "KubeNodeNotReady"}
+
+		It("Should match the configured alert names", func() {
+			matchers := createAlertNameMatchers(alertNames)
+			Expect(matchers).To(HaveLen(1))
+			Expect(*matchers[0].Name).To(Equal("alertname"))
+			Expect(*matchers[0].Value).To(Equal("KubeNodeUnreachable|KubeNodeNotReady"))
+			Expect(*matchers[0].IsRegex).To(BeTrue())
+		})
+		It("Should escape alert names that contain regex characters", func() {
+			matchers := createAlertNameMatchers([]string{"Alert.Name"})
+			Expect(*matchers[0].Value).To(Equal(`Alert\.Name`))
+		})
+		It("Should create a silence when none exists", func() {
+			comment := fmt.Sprintf("Silence for configured alerts during OSD upgrade to version %s", testVersion)
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil),
+				silenceClient.EXPECT().Create(gomock.Any(), createAlertNameMatchers(alertNames), gomock.Any(), gomock.Any(), testOperatorName, comment).Return(nil),
+			)
+			err := maintenance.SetAlerts(time.Now().Add(90*time.Minute), testVersion, alertNames)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should extend an existing silence that ends too soon", func() {
+			end := time.Time(testEnd).Add(time.Hour)
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Update(gomock.Any(), activeSilenceId, strfmt.DateTime(end.UTC())).Return(nil),
+			)
+			err := maintenance.SetAlerts(end, testVersion, alertNames)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should leave an existing silence that ends later alone", func() {
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil)
+			err := maintenance.SetAlerts(time.Time(testEnd).Add(-time.Minute), testVersion, alertNames)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should update an existing silence without an end", func() {
+			end := time.Time(testEnd).Add(time.Hour)
+			silence := testActiveSilences[0]
+			silence.EndsAt = nil
+			silences := []amv2Models.GettableSilence{silence}
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&silences, nil),
+				silenceClient.EXPECT().Update(gomock.Any(), activeSilenceId, strfmt.DateTime(end.UTC())).Return(nil),
+			)
+			err := maintenance.SetAlerts(end, testVersion, alertNames)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should do nothing if no alerts are configured", func() {
+			err := maintenance.SetAlerts(time.Now().Add(90*time.Minute), testVersion, []string{})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should remove the alert silences at the end of the upgrade", func() {
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Delete(gomock.Any(), activeSilenceId).Return(nil),
+			)
+			err := maintenance.EndAlerts()
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	// Extending an active maintenance in place
+	Context("Extend an active maintenance", func() {
+		It("Should push back the end of the existing silence without recreating it", func() {
+			extendedEnd := strfmt.DateTime(time.Time(testEnd).Add(30 * time.Minute).UTC())
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Update(gomock.Any(), activeSilenceId, extendedEnd).Return(nil),
+			)
+			err := maintenance.Extend(30*time.Minute, testVersion)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should error if there is no active maintenance to extend", func() {
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil)
+			err := maintenance.Extend(30*time.Minute, testVersion)
+			Expect(err).Should(HaveOccurred())
+		})
+		It("Should error if the silence can not be updated", func() {
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testActiveSilences, nil),
+				silenceClient.EXPECT().Update(gomock.Any(), activeSilenceId, gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			err := maintenance.Extend(30*time.Minute, testVersion)
+			Expect(err).Should(HaveOccurred())
+		})
+		It("Should only match silences for the exact version", func() {
+			comment := "Silence for OSD control plane upgrade to version 4.5.1"
+			workerComment := "Silence for OSD worker node upgrade to version 4.5.1 with remaining 3 nodes"
+			otherComment := "Silence for OSD control plane upgrade to version 4.5.10"
+			Expect(forVersion("4.5.1")(&amv2Models.GettableSilence{Silence: amv2Models.Silence{Comment: &comment}})).To(BeTrue())
+			Expect(forVersion("4.5.1")(&amv2Models.GettableSilence{Silence: amv2Models.Silence{Comment: &workerComment}})).To(BeTrue())
+			Expect(forVersion("4.5.1")(&amv2Models.GettableSilence{Silence: amv2Models.Silence{Comment: &otherComment}})).To(BeFalse())
+		})
+	})
+	// Rendering configurable comments and creators
+	Context("Configuring the comment and creator", func() {
+		var upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+
+		It("Should render the default comment as before", func() {
+			comment, err := maintenance.comment(controlPlaneSilenceCommentId, upgradeConfig.Spec.Desired.Version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(comment).To(Equal("Silence for OSD control plane upgrade to version " + upgradeConfig.Spec.Desired.Version))
+		})
+		It("Should render a custom template with the version and cluster", func() {
+			t, err := ParseCommentTemplate("[{{.Cluster}}] {{.Description}} maintenance for {{.Version}}")
+			Expect(err).ShouldNot(HaveOccurred())
+			maintenance.commentTemplate = t
+			maintenance.cluster = "test-cluster"
+
+			comment, err := maintenance.comment(workerSilenceCommentId, upgradeConfig.Spec.Desired.Version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(comment).To(Equal("[test-cluster] OSD worker node maintenance for " + upgradeConfig.Spec.Desired.Version))
+			again, err := maintenance.comment(workerSilenceCommentId, upgradeConfig.Spec.Desired.Version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(again).To(Equal(comment))
+		})
+		It("Should reject templates that can not identify the maintenance", func() {
+			_, err := ParseCommentTemplate("Upgrade of {{.Cluster}}")
+			Expect(err).Should(HaveOccurred())
+			_, err = ParseCommentTemplate("{{.Description}} {{.Version")
+			Expect(err).Should(HaveOccurred())
+			_, err = ParseCommentTemplate("{{.Description}} {{.Version}} {{.Unknown}}")
+			Expect(err).Should(HaveOccurred())
+		})
+		It("Should create silences with the configured creator and comment", func() {
+			t, err := ParseCommentTemplate("{{.Description}} {{.Version}} on {{.Cluster}}")
+			Expect(err).ShouldNot(HaveOccurred())
+			maintenance.commentTemplate = t
+			maintenance.cluster = "test-cluster"
+			maintenance.creator = testCreatedByTest
+
+			comment := fmt.Sprintf("OSD worker node %s on test-cluster with remaining %d nodes", upgradeConfig.Spec.Desired.Version, testWorkerCount)
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil).Times(2),
+				silenceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), testCreatedByTest, comment).Return(nil),
+			)
+			err = maintenance.SetWorker(time.Now().Add(90*time.Minute), upgradeConfig.Spec.Desired.Version, testWorkerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should only treat silences from the configured creator as its own", func() {
+			maintenance.creator = testCreatedByTest
+			owned := amv2Models.GettableSilence{Silence: amv2Models.Silence{CreatedBy: &testCreatedByTest}}
+			notOwned := amv2Models.GettableSilence{Silence: amv2Models.Silence{CreatedBy: &testCreatedByOperator}}
+			Expect(createdBy(maintenance.creatorName())(&owned)).To(BeTrue())
+			Expect(createdBy(maintenance.creatorName())(&notOwned)).To(BeFalse())
+		})
+	})
+
+	// Deciding whether maintenance is active
+	Context("Checking whether maintenance is active", func() {
+		var (
+			workerComment  = fmt.Sprintf("Silence for OSD worker node upgrade to version %s with remaining %d nodes", testVersion, testWorkerCount)
+			pendingStatus  = amv2Models.SilenceStatusStatePending
+			reordered      = amv2Models.Matchers{createDefaultMatchers()[1], createDefaultMatchers()[0]}
+			otherMatchers  = alertmanager.Matchers(alertmanager.RegexMatcher("severity", "(warning|info)"))
+			maintenanceFor = func(state *string, comment string, matchers amv2Models.Matchers) amv2Models.GettableSilence {
+				return amv2Models.GettableSilence{
+					ID:     &activeSilenceId,
+					Status: &amv2Models.SilenceStatus{State: state},
+					Silence: amv2Models.Silence{
+						Comment:   &comment,
+						CreatedBy: &testCreatedByOperator,
+						EndsAt:    &testEnd,
+						Matchers:  matchers,
+						StartsAt:  &testNow,
+					},
+				}
+			}
+		)
+
+		It("Should be active when the matchers are in a different order", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&activeSilenceStatus, workerComment, reordered)}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			active, err := maintenance.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(BeTrue())
+		})
+		It("Should be active for a worker maintenance silencing only node alerts", func() {
+			maintenance.workerAlerts = []string{"KubeNodeNotReady"}
+			silences := []amv2Models.GettableSilence{maintenanceFor(&activeSilenceStatus, workerComment, createAlertNameMatchers(maintenance.workerAlerts))}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			active, err := maintenance.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(BeTrue())
+		})
+		It("Should not be active when the matchers differ", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&activeSilenceStatus, workerComment, otherMatchers)}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			active, err := maintenance.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+		It("Should not be active when the comment is not a maintenance comment", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&activeSilenceStatus, testComment, createDefaultMatchers())}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			active, err := maintenance.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+		It("Should not be active while the maintenance is pending", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&pendingStatus, workerComment, createDefaultMatchers())}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			active, err := maintenance.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+		It("Should not recreate a pending worker maintenance", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&pendingStatus, workerComment, reordered)}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			err := maintenance.SetWorker(time.Now().Add(90*time.Minute), testVersion, testWorkerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should create a worker maintenance when the existing silence has other matchers", func() {
+			silences := []amv2Models.GettableSilence{maintenanceFor(&activeSilenceStatus, workerComment, otherMatchers)}
+			gomock.InOrder(
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences)),
+				silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(&testNoActiveSilences, nil),
+				silenceClient.EXPECT().Create(gomock.Any(), createDefaultMatchers(), gomock.Any(), gomock.Any(), gomock.Any(), workerComment).Return(nil),
+			)
+			err := maintenance.SetWorker(time.Now().Add(90*time.Minute), testVersion, testWorkerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	// Recording the active silences metric
+	Context("Recording active maintenance silences", func() {
+		It("Should set the gauge to the number of active operator silences", func() {
+			mockMetricsClient := mockMetrics.NewMockMetrics(mockCtrl)
+			ownedStatus := amv2Models.SilenceStatusStateActive
+			expiredStatus := amv2Models.SilenceStatusStateExpired
+			silences := []amv2Models.GettableSilence{
+				{Status: &amv2Models.SilenceStatus{State: &ownedStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByOperator}},
+				{Status: &amv2Models.SilenceStatus{State: &ownedStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByOperator}},
+				{Status: &amv2Models.SilenceStatus{State: &ownedStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByTest}},
+				{Status: &amv2Models.SilenceStatus{State: &expiredStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByOperator}},
+			}
+			gomock.InOrder(
+				silenceClient.EXPECT().Count(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, predicates ...alertmanager.SilencePredicate) (int, error) {
+					filtered, err := filterFixtures(silences)(ctx, predicates...)
+					return len(*filtered), err
+				}),
+				mockMetricsClient.EXPECT().UpdateMetricMaintenanceSilencesActive("test-upgradeconfig", 2),
+			)
+			err := maintenance.RecordActiveSilences(mockMetricsClient, "test-upgradeconfig")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should leave the gauge alone if the silences can not be counted", func() {
+			mockMetricsClient := mockMetrics.NewMockMetrics(mockCtrl)
+			silenceClient.EXPECT().Count(gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("fake error"))
+			err := maintenance.RecordActiveSilences(mockMetricsClient, "test-upgradeconfig")
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	// Verifying maintenances have been removed
+	Context("Verifying maintenance silences have ended", func() {
+		It("Should succeed when no operator silences remain", func() {
+			expiredStatus := amv2Models.SilenceStatusStateExpired
+			silences := []amv2Models.GettableSilence{
+				{ID: &activeSilenceId, Status: &amv2Models.SilenceStatus{State: &expiredStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByOperator}},
+				{ID: &activeSilenceId, Status: &amv2Models.SilenceStatus{State: &activeSilenceStatus}, Silence: amv2Models.Silence{CreatedBy: &testCreatedByTest}},
+			}
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(silences))
+			err := maintenance.VerifyEnded()
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("Should error with the IDs of lingering operator silences", func() {
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).DoAndReturn(filterFixtures(testActiveSilences))
+			err := maintenance.VerifyEnded()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(activeSilenceId))
+		})
+		It("Should error if Alertmanager can not be reached", func() {
+			silenceClient.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("fake error"))
+			err := maintenance.VerifyEnded()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
 	// Finding and removing all active maintenances
 	Context("Build Alert Manager", func() {
 		It("Build an Alert Manager Client and not return an error", func() {
@@ -202,5 +523,70 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			_, err := ammb.NewClient(mockKubeClient)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+		It("Build an Alert Manager Client for an overridden Alertmanager URL without the service account's token", func() {
+			authorization := make(chan string, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization <- r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+			ammb := alertManagerMaintenanceBuilder{alertManagerURL: server.URL}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			m, err := ammb.NewClient(mockKubeClient)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = m.IsActive()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(authorization).To(Receive(BeEmpty()))
+		})
+		It("Targets the overridden Alertmanager host and path", func() {
+			alertManagerURL, err := ParseAlertManagerURL("http://observability.example.com:9093/alertmanager/")
+			Expect(err).ShouldNot(HaveOccurred())
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			transport, err := getTransport(mockKubeClient, alertManagerURL)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(transport.Host).To(Equal("observability.example.com:9093"))
+			Expect(transport.BasePath).To(Equal("/alertmanager/api/v2/"))
+		})
+		It("Targets the in-cluster Alertmanager route without an override", func() {
+			mockKubeClient.EXPECT().Get(context.TODO(), types.NamespacedName{Namespace: alertManagerNamespace, Name: alertManagerRouteName}, gomock.Any()).SetArg(2, routev1.Route{Spec: routev1.RouteSpec{Host: "alertmanager-main.apps.example.com"}})
+
+			transport, err := getTransport(mockKubeClient, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(transport.Host).To(Equal("alertmanager-main.apps.example.com"))
+			Expect(transport.BasePath).To(Equal(alertManagerBasePath))
+		})
+		It("Rejects invalid Alertmanager URLs", func() {
+			for _, invalid := range []string{"alertmanager.example.com", "ftp://alertmanager.example.com", "https://", "https://alertmanager.example.com/?silence=1", "://bad"} {
+				_, err := ParseAlertManagerURL(invalid)
+				Expect(err).Should(HaveOccurred(), invalid)
+			}
+			u, err := ParseAlertManagerURL("")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(u).To(BeNil())
+		})
 	})
 })
+
+// filterFixtures returns a Filter implementation applying the predicates to silences
+func filterFixtures(silences []amv2Models.GettableSilence) func(context.Context, ...alertmanager.SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+	return func(_ context.Context, predicates ...alertmanager.SilencePredicate) (*[]amv2Models.GettableSilence, error) {
+		filtered := []amv2Models.GettableSilence{}
+		for i := range silences {
+			matches := true
+			for _, p := range predicates {
+				if !p(&silences[i]) {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				filtered = append(filtered, silences[i])
+			}
+		}
+		return &filtered, nil
+	}
+}
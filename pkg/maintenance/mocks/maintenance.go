@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	metrics "github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	reflect "reflect"
 	time "time"
 )
@@ -33,6 +34,20 @@ func (m *MockMaintenance) EXPECT() *MockMaintenanceMockRecorder {
 	return m.recorder
 }
 
+// EndAlerts mocks base method
+func (m *MockMaintenance) EndAlerts() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndAlerts")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndAlerts indicates an expected call of EndAlerts
+func (mr *MockMaintenanceMockRecorder) EndAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndAlerts", reflect.TypeOf((*MockMaintenance)(nil).EndAlerts))
+}
+
 // EndControlPlane mocks base method
 func (m *MockMaintenance) EndControlPlane() error {
 	m.ctrl.T.Helper()
@@ -75,6 +90,20 @@ func (mr *MockMaintenanceMockRecorder) EndWorker() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndWorker", reflect.TypeOf((*MockMaintenance)(nil).EndWorker))
 }
 
+// Extend mocks base method
+func (m *MockMaintenance) Extend(arg0 time.Duration, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend
+func (mr *MockMaintenanceMockRecorder) Extend(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockMaintenance)(nil).Extend), arg0, arg1)
+}
+
 // IsActive mocks base method
 func (m *MockMaintenance) IsActive() (bool, error) {
 	m.ctrl.T.Helper()
@@ -90,6 +119,34 @@ func (mr *MockMaintenanceMockRecorder) IsActive() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockMaintenance)(nil).IsActive))
 }
 
+// RecordActiveSilences mocks base method
+func (m *MockMaintenance) RecordActiveSilences(arg0 metrics.Metrics, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordActiveSilences", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordActiveSilences indicates an expected call of RecordActiveSilences
+func (mr *MockMaintenanceMockRecorder) RecordActiveSilences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActiveSilences", reflect.TypeOf((*MockMaintenance)(nil).RecordActiveSilences), arg0, arg1)
+}
+
+// SetAlerts mocks base method
+func (m *MockMaintenance) SetAlerts(arg0 time.Time, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlerts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlerts indicates an expected call of SetAlerts
+func (mr *MockMaintenanceMockRecorder) SetAlerts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlerts", reflect.TypeOf((*MockMaintenance)(nil).SetAlerts), arg0, arg1, arg2)
+}
+
 // SetWorker mocks base method
 func (m *MockMaintenance) SetWorker(arg0 time.Time, arg1 string, arg2 int32) error {
 	m.ctrl.T.Helper()
@@ -117,3 +174,17 @@ func (mr *MockMaintenanceMockRecorder) StartControlPlane(arg0, arg1, arg2 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartControlPlane", reflect.TypeOf((*MockMaintenance)(nil).StartControlPlane), arg0, arg1, arg2)
 }
+
+// VerifyEnded mocks base method
+func (m *MockMaintenance) VerifyEnded() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEnded")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEnded indicates an expected call of VerifyEnded
+func (mr *MockMaintenanceMockRecorder) VerifyEnded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEnded", reflect.TypeOf((*MockMaintenance)(nil).VerifyEnded))
+}
@@ -22,6 +22,11 @@ const (
 	metricsTag = "upgradeoperator"
 	nameLabel  = "upgradeconfig_name"
 	nodeLabel  = "node_name"
+	poolLabel  = "machineconfigpool"
+
+	outcomeLabel = "outcome"
+	reasonLabel  = "reason"
+	phaseLabel   = "phase"
 
 	Namespace = "upgradeoperator"
 	Subsystem = "upgrade"
@@ -36,6 +41,11 @@ const (
 	ControlPlaneCompletedStateValue = "control_plane_completed"
 	WorkersStartedStateValue        = "workers_started"
 	WorkersCompletedStateValue      = "workers_completed"
+
+	DrainOutcomeSuccess        = "success"
+	DrainOutcomeTimeout        = "timeout"
+	DrainOutcomeForceEscalated = "force_escalated"
+	DrainOutcomeFailed         = "failed"
 )
 
 //go:generate mockgen -destination=mocks/metrics.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/metrics Metrics
@@ -52,6 +62,8 @@ type Metrics interface {
 	UpdateMetricUpgradeConfigSynced(string)
 	ResetMetricUpgradeConfigSynced(string)
 	UpdateMetricUpgradeWindowBreached(string)
+	UpdateMetricUpgradeStartedInWindow(string, bool)
+	UpdateMetricUpgradeWindowExceeded(string, bool)
 	UpdateMetricUpgradeControlPlaneTimeout(string, string)
 	ResetMetricUpgradeControlPlaneTimeout(string, string)
 	UpdateMetricUpgradeWorkerTimeout(string, string)
@@ -59,9 +71,19 @@ type Metrics interface {
 	UpdateMetricNodeDrainFailed(string)
 	ResetMetricNodeDrainFailed(string)
 	ResetAllMetricNodeDrainFailed()
+	UpdateMetricNodeDrainPDBForced(string)
+	UpdateMetricNodeDrainOutcome(string, string, time.Duration)
+	UpdateMetricUpgradeDuration(string, string, time.Duration)
+	UpdateMetricUpgradeFailed(string, string)
+	UpdateMetricUpgradePhaseDuration(string, string, time.Duration)
+	ResetMetricUpgradePhaseDuration()
 	ResetFailureMetrics()
+	ResetUpgradeMetrics()
 	ResetAllMetrics()
 	UpdateMetricNotificationEventSent(string, string, string)
+	UpdateMetricNotificationSendAttempt(string)
+	UpdateMetricNotificationSendFailed(string)
+	UpdateMetricMaintenanceSilencesActive(string, int)
 	IsAlertFiring(alert string, checkedNS, ignoredNS []string) (bool, error)
 	IsMetricNotificationEventSentSet(upgradeConfigName string, event string, version string) (bool, error)
 	IsClusterVersionAtVersion(version string) (bool, error)
@@ -143,6 +165,16 @@ var (
 		Name:      "upgrade_window_breached",
 		Help:      "Failed to commence upgrade during the upgrade window",
 	}, []string{nameLabel})
+	metricUpgradeStartedInWindow = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "upgrade_started_in_window",
+		Help:      "Upgrade commenced within its upgrade window",
+	}, []string{nameLabel})
+	metricUpgradeWindowExceeded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "upgrade_window_exceeded",
+		Help:      "Upgrade completed after the expected end of its upgrade window",
+	}, []string{nameLabel})
 	metricUpgradeConfigSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: metricsTag,
 		Name:      "upgradeconfig_synced",
@@ -163,11 +195,57 @@ var (
 		Name:      "node_drain_timeout",
 		Help:      "Node cannot be drained successfully in time.",
 	}, []string{nodeLabel})
+	metricNodeDrainPDBForced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "node_drain_pdb_forced",
+		Help:      "Pods protected by a PodDisruptionBudget were forcibly removed to drain the node.",
+	}, []string{nodeLabel})
+	metricNodeDrainDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsTag,
+		Name:      "node_drain_duration_seconds",
+		Help:      "Time taken to drain a node, from its cordon until the drain outcome is known.",
+		Buckets:   []float64{60, 300, 600, 1200, 1800, 2700, 3600, 7200},
+	}, []string{poolLabel})
+	metricNodeDrainOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsTag,
+		Name:      "node_drain_outcomes_total",
+		Help:      "Node drains by outcome.",
+	}, []string{poolLabel, outcomeLabel})
+	metricUpgradeDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "upgrade_duration_seconds",
+		Help:      "Time taken by a successful upgrade, from its commencement to its completion.",
+	}, []string{nameLabel, VersionLabel})
+	metricUpgradePhaseDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "upgrade_phase_duration_seconds",
+		Help:      "Time spent in each phase of the current upgrade.",
+	}, []string{nameLabel, phaseLabel})
+	metricUpgradesFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsTag,
+		Name:      "upgrades_failed_total",
+		Help:      "Upgrades that failed, by the reason for their failure.",
+	}, []string{nameLabel, reasonLabel})
 	metricUpgradeNotification = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: metricsTag,
 		Name:      "upgrade_notification",
 		Help:      "Notification event raised",
 	}, []string{nameLabel, eventLabel, VersionLabel})
+	metricNotificationSendAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsTag,
+		Name:      "notification_send_attempts_total",
+		Help:      "Attempts made to send a notification, by the event notified.",
+	}, []string{eventLabel})
+	metricNotificationSendFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsTag,
+		Name:      "notification_send_failures_total",
+		Help:      "Notifications that could not be sent once retries were exhausted, by the event notified.",
+	}, []string{eventLabel})
+	metricMaintenanceSilencesActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "maintenance_silences_active",
+		Help:      "Number of active maintenance silences created by the operator",
+	}, []string{nameLabel})
 
 	metricsList = []*prometheus.GaugeVec{
 		metricValidationFailed,
@@ -175,10 +253,35 @@ var (
 		metricScalingFailed,
 		metricClusterVerificationFailed,
 		metricUpgradeWindowBreached,
+		metricUpgradeStartedInWindow,
+		metricUpgradeWindowExceeded,
 		metricUpgradeConfigSynced,
 		metricUpgradeControlPlaneTimeout,
 		metricUpgradeWorkerTimeout,
 		metricNodeDrainFailed,
+		metricNodeDrainPDBForced,
+		metricUpgradeDuration,
+		metricUpgradePhaseDuration,
+		metricUpgradeNotification,
+		metricMaintenanceSilencesActive,
+	}
+
+	// upgradeMetricsList holds the metrics that describe a single upgrade. Counters and
+	// histograms which accumulate across upgrades are deliberately excluded.
+	upgradeMetricsList = []*prometheus.GaugeVec{
+		metricValidationFailed,
+		metricClusterCheckFailed,
+		metricScalingFailed,
+		metricClusterVerificationFailed,
+		metricUpgradeWindowBreached,
+		metricUpgradeStartedInWindow,
+		metricUpgradeWindowExceeded,
+		metricUpgradeControlPlaneTimeout,
+		metricUpgradeWorkerTimeout,
+		metricNodeDrainFailed,
+		metricNodeDrainPDBForced,
+		metricUpgradeDuration,
+		metricUpgradePhaseDuration,
 		metricUpgradeNotification,
 	}
 )
@@ -187,6 +290,7 @@ func init() {
 	for _, m := range metricsList {
 		metrics.Registry.MustRegister(m)
 	}
+	metrics.Registry.MustRegister(metricNodeDrainDuration, metricNodeDrainOutcomes, metricUpgradesFailed, metricNotificationSendAttempts, metricNotificationSendFailures)
 }
 
 func (c *Counter) UpdateMetricValidationFailed(upgradeConfigName string) {
@@ -277,6 +381,45 @@ func (c *Counter) ResetAllMetricNodeDrainFailed() {
 	metricNodeDrainFailed.Reset()
 }
 
+func (c *Counter) UpdateMetricNodeDrainPDBForced(nodeName string) {
+	metricNodeDrainPDBForced.With(prometheus.Labels{
+		nodeLabel: nodeName}).Set(
+		float64(1))
+}
+
+// UpdateMetricNodeDrainOutcome records the outcome and duration of a node drain in the given MachineConfigPool
+func (c *Counter) UpdateMetricNodeDrainOutcome(pool string, outcome string, duration time.Duration) {
+	metricNodeDrainOutcomes.With(prometheus.Labels{
+		poolLabel:    pool,
+		outcomeLabel: outcome}).Inc()
+	metricNodeDrainDuration.With(prometheus.Labels{
+		poolLabel: pool}).Observe(duration.Seconds())
+}
+
+func (c *Counter) UpdateMetricUpgradeDuration(upgradeConfigName, version string, duration time.Duration) {
+	metricUpgradeDuration.With(prometheus.Labels{
+		VersionLabel: version,
+		nameLabel:    upgradeConfigName}).Set(
+		duration.Seconds())
+}
+
+func (c *Counter) UpdateMetricUpgradePhaseDuration(upgradeConfigName, phase string, duration time.Duration) {
+	metricUpgradePhaseDuration.With(prometheus.Labels{
+		nameLabel:  upgradeConfigName,
+		phaseLabel: phase}).Set(
+		duration.Seconds())
+}
+
+func (c *Counter) ResetMetricUpgradePhaseDuration() {
+	metricUpgradePhaseDuration.Reset()
+}
+
+func (c *Counter) UpdateMetricUpgradeFailed(upgradeConfigName, reason string) {
+	metricUpgradesFailed.With(prometheus.Labels{
+		nameLabel:   upgradeConfigName,
+		reasonLabel: reason}).Inc()
+}
+
 func (c *Counter) UpdateMetricClusterVerificationFailed(upgradeConfigName string) {
 	metricClusterVerificationFailed.With(prometheus.Labels{
 		nameLabel: upgradeConfigName}).Set(
@@ -301,6 +444,25 @@ func (c *Counter) UpdateMetricUpgradeWindowBreached(upgradeConfigName string) {
 		float64(1))
 }
 
+func (c *Counter) UpdateMetricUpgradeStartedInWindow(upgradeConfigName string, inWindow bool) {
+	metricUpgradeStartedInWindow.With(prometheus.Labels{
+		nameLabel: upgradeConfigName}).Set(
+		boolToFloat(inWindow))
+}
+
+func (c *Counter) UpdateMetricUpgradeWindowExceeded(upgradeConfigName string, exceeded bool) {
+	metricUpgradeWindowExceeded.With(prometheus.Labels{
+		nameLabel: upgradeConfigName}).Set(
+		boolToFloat(exceeded))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (c *Counter) UpdateMetricNotificationEventSent(upgradeConfigName string, event string, version string) {
 	metricUpgradeNotification.With(prometheus.Labels{
 		VersionLabel: version,
@@ -309,6 +471,22 @@ func (c *Counter) UpdateMetricNotificationEventSent(upgradeConfigName string, ev
 		float64(1))
 }
 
+func (c *Counter) UpdateMetricNotificationSendAttempt(event string) {
+	metricNotificationSendAttempts.With(prometheus.Labels{
+		eventLabel: event}).Inc()
+}
+
+func (c *Counter) UpdateMetricNotificationSendFailed(event string) {
+	metricNotificationSendFailures.With(prometheus.Labels{
+		eventLabel: event}).Inc()
+}
+
+func (c *Counter) UpdateMetricMaintenanceSilencesActive(upgradeConfigName string, count int) {
+	metricMaintenanceSilencesActive.With(prometheus.Labels{
+		nameLabel: upgradeConfigName}).Set(
+		float64(count))
+}
+
 // ResetAllMetrics will reset all the metrics
 func (c *Counter) ResetAllMetrics() {
 	for _, m := range metricsList {
@@ -316,6 +494,13 @@ func (c *Counter) ResetAllMetrics() {
 	}
 }
 
+// ResetUpgradeMetrics will reset the metrics describing previous upgrades
+func (c *Counter) ResetUpgradeMetrics() {
+	for _, m := range upgradeMetricsList {
+		m.Reset()
+	}
+}
+
 // ResetFailureMetrics will reset the metric which indicates the upgrade failed
 func (c *Counter) ResetFailureMetrics() {
 	failureMetricsList := []*prometheus.GaugeVec{
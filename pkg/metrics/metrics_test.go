@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// seriesCount returns the number of series a collector currently reports
+func seriesCount(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric, 100)
+	c.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+var _ = Describe("Metrics", func() {
+	var c *Counter
+
+	BeforeEach(func() {
+		c = &Counter{}
+		metricNodeDrainDuration.Reset()
+		metricNodeDrainOutcomes.Reset()
+		metricUpgradeDuration.Reset()
+		metricUpgradesFailed.Reset()
+	})
+
+	Context("When a new upgrade is observed", func() {
+		It("clears the metrics of previous upgrades", func() {
+			c.UpdateMetricUpgradeWorkerTimeout("managed-upgrade-config", "4.5.15")
+			c.UpdateMetricNotificationEventSent("managed-upgrade-config", "completed", "4.5.15")
+			c.UpdateMetricUpgradeDuration("managed-upgrade-config", "4.5.15", time.Hour)
+			c.UpdateMetricUpgradePhaseDuration("managed-upgrade-config", "workers", time.Hour)
+			c.ResetUpgradeMetrics()
+			for _, m := range []prometheus.Collector{metricUpgradeWorkerTimeout, metricUpgradeNotification, metricUpgradeDuration, metricUpgradePhaseDuration} {
+				Expect(seriesCount(m)).To(Equal(0))
+			}
+		})
+
+		It("keeps the metrics which accumulate across upgrades", func() {
+			c.UpdateMetricUpgradeFailed("managed-upgrade-config", "FailedUpgrade")
+			c.UpdateMetricNodeDrainOutcome("worker", DrainOutcomeSuccess, time.Minute)
+			c.ResetUpgradeMetrics()
+			Expect(seriesCount(metricUpgradesFailed)).To(Equal(1))
+			Expect(seriesCount(metricNodeDrainOutcomes)).To(Equal(1))
+			Expect(seriesCount(metricNodeDrainDuration)).To(Equal(1))
+		})
+	})
+
+	Context("When recording the end of an upgrade", func() {
+		It("records the duration of a completed upgrade", func() {
+			c.UpdateMetricUpgradeDuration("managed-upgrade-config", "4.5.16", 2*time.Hour)
+			Expect(testutil.ToFloat64(metricUpgradeDuration.WithLabelValues("managed-upgrade-config", "4.5.16"))).To(Equal(float64(7200)))
+		})
+
+		It("counts failed upgrades by reason", func() {
+			c.UpdateMetricUpgradeFailed("managed-upgrade-config", "FailedUpgrade")
+			c.UpdateMetricUpgradeFailed("managed-upgrade-config", "FailedUpgrade")
+			Expect(testutil.ToFloat64(metricUpgradesFailed.WithLabelValues("managed-upgrade-config", "FailedUpgrade"))).To(Equal(float64(2)))
+		})
+
+		It("keeps counting failed upgrades when all metrics are reset", func() {
+			c.UpdateMetricUpgradeFailed("managed-upgrade-config", "FailedUpgrade")
+			c.ResetAllMetrics()
+			Expect(testutil.ToFloat64(metricUpgradesFailed.WithLabelValues("managed-upgrade-config", "FailedUpgrade"))).To(Equal(float64(1)))
+		})
+	})
+
+	Context("When recording upgrade window adherence", func() {
+		It("records whether the upgrade started within its window", func() {
+			c.UpdateMetricUpgradeStartedInWindow("managed-upgrade-config", true)
+			Expect(testutil.ToFloat64(metricUpgradeStartedInWindow.WithLabelValues("managed-upgrade-config"))).To(Equal(float64(1)))
+			c.UpdateMetricUpgradeStartedInWindow("managed-upgrade-config", false)
+			Expect(testutil.ToFloat64(metricUpgradeStartedInWindow.WithLabelValues("managed-upgrade-config"))).To(Equal(float64(0)))
+		})
+
+		It("records whether the upgrade exceeded its window", func() {
+			c.UpdateMetricUpgradeWindowExceeded("managed-upgrade-config", true)
+			Expect(testutil.ToFloat64(metricUpgradeWindowExceeded.WithLabelValues("managed-upgrade-config"))).To(Equal(float64(1)))
+		})
+	})
+
+	Context("When recording notification sends", func() {
+		It("counts attempts and final failures by event", func() {
+			metricNotificationSendAttempts.Reset()
+			metricNotificationSendFailures.Reset()
+			c.UpdateMetricNotificationSendAttempt("started")
+			c.UpdateMetricNotificationSendAttempt("started")
+			c.UpdateMetricNotificationSendFailed("started")
+			Expect(testutil.ToFloat64(metricNotificationSendAttempts.WithLabelValues("started"))).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(metricNotificationSendFailures.WithLabelValues("started"))).To(Equal(float64(1)))
+		})
+	})
+
+	Context("When recording node drain outcomes", func() {
+		It("counts each outcome by MachineConfigPool", func() {
+			outcomes := []string{DrainOutcomeSuccess, DrainOutcomeTimeout, DrainOutcomeForceEscalated, DrainOutcomeFailed}
+			for _, outcome := range outcomes {
+				c.UpdateMetricNodeDrainOutcome("worker", outcome, time.Minute)
+			}
+			c.UpdateMetricNodeDrainOutcome("infra", DrainOutcomeSuccess, time.Minute)
+
+			for _, outcome := range outcomes {
+				Expect(testutil.ToFloat64(metricNodeDrainOutcomes.WithLabelValues("worker", outcome))).To(Equal(float64(1)))
+			}
+			Expect(testutil.ToFloat64(metricNodeDrainOutcomes.WithLabelValues("infra", DrainOutcomeSuccess))).To(Equal(float64(1)))
+		})
+
+		It("observes the drain duration", func() {
+			c.UpdateMetricNodeDrainOutcome("worker", DrainOutcomeSuccess, 90*time.Second)
+
+			expected := `
+# HELP upgradeoperator_node_drain_duration_seconds Time taken to drain a node, from its cordon until the drain outcome is known.
+# TYPE upgradeoperator_node_drain_duration_seconds histogram
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="60"} 0
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="300"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="600"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="1200"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="1800"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="2700"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="3600"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="7200"} 1
+upgradeoperator_node_drain_duration_seconds_bucket{machineconfigpool="worker",le="+Inf"} 1
+upgradeoperator_node_drain_duration_seconds_sum{machineconfigpool="worker"} 90
+upgradeoperator_node_drain_duration_seconds_count{machineconfigpool="worker"} 1
+`
+			Expect(testutil.CollectAndCompare(metricNodeDrainDuration, strings.NewReader(expected))).To(Succeed())
+		})
+	})
+})
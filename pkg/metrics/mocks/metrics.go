@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	metrics "github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	reflect "reflect"
+	time "time"
 )
 
 // MockMetrics is a mock of Metrics interface
@@ -165,6 +166,18 @@ func (mr *MockMetricsMockRecorder) ResetMetricUpgradeControlPlaneTimeout(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricUpgradeControlPlaneTimeout", reflect.TypeOf((*MockMetrics)(nil).ResetMetricUpgradeControlPlaneTimeout), arg0, arg1)
 }
 
+// ResetMetricUpgradePhaseDuration mocks base method
+func (m *MockMetrics) ResetMetricUpgradePhaseDuration() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetMetricUpgradePhaseDuration")
+}
+
+// ResetMetricUpgradePhaseDuration indicates an expected call of ResetMetricUpgradePhaseDuration
+func (mr *MockMetricsMockRecorder) ResetMetricUpgradePhaseDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricUpgradePhaseDuration", reflect.TypeOf((*MockMetrics)(nil).ResetMetricUpgradePhaseDuration))
+}
+
 // ResetMetricUpgradeWorkerTimeout mocks base method
 func (m *MockMetrics) ResetMetricUpgradeWorkerTimeout(arg0, arg1 string) {
 	m.ctrl.T.Helper()
@@ -177,6 +190,18 @@ func (mr *MockMetricsMockRecorder) ResetMetricUpgradeWorkerTimeout(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricUpgradeWorkerTimeout", reflect.TypeOf((*MockMetrics)(nil).ResetMetricUpgradeWorkerTimeout), arg0, arg1)
 }
 
+// ResetUpgradeMetrics mocks base method
+func (m *MockMetrics) ResetUpgradeMetrics() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetUpgradeMetrics")
+}
+
+// ResetUpgradeMetrics indicates an expected call of ResetUpgradeMetrics
+func (mr *MockMetricsMockRecorder) ResetUpgradeMetrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUpgradeMetrics", reflect.TypeOf((*MockMetrics)(nil).ResetUpgradeMetrics))
+}
+
 // UpdateMetricClusterCheckFailed mocks base method
 func (m *MockMetrics) UpdateMetricClusterCheckFailed(arg0 string) {
 	m.ctrl.T.Helper()
@@ -225,6 +250,18 @@ func (mr *MockMetricsMockRecorder) UpdateMetricClusterVerificationSucceeded(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricClusterVerificationSucceeded", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricClusterVerificationSucceeded), arg0)
 }
 
+// UpdateMetricMaintenanceSilencesActive mocks base method
+func (m *MockMetrics) UpdateMetricMaintenanceSilencesActive(arg0 string, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricMaintenanceSilencesActive", arg0, arg1)
+}
+
+// UpdateMetricMaintenanceSilencesActive indicates an expected call of UpdateMetricMaintenanceSilencesActive
+func (mr *MockMetricsMockRecorder) UpdateMetricMaintenanceSilencesActive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricMaintenanceSilencesActive", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricMaintenanceSilencesActive), arg0, arg1)
+}
+
 // UpdateMetricNodeDrainFailed mocks base method
 func (m *MockMetrics) UpdateMetricNodeDrainFailed(arg0 string) {
 	m.ctrl.T.Helper()
@@ -237,6 +274,30 @@ func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainFailed(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainFailed", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainFailed), arg0)
 }
 
+// UpdateMetricNodeDrainOutcome mocks base method
+func (m *MockMetrics) UpdateMetricNodeDrainOutcome(arg0, arg1 string, arg2 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNodeDrainOutcome", arg0, arg1, arg2)
+}
+
+// UpdateMetricNodeDrainOutcome indicates an expected call of UpdateMetricNodeDrainOutcome
+func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainOutcome(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainOutcome", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainOutcome), arg0, arg1, arg2)
+}
+
+// UpdateMetricNodeDrainPDBForced mocks base method
+func (m *MockMetrics) UpdateMetricNodeDrainPDBForced(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNodeDrainPDBForced", arg0)
+}
+
+// UpdateMetricNodeDrainPDBForced indicates an expected call of UpdateMetricNodeDrainPDBForced
+func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainPDBForced(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainPDBForced", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainPDBForced), arg0)
+}
+
 // UpdateMetricNotificationEventSent mocks base method
 func (m *MockMetrics) UpdateMetricNotificationEventSent(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
@@ -249,6 +310,30 @@ func (mr *MockMetricsMockRecorder) UpdateMetricNotificationEventSent(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationEventSent", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationEventSent), arg0, arg1, arg2)
 }
 
+// UpdateMetricNotificationSendAttempt mocks base method
+func (m *MockMetrics) UpdateMetricNotificationSendAttempt(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNotificationSendAttempt", arg0)
+}
+
+// UpdateMetricNotificationSendAttempt indicates an expected call of UpdateMetricNotificationSendAttempt
+func (mr *MockMetricsMockRecorder) UpdateMetricNotificationSendAttempt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationSendAttempt", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationSendAttempt), arg0)
+}
+
+// UpdateMetricNotificationSendFailed mocks base method
+func (m *MockMetrics) UpdateMetricNotificationSendFailed(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNotificationSendFailed", arg0)
+}
+
+// UpdateMetricNotificationSendFailed indicates an expected call of UpdateMetricNotificationSendFailed
+func (mr *MockMetricsMockRecorder) UpdateMetricNotificationSendFailed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationSendFailed", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationSendFailed), arg0)
+}
+
 // UpdateMetricScalingFailed mocks base method
 func (m *MockMetrics) UpdateMetricScalingFailed(arg0 string) {
 	m.ctrl.T.Helper()
@@ -297,6 +382,54 @@ func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeControlPlaneTimeout(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeControlPlaneTimeout", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeControlPlaneTimeout), arg0, arg1)
 }
 
+// UpdateMetricUpgradeDuration mocks base method
+func (m *MockMetrics) UpdateMetricUpgradeDuration(arg0, arg1 string, arg2 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricUpgradeDuration", arg0, arg1, arg2)
+}
+
+// UpdateMetricUpgradeDuration indicates an expected call of UpdateMetricUpgradeDuration
+func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeDuration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeDuration", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeDuration), arg0, arg1, arg2)
+}
+
+// UpdateMetricUpgradeFailed mocks base method
+func (m *MockMetrics) UpdateMetricUpgradeFailed(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricUpgradeFailed", arg0, arg1)
+}
+
+// UpdateMetricUpgradeFailed indicates an expected call of UpdateMetricUpgradeFailed
+func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeFailed", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeFailed), arg0, arg1)
+}
+
+// UpdateMetricUpgradePhaseDuration mocks base method
+func (m *MockMetrics) UpdateMetricUpgradePhaseDuration(arg0, arg1 string, arg2 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricUpgradePhaseDuration", arg0, arg1, arg2)
+}
+
+// UpdateMetricUpgradePhaseDuration indicates an expected call of UpdateMetricUpgradePhaseDuration
+func (mr *MockMetricsMockRecorder) UpdateMetricUpgradePhaseDuration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradePhaseDuration", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradePhaseDuration), arg0, arg1, arg2)
+}
+
+// UpdateMetricUpgradeStartedInWindow mocks base method
+func (m *MockMetrics) UpdateMetricUpgradeStartedInWindow(arg0 string, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricUpgradeStartedInWindow", arg0, arg1)
+}
+
+// UpdateMetricUpgradeStartedInWindow indicates an expected call of UpdateMetricUpgradeStartedInWindow
+func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeStartedInWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeStartedInWindow", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeStartedInWindow), arg0, arg1)
+}
+
 // UpdateMetricUpgradeWindowBreached mocks base method
 func (m *MockMetrics) UpdateMetricUpgradeWindowBreached(arg0 string) {
 	m.ctrl.T.Helper()
@@ -309,6 +442,18 @@ func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeWindowBreached(arg0 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeWindowBreached", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeWindowBreached), arg0)
 }
 
+// UpdateMetricUpgradeWindowExceeded mocks base method
+func (m *MockMetrics) UpdateMetricUpgradeWindowExceeded(arg0 string, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricUpgradeWindowExceeded", arg0, arg1)
+}
+
+// UpdateMetricUpgradeWindowExceeded indicates an expected call of UpdateMetricUpgradeWindowExceeded
+func (mr *MockMetricsMockRecorder) UpdateMetricUpgradeWindowExceeded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricUpgradeWindowExceeded", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricUpgradeWindowExceeded), arg0, arg1)
+}
+
 // UpdateMetricUpgradeWindowNotBreached mocks base method
 func (m *MockMetrics) UpdateMetricUpgradeWindowNotBreached(arg0 string) {
 	m.ctrl.T.Helper()
@@ -3,8 +3,11 @@ package notifier
 import "strings"
 
 const (
-	OCM   ConfigManagerSource = "OCM"
-	LOCAL ConfigManagerSource = "LOCAL"
+	OCM       ConfigManagerSource = "OCM"
+	LOCAL     ConfigManagerSource = "LOCAL"
+	WEBHOOK   ConfigManagerSource = "WEBHOOK"
+	CONFIGMAP ConfigManagerSource = "CONFIGMAP"
+	FILE      ConfigManagerSource = "FILE"
 )
 
 type ConfigManagerSource string
@@ -28,6 +31,10 @@ func (cfg *NotifierConfig) IsValid() error {
 		return nil
 	case string(LOCAL):
 		return nil
+	case string(WEBHOOK):
+		return nil
+	case string(CONFIGMAP), string(FILE):
+		return nil
 	default:
 		return ErrNoNotifierConfigured
 	}
@@ -1,6 +1,10 @@
 package notifier
 
-import logf "sigs.k8s.io/controller-runtime/pkg/log"
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
 
 func NewLogNotifier() (*logNotifier, error) {
 	return &logNotifier{}, nil
@@ -11,7 +15,7 @@ type logNotifier struct {}
 
 var log = logf.Log.WithName("event-notifier")
 
-func (s *logNotifier) NotifyState(value NotifyState, description string) error {
+func (s *logNotifier) NotifyState(uc *upgradev1alpha1.UpgradeConfig, value NotifyState, description string) error {
 	log.Info("Upgrade-State:%s Description:%s", string(value), description)
 	return nil
 }
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	reflect "reflect"
 )
@@ -34,15 +35,15 @@ func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
 }
 
 // NotifyState mocks base method
-func (m *MockNotifier) NotifyState(arg0 notifier.NotifyState, arg1 string) error {
+func (m *MockNotifier) NotifyState(arg0 *v1alpha1.UpgradeConfig, arg1 notifier.NotifyState, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NotifyState", arg0, arg1)
+	ret := m.ctrl.Call(m, "NotifyState", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // NotifyState indicates an expected call of NotifyState
-func (mr *MockNotifierMockRecorder) NotifyState(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockNotifierMockRecorder) NotifyState(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyState", reflect.TypeOf((*MockNotifier)(nil).NotifyState), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyState", reflect.TypeOf((*MockNotifier)(nil).NotifyState), arg0, arg1, arg2)
 }
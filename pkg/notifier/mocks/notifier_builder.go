@@ -8,7 +8,6 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	configmanager "github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
-	upgradeconfigmanager "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	reflect "reflect"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,16 +36,16 @@ func (m *MockNotifierBuilder) EXPECT() *MockNotifierBuilderMockRecorder {
 }
 
 // New mocks base method
-func (m *MockNotifierBuilder) New(arg0 client.Client, arg1 configmanager.ConfigManagerBuilder, arg2 upgradeconfigmanager.UpgradeConfigManagerBuilder) (notifier.Notifier, error) {
+func (m *MockNotifierBuilder) New(arg0 client.Client, arg1 configmanager.ConfigManagerBuilder) (notifier.Notifier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "New", arg0, arg1)
 	ret0, _ := ret[0].(notifier.Notifier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // New indicates an expected call of New
-func (mr *MockNotifierBuilderMockRecorder) New(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockNotifierBuilderMockRecorder) New(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockNotifierBuilder)(nil).New), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockNotifierBuilder)(nil).New), arg0, arg1)
 }
@@ -6,30 +6,32 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
-	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	"github.com/openshift/managed-upgrade-operator/util"
 )
 
 //go:generate mockgen -destination=mocks/notifier.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier Notifier
 type Notifier interface {
-	NotifyState(value NotifyState, description string) error
+	// NotifyState notifies of the state of the upgrade the UpgradeConfig describes
+	NotifyState(uc *upgradev1alpha1.UpgradeConfig, value NotifyState, description string) error
 }
 
 //go:generate mockgen -destination=mocks/notifier_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotifierBuilder
 type NotifierBuilder interface {
-	New(client.Client, configmanager.ConfigManagerBuilder, upgradeconfigmanager.UpgradeConfigManagerBuilder) (Notifier, error)
+	New(client.Client, configmanager.ConfigManagerBuilder) (Notifier, error)
 }
 
 // Represents valid notify states that can be reported
 const (
-	StatePending   NotifyState = "pending"
-	StateStarted   NotifyState = "started"
-	StateCompleted NotifyState = "completed"
-	StateDelayed   NotifyState = "delayed"
-	StateFailed    NotifyState = "failed"
-	StateCancelled NotifyState = "cancelled"
-	StateScheduled NotifyState = "scheduled"
+	StatePending        NotifyState = "pending"
+	StateStarted        NotifyState = "started"
+	StateWorkersStarted NotifyState = "workers_started"
+	StateCompleted      NotifyState = "completed"
+	StateDelayed        NotifyState = "delayed"
+	StateFailed         NotifyState = "failed"
+	StateCancelled      NotifyState = "cancelled"
+	StateScheduled      NotifyState = "scheduled"
 )
 
 type NotifyState string
@@ -47,25 +49,29 @@ func NewBuilder() NotifierBuilder {
 type notifierBuilder struct{}
 
 // Creates a new Notifier instance
-func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.ConfigManagerBuilder, upgradeConfigManagerBuilder upgradeconfigmanager.UpgradeConfigManagerBuilder) (Notifier, error) {
+func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.ConfigManagerBuilder) (Notifier, error) {
 	cfg, err := readNotifierConfig(client, cfgBuilder)
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialise upgrade config manager
-	upgradeConfigManager, err := upgradeConfigManagerBuilder.NewManager(client)
-	if err != nil {
-		return nil, err
-	}
-
 	switch strings.ToUpper(cfg.ConfigManager.Source) {
 	case "OCM":
 		cfg, err := readOcmNotifierConfig(client, cfgBuilder)
 		if err != nil {
 			return nil, err
 		}
-		mgr, err := NewOCMNotifier(client, cfg.GetOCMBaseURL(), upgradeConfigManager)
+		mgr, err := NewOCMNotifier(client, cfg.GetOCMBaseURL())
+		if err != nil {
+			return nil, err
+		}
+		return mgr, nil
+	case "WEBHOOK":
+		cfg, err := readWebhookNotifierConfig(client, cfgBuilder)
+		if err != nil {
+			return nil, err
+		}
+		mgr, err := NewWebhookNotifier(client, cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -107,3 +113,18 @@ func readOcmNotifierConfig(client client.Client, cfb configmanager.ConfigManager
 	}
 	return cfg, cfg.IsValid()
 }
+
+// Read webhook provider configuration
+func readWebhookNotifierConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*WebhookNotifierConfig, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	cfm := cfb.New(client, ns)
+	cfg := &WebhookNotifierConfig{}
+	err = cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.IsValid()
+}
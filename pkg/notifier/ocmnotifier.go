@@ -6,19 +6,18 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/ocm"
-	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 )
 
-func NewOCMNotifier(client client.Client, ocmBaseUrl *url.URL, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager) (*ocmNotifier, error) {
+func NewOCMNotifier(client client.Client, ocmBaseUrl *url.URL) (*ocmNotifier, error) {
 	ocmClient, err := ocm.NewBuilder().New(client, ocmBaseUrl)
 	if err != nil {
 		return nil, err
 	}
 	return &ocmNotifier{
-		client:               client,
-		ocmClient:            ocmClient,
-		upgradeConfigManager: upgradeConfigManager,
+		client:    client,
+		ocmClient: ocmClient,
 	}, nil
 }
 
@@ -27,18 +26,20 @@ type ocmNotifier struct {
 	client client.Client
 	// OCM client
 	ocmClient ocm.OcmClient
-	// Retrieves the upgrade config from the cluster
-	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
 }
 
-func (s *ocmNotifier) NotifyState(value NotifyState, description string) error {
+func (s *ocmNotifier) NotifyState(uc *upgradev1alpha1.UpgradeConfig, value NotifyState, description string) error {
+	// Upgrade policies have no state for the upgrade of the workers, so it is not reported to OCM
+	if value == StateWorkersStarted {
+		return nil
+	}
 
 	cluster, err := s.ocmClient.GetCluster()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve internal ocm cluster ID: %v", err)
 	}
 
-	policyId, err := s.getPolicyIdForUpgradeConfig(cluster.Id)
+	policyId, err := s.getPolicyIdForUpgradeConfig(uc, cluster.Id)
 	if err != nil {
 		return fmt.Errorf("can't determine policy ID to notify for: %v", err)
 	}
@@ -63,13 +64,7 @@ func (s *ocmNotifier) NotifyState(value NotifyState, description string) error {
 }
 
 // Determines the Cluster Services Upgrade Policy ID corresponding to the UpgradeConfig
-func (s *ocmNotifier) getPolicyIdForUpgradeConfig(clusterId string) (*string, error) {
-	// Get current UC
-	uc, err := s.upgradeConfigManager.Get()
-	if err != nil {
-		return nil, err
-	}
-
+func (s *ocmNotifier) getPolicyIdForUpgradeConfig(uc *upgradev1alpha1.UpgradeConfig, clusterId string) (*string, error) {
 	// Get current policies
 	policies, err := s.ocmClient.GetClusterUpgradePolicies(clusterId)
 	if err != nil {
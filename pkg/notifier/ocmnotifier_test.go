@@ -10,7 +10,6 @@ import (
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	mockOcm "github.com/openshift/managed-upgrade-operator/pkg/ocm/mocks"
-	mockUCMgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 
@@ -38,23 +37,20 @@ const (
 
 var _ = Describe("OCM Notifier", func() {
 	var (
-		mockCtrl                 *gomock.Controller
-		mockKubeClient           *mocks.MockClient
-		mockUpgradeConfigManager *mockUCMgr.MockUpgradeConfigManager
-		mockOcmClient            *mockOcm.MockOcmClient
-		notifier                 *ocmNotifier
-		upgradeConfigName        types.NamespacedName
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockOcmClient     *mockOcm.MockOcmClient
+		notifier          *ocmNotifier
+		upgradeConfigName types.NamespacedName
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
 		mockOcmClient = mockOcm.NewMockOcmClient(mockCtrl)
-		mockUpgradeConfigManager = mockUCMgr.NewMockUpgradeConfigManager(mockCtrl)
 		notifier = &ocmNotifier{
-			client:               mockKubeClient,
-			ocmClient:            mockOcmClient,
-			upgradeConfigManager: mockUpgradeConfigManager,
+			client:    mockKubeClient,
+			ocmClient: mockOcmClient,
 		}
 	})
 
@@ -110,6 +106,16 @@ var _ = Describe("OCM Notifier", func() {
 			_ = os.Setenv("OPERATOR_NAMESPACE", ns)
 		})
 
+		Context("When notifying that the workers have started upgrading", func() {
+			It("does not notify OCM", func() {
+				uc := *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+				mockOcmClient.EXPECT().GetCluster().Times(0)
+				mockOcmClient.EXPECT().SetState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				err := notifier.NotifyState(&uc, StateWorkersStarted, TEST_STATE_DESCRIPTION)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("When an associated policy ID can't be found", func() {
 			var uc upgradev1alpha1.UpgradeConfig
 			BeforeEach(func() {
@@ -121,10 +127,9 @@ var _ = Describe("OCM Notifier", func() {
 			It("returns an error", func() {
 				gomock.InOrder(
 					mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockOcmClient.EXPECT().GetClusterUpgradePolicies(cluster.Id).Return(nil, fmt.Errorf("fake error")),
 				)
-				err := notifier.NotifyState(TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
+				err := notifier.NotifyState(&uc, TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(ContainSubstring("can't determine policy ID"))
 			})
@@ -141,10 +146,9 @@ var _ = Describe("OCM Notifier", func() {
 			It("returns an error", func() {
 				gomock.InOrder(
 					mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
 				)
-				err := notifier.NotifyState(TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
+				err := notifier.NotifyState(&uc, TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(ContainSubstring("can't determine policy ID"))
 			})
@@ -161,10 +165,9 @@ var _ = Describe("OCM Notifier", func() {
 			It("returns an error", func() {
 				gomock.InOrder(
 					mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
-					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
 				)
-				err := notifier.NotifyState(TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
+				err := notifier.NotifyState(&uc, TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(ContainSubstring("can't determine policy ID"))
 			})
@@ -182,11 +185,10 @@ var _ = Describe("OCM Notifier", func() {
 				It("does not send a notification", func() {
 					gomock.InOrder(
 						mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
-						mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 						mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
 						mockOcmClient.EXPECT().GetClusterUpgradePolicyState(TEST_POLICY_ID, TEST_CLUSTER_ID).Return(&upgradePolicyState, nil),
 					)
-					err := notifier.NotifyState(TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
+					err := notifier.NotifyState(&uc, TEST_STATE_VALUE, TEST_STATE_DESCRIPTION)
 					Expect(err).To(BeNil())
 				})
 			})
@@ -201,12 +203,11 @@ var _ = Describe("OCM Notifier", func() {
 				It("sends a notification", func() {
 					gomock.InOrder(
 						mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
-						mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 						mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
 						mockOcmClient.EXPECT().GetClusterUpgradePolicyState(TEST_POLICY_ID, TEST_CLUSTER_ID).Return(&upgradePolicyState, nil),
 						mockOcmClient.EXPECT().SetState(string(StateCompleted), TEST_STATE_DESCRIPTION, TEST_POLICY_ID, TEST_CLUSTER_ID),
 					)
-					err := notifier.NotifyState(StateCompleted, TEST_STATE_DESCRIPTION)
+					err := notifier.NotifyState(&uc, StateCompleted, TEST_STATE_DESCRIPTION)
 					Expect(err).To(BeNil())
 				})
 			})
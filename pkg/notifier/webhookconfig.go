@@ -0,0 +1,89 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookURLSecretKey = "url"
+	// defaultWebhookPayload is understood by Slack incoming webhooks
+	defaultWebhookPayload = `{"text": {{ printf "Upgrade of cluster %s to version %s is %s: %s" .ClusterID .Version .State .Description | json }}}`
+)
+
+type WebhookNotifierConfig struct {
+	Webhook WebhookNotifierSettings `yaml:"webhookNotifier"`
+}
+
+type WebhookNotifierSettings struct {
+	// URL is the webhook to POST notifications to
+	URL string `yaml:"url"`
+	// URLSecretRef references a secret in the operator namespace holding the webhook URL,
+	// for webhooks such as Slack's whose URL is itself a credential
+	URLSecretRef *SecretKeyRef `yaml:"urlSecretRef"`
+	// Timeout is the number of seconds to wait for the webhook to respond
+	Timeout int `yaml:"timeout" default:"10"`
+	// Payload is a template of the JSON body to POST
+	Payload string `yaml:"payload"`
+}
+
+type SecretKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key" default:"url"`
+}
+
+func (cfg *WebhookNotifierConfig) IsValid() error {
+	wh := cfg.Webhook
+	if wh.URL == "" && wh.URLSecretRef == nil {
+		return fmt.Errorf("webhook notifier requires a url or urlSecretRef")
+	}
+	if wh.URL != "" && wh.URLSecretRef != nil {
+		return fmt.Errorf("webhook notifier url and urlSecretRef are mutually exclusive")
+	}
+	if wh.URL != "" {
+		if err := validateWebhookURL(wh.URL); err != nil {
+			return err
+		}
+	}
+	if wh.URLSecretRef != nil && wh.URLSecretRef.Name == "" {
+		return fmt.Errorf("webhook notifier urlSecretRef name is required")
+	}
+	if wh.Timeout < 0 {
+		return fmt.Errorf("webhook notifier timeout is invalid")
+	}
+	if _, err := parsePayloadTemplate(cfg.GetPayload()); err != nil {
+		return fmt.Errorf("webhook notifier payload is not a valid template: %v", err)
+	}
+	return nil
+}
+
+func (cfg *WebhookNotifierConfig) GetTimeoutDuration() time.Duration {
+	if cfg.Webhook.Timeout == 0 {
+		return defaultWebhookTimeout
+	}
+	return time.Duration(cfg.Webhook.Timeout) * time.Second
+}
+
+func (cfg *WebhookNotifierConfig) GetPayload() string {
+	if cfg.Webhook.Payload == "" {
+		return defaultWebhookPayload
+	}
+	return cfg.Webhook.Payload
+}
+
+func (ref *SecretKeyRef) GetKey() string {
+	if ref.Key == "" {
+		return defaultWebhookURLSecretKey
+	}
+	return ref.Key
+}
+
+func validateWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook notifier url is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// webhookPayloadData is the data available to the webhook payload template
+type webhookPayloadData struct {
+	ClusterID   string
+	Name        string
+	Version     string
+	State       string
+	Description string
+}
+
+func NewWebhookNotifier(client client.Client, cfg *WebhookNotifierConfig) (*webhookNotifier, error) {
+	webhookURL, err := resolveWebhookURL(client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parsePayloadTemplate(cfg.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	return &webhookNotifier{
+		url:        webhookURL,
+		payload:    payload,
+		httpClient: &http.Client{Timeout: cfg.GetTimeoutDuration()},
+		cvClient:   clusterversion.NewCVClient(client),
+	}, nil
+}
+
+// A notifier that POSTs each state transition to a webhook, such as a Slack incoming webhook
+type webhookNotifier struct {
+	// URL the notifications are POSTed to
+	url string
+	// Template of the body of each notification
+	payload *template.Template
+	// Client used to call the webhook
+	httpClient *http.Client
+	// Retrieves the cluster's identity
+	cvClient clusterversion.ClusterVersion
+}
+
+func (s *webhookNotifier) NotifyState(uc *upgradev1alpha1.UpgradeConfig, value NotifyState, description string) error {
+	cv, err := s.cvClient.GetClusterVersion()
+	if err != nil {
+		return fmt.Errorf("can't determine the cluster to notify for: %v", err)
+	}
+
+	body := &bytes.Buffer{}
+	err = s.payload.Execute(body, webhookPayloadData{
+		ClusterID:   string(cv.Spec.ClusterID),
+		Name:        uc.Name,
+		Version:     uc.Spec.Desired.Version,
+		State:       string(value),
+		Description: description,
+	})
+	if err != nil {
+		return fmt.Errorf("can't render notification payload: %v", err)
+	}
+
+	resp, err := s.httpClient.Post(s.url, "application/json", body)
+	if err != nil {
+		return fmt.Errorf("can't send notification: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook rejected the notification with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// parsePayloadTemplate parses a payload template, which may quote values as JSON strings
+// with the json function
+func parsePayloadTemplate(payload string) (*template.Template, error) {
+	return template.New("payload").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(payload)
+}
+
+// resolveWebhookURL returns the configured webhook URL, reading it from its secret if
+// it is not configured directly
+func resolveWebhookURL(c client.Client, cfg *WebhookNotifierConfig) (string, error) {
+	ref := cfg.Webhook.URLSecretRef
+	if ref == nil {
+		return cfg.Webhook.URL, nil
+	}
+
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return "", err
+	}
+	secret := &corev1.Secret{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: ref.Name}, secret)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch webhook secret %s: %v", ref.Name, err)
+	}
+	webhookURL, ok := secret.Data[ref.GetKey()]
+	if !ok {
+		return "", fmt.Errorf("webhook secret %s has no key %s", ref.Name, ref.GetKey())
+	}
+	if err := validateWebhookURL(string(webhookURL)); err != nil {
+		return "", err
+	}
+	return string(webhookURL), nil
+}
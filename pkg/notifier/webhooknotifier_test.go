@@ -0,0 +1,127 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook Notifier", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		mockCVClient   *cvMocks.MockClusterVersion
+		server         *httptest.Server
+		status         int
+		received       []byte
+		uc             upgradev1alpha1.UpgradeConfig
+		cv             configv1.ClusterVersion
+		cfg            *WebhookNotifierConfig
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		status = http.StatusOK
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(status)
+		}))
+		uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "test-upgradeconfig", Namespace: TEST_OPERATOR_NAMESPACE}).GetUpgradeConfig()
+		uc.Spec.Desired.Version = TEST_UPGRADEPOLICY_VERSION
+		cv = configv1.ClusterVersion{Spec: configv1.ClusterVersionSpec{ClusterID: TEST_CLUSTER_ID}}
+		cfg = &WebhookNotifierConfig{Webhook: WebhookNotifierSettings{URL: server.URL}}
+		_ = os.Setenv("OPERATOR_NAMESPACE", TEST_OPERATOR_NAMESPACE)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	newNotifier := func() *webhookNotifier {
+		wn, err := NewWebhookNotifier(mockKubeClient, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		wn.cvClient = mockCVClient
+		return wn
+	}
+
+	Context("When notifying a state", func() {
+		It("POSTs the default payload to the webhook", func() {
+			mockCVClient.EXPECT().GetClusterVersion().Return(&cv, nil)
+			err := newNotifier().NotifyState(&uc, StateStarted, "a \"quoted\" description")
+			Expect(err).NotTo(HaveOccurred())
+			payload := map[string]string{}
+			Expect(json.Unmarshal(received, &payload)).To(Succeed())
+			Expect(payload["text"]).To(ContainSubstring(TEST_CLUSTER_ID))
+			Expect(payload["text"]).To(ContainSubstring(TEST_UPGRADEPOLICY_VERSION))
+			Expect(payload["text"]).To(ContainSubstring(string(StateStarted)))
+			Expect(payload["text"]).To(ContainSubstring("a \"quoted\" description"))
+		})
+
+		It("renders a configured payload", func() {
+			cfg.Webhook.Payload = `{"cluster": {{ json .ClusterID }}, "version": {{ json .Version }}, "state": {{ json .State }}}`
+			mockCVClient.EXPECT().GetClusterVersion().Return(&cv, nil)
+			err := newNotifier().NotifyState(&uc, StateCompleted, TEST_STATE_DESCRIPTION)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(MatchJSON(`{"cluster": "` + TEST_CLUSTER_ID + `", "version": "` + TEST_UPGRADEPOLICY_VERSION + `", "state": "completed"}`))
+		})
+
+		It("returns an error if the webhook does not accept the notification", func() {
+			status = http.StatusForbidden
+			mockCVClient.EXPECT().GetClusterVersion().Return(&cv, nil)
+			err := newNotifier().NotifyState(&uc, StateStarted, TEST_STATE_DESCRIPTION)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("403"))
+		})
+	})
+
+	Context("When the webhook URL is sourced from a secret", func() {
+		BeforeEach(func() {
+			cfg.Webhook.URL = ""
+			cfg.Webhook.URLSecretRef = &SecretKeyRef{Name: "slack-webhook"}
+		})
+
+		It("reads the URL from the secret", func() {
+			secret := corev1.Secret{Data: map[string][]byte{"url": []byte(server.URL)}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: TEST_OPERATOR_NAMESPACE, Name: "slack-webhook"}, gomock.Any()).SetArg(2, secret)
+			Expect(newNotifier().url).To(Equal(server.URL))
+		})
+
+		It("fails if the secret does not hold the URL", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, corev1.Secret{})
+			_, err := NewWebhookNotifier(mockKubeClient, cfg)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When validating the webhook config", func() {
+		It("requires a URL", func() {
+			Expect((&WebhookNotifierConfig{}).IsValid()).NotTo(Succeed())
+			Expect((&WebhookNotifierConfig{Webhook: WebhookNotifierSettings{URL: "not a url"}}).IsValid()).NotTo(Succeed())
+			Expect(cfg.IsValid()).To(Succeed())
+		})
+
+		It("rejects a payload that is not a valid template", func() {
+			cfg.Webhook.Payload = `{"text": {{ .State }`
+			Expect(cfg.IsValid()).NotTo(Succeed())
+		})
+	})
+})
@@ -35,6 +35,7 @@ func (ocb *ocmClientBuilder) New(c client.Client, ocmBaseUrl *url.URL) (OcmClien
 		client:     c,
 		ocmBaseUrl: ocmBaseUrl,
 		httpClient: httpClient,
+		cache:      defaultResponseCache,
 	}, nil
 
 }
@@ -0,0 +1,91 @@
+package ocm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// A response which may be reused while the server reports the resource as not modified
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// responseCache holds the last response to each request that the server supports conditional
+// requests for
+type responseCache struct {
+	mutex     sync.Mutex
+	responses map[string]cachedResponse
+}
+
+// OCM clients are built for each refresh, so responses are cached for the life of the operator
+var defaultResponseCache = newResponseCache()
+
+func newResponseCache() *responseCache {
+	return &responseCache{responses: map[string]cachedResponse{}}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response, ok := c.responses[key]
+	return response, ok
+}
+
+func (c *responseCache) set(key string, response cachedResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.responses[key] = response
+}
+
+// conditionalGet GETs the URL and unmarshals the response into result. If an earlier response
+// carried an ETag or Last-Modified header the request is made conditional on the resource having
+// changed, and the earlier response is reused if it has not. Servers which do not support
+// conditional requests are sent a full request each time.
+func (s *ocmClient) conditionalGet(reqUrl string, queryParams map[string]string, result interface{}) (*resty.Response, error) {
+	query := url.Values{}
+	for k, v := range queryParams {
+		query.Set(k, v)
+	}
+	key := reqUrl + "?" + query.Encode()
+
+	request := s.httpClient.R().
+		SetQueryParams(queryParams).
+		ExpectContentType("application/json")
+	cached, found := s.cache.get(key)
+	if found {
+		if cached.etag != "" {
+			request.SetHeader("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			request.SetHeader("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	response, err := request.Get(reqUrl)
+	if err != nil {
+		return nil, err
+	}
+	if found && response.StatusCode() == http.StatusNotModified {
+		return response, json.Unmarshal(cached.body, result)
+	}
+	if !response.IsSuccess() {
+		return response, nil
+	}
+
+	err = json.Unmarshal(response.Body(), result)
+	if err != nil {
+		return response, err
+	}
+	etag := response.Header().Get("ETag")
+	lastModified := response.Header().Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		s.cache.set(key, cachedResponse{etag: etag, lastModified: lastModified, body: response.Body()})
+	}
+	return response, nil
+}
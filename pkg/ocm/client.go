@@ -46,6 +46,8 @@ type ocmClient struct {
 	ocmBaseUrl *url.URL
 	// HTTP client used for API queries (TODO: remove in favour of OCM SDK)
 	httpClient *resty.Client
+	// Responses that are reused while they are not modified
+	cache *responseCache
 }
 
 type ocmRoundTripper struct {
@@ -78,15 +80,12 @@ func (s *ocmClient) GetCluster() (*ClusterInfo, error) {
 	}
 	csUrl.Path = path.Join(csUrl.Path, CLUSTERS_V1_PATH)
 
-	response, err := s.httpClient.R().
-		SetQueryParams(map[string]string{
-			"page":   "1",
-			"size":   "1",
-			"search": fmt.Sprintf("external_id = '%s'", externalID),
-		}).
-		SetResult(&ClusterList{}).
-		ExpectContentType("application/json").
-		Get(csUrl.String())
+	listResponse := &ClusterList{}
+	response, err := s.conditionalGet(csUrl.String(), map[string]string{
+		"page":   "1",
+		"size":   "1",
+		"search": fmt.Sprintf("external_id = '%s'", externalID),
+	}, listResponse)
 
 	if err != nil {
 		return nil, fmt.Errorf("can't query OCM cluster service: %v", err)
@@ -97,7 +96,6 @@ func (s *ocmClient) GetCluster() (*ClusterInfo, error) {
 		return nil, fmt.Errorf("received error code %v, operation id '%v'", response.StatusCode(), operationId)
 	}
 
-	listResponse := response.Result().(*ClusterList)
 	if listResponse.Size != 1 || len(listResponse.Items) != 1 {
 		return nil, ErrClusterIdNotFound
 	}
@@ -114,10 +112,8 @@ func (s *ocmClient) GetClusterUpgradePolicies(clusterId string) (*UpgradePolicyL
 	}
 	upUrl.Path = path.Join(upUrl.Path, CLUSTERS_V1_PATH, clusterId, UPGRADEPOLICIES_V1_PATH)
 
-	response, err := s.httpClient.R().
-		SetResult(&UpgradePolicyList{}).
-		ExpectContentType("application/json").
-		Get(upUrl.String())
+	upgradeResponse := &UpgradePolicyList{}
+	response, err := s.conditionalGet(upUrl.String(), nil, upgradeResponse)
 
 	if err != nil {
 		return nil, fmt.Errorf("can't send notification: %v", err)
@@ -127,7 +123,6 @@ func (s *ocmClient) GetClusterUpgradePolicies(clusterId string) (*UpgradePolicyL
 		return nil, fmt.Errorf("received error code '%v' from OCM upgrade policy service, operation id '%v'", response.StatusCode(), operationId)
 	}
 
-	upgradeResponse := response.Result().(*UpgradePolicyList)
 	return upgradeResponse, nil
 }
 
@@ -172,10 +167,8 @@ func (s *ocmClient) GetClusterUpgradePolicyState(policyId string, clusterId stri
 	}
 	upUrl.Path = path.Join(upUrl.Path, CLUSTERS_V1_PATH, clusterId, UPGRADEPOLICIES_V1_PATH, policyId, STATE_V1_PATH)
 
-	response, err := s.httpClient.R().
-		SetResult(&UpgradePolicyState{}).
-		ExpectContentType("application/json").
-		Get(upUrl.String())
+	stateResponse := &UpgradePolicyState{}
+	response, err := s.conditionalGet(upUrl.String(), nil, stateResponse)
 
 	if err != nil {
 		return nil, fmt.Errorf("can't send notification: %v", err)
@@ -185,6 +178,5 @@ func (s *ocmClient) GetClusterUpgradePolicyState(policyId string, clusterId stri
 		return nil, fmt.Errorf("received error code '%v' from OCM upgrade policy service, operation id '%v'", response.StatusCode(), operationId)
 	}
 
-	stateResponse := response.Result().(*UpgradePolicyState)
 	return stateResponse, nil
 }
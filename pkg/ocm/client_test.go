@@ -60,6 +60,7 @@ var _ = Describe("OCM Client", func() {
 			client:     mockKubeClient,
 			ocmBaseUrl: ocmServerUrl,
 			httpClient: httpClient,
+			cache:      newResponseCache(),
 		}
 
 		clusterListResponse = ClusterList{
@@ -155,6 +156,63 @@ var _ = Describe("OCM Client", func() {
 		})
 	})
 
+	Context("When getting upgrade policies more than once", func() {
+		var (
+			upUrl       string
+			etag        string
+			conditional []string
+		)
+
+		BeforeEach(func() {
+			upUrl = path.Join(CLUSTERS_V1_PATH, TEST_CLUSTER_ID, UPGRADEPOLICIES_V1_PATH)
+			etag = `"1"`
+			conditional = []string{}
+			httpmock.RegisterResponder(http.MethodGet, upUrl, func(req *http.Request) (*http.Response, error) {
+				ifNoneMatch := req.Header.Get("If-None-Match")
+				conditional = append(conditional, ifNoneMatch)
+				if etag != "" && ifNoneMatch == etag {
+					return httpmock.NewStringResponse(http.StatusNotModified, ""), nil
+				}
+				response, err := httpmock.NewJsonResponse(http.StatusOK, upgradePolicyListResponse)
+				if etag != "" {
+					response.Header.Set("ETag", etag)
+				}
+				return response, err
+			})
+		})
+
+		It("reuses the policies if they have not changed", func() {
+			first, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			second, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			Expect(*second).To(Equal(*first))
+			Expect(conditional).To(Equal([]string{"", `"1"`}))
+		})
+
+		It("returns the new policies if they have changed", func() {
+			_, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			etag = `"2"`
+			upgradePolicyListResponse.Items[0].Version = "4.4.6"
+			result, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			Expect(result.Items[0].Version).To(Equal("4.4.6"))
+			Expect(conditional).To(Equal([]string{"", `"1"`}))
+		})
+
+		It("makes full requests if the server does not support conditional requests", func() {
+			etag = ""
+			_, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			upgradePolicyListResponse.Items[0].Version = "4.4.6"
+			result, err := oc.GetClusterUpgradePolicies(TEST_CLUSTER_ID)
+			Expect(err).To(BeNil())
+			Expect(result.Items[0].Version).To(Equal("4.4.6"))
+			Expect(conditional).To(Equal([]string{"", ""}))
+		})
+	})
+
 	Context("When getting upgrade policy state", func() {
 		It("returns the correct info", func() {
 
@@ -0,0 +1,152 @@
+package scaler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// The ClusterAutoscaler whose resource limits bound the size of the cluster
+	CLUSTER_AUTOSCALER_NAME = "default"
+
+	// The size of a MachineSet's instances, as recorded by the machine API where it knows them
+	ANNOTATION_VCPU      = "machine.openshift.io/vCPU"
+	ANNOTATION_MEMORY_MB = "machine.openshift.io/memoryMb"
+)
+
+var clusterAutoscalerGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.openshift.io",
+	Version: "v1",
+	Kind:    "ClusterAutoscaler",
+}
+
+// CapacityResult reports whether the cluster has the capacity for the extra upgrade nodes
+type CapacityResult struct {
+	// Known is false when no limit on the capacity of the cluster could be determined, as is
+	// the case for cloud quotas, which can not be queried from within the cluster
+	Known bool
+	// Exceeded describes the limits the extra nodes would exceed
+	Exceeded []string
+}
+
+// extraCapacity is the capacity the extra upgrade nodes still to be created will add to the cluster
+type extraCapacity struct {
+	nodes int64
+	cores int64
+	// memory is in GiB, as the ClusterAutoscaler's memory limits are
+	memory int64
+	// sized is false when the size of the instances of any MachineSet is not known
+	sized bool
+}
+
+// CheckCapacity estimates the capacity the extra upgrade nodes need and checks it against the
+// resource limits of the cluster's ClusterAutoscaler, which are the only limits on the capacity
+// of the cluster that can be determined. Extra nodes already created are not counted again.
+func (s *machineSetScaler) CheckCapacity(c client.Client, logger logr.Logger) (*CapacityResult, error) {
+	extra, err := s.extraCapacity(c)
+	if err != nil {
+		return nil, err
+	}
+	if extra.nodes == 0 {
+		return &CapacityResult{Known: true}, nil
+	}
+
+	ca := &unstructured.Unstructured{}
+	ca.SetGroupVersionKind(clusterAutoscalerGVK)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: CLUSTER_AUTOSCALER_NAME}, ca)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return &CapacityResult{Known: false}, nil
+		}
+		return nil, err
+	}
+	maxNodes, nodesLimited, _ := unstructured.NestedInt64(ca.Object, "spec", "resourceLimits", "maxNodesTotal")
+	maxCores, coresLimited, _ := unstructured.NestedInt64(ca.Object, "spec", "resourceLimits", "cores", "max")
+	maxMemory, memoryLimited, _ := unstructured.NestedInt64(ca.Object, "spec", "resourceLimits", "memory", "max")
+	coresLimited = coresLimited && extra.sized
+	memoryLimited = memoryLimited && extra.sized
+	if !nodesLimited && !coresLimited && !memoryLimited {
+		return &CapacityResult{Known: false}, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	err = c.List(context.TODO(), nodes)
+	if err != nil {
+		return nil, err
+	}
+	var cores, memory int64
+	for _, node := range nodes.Items {
+		cores += node.Status.Capacity.Cpu().Value()
+		memory += node.Status.Capacity.Memory().Value() / (1 << 30)
+	}
+
+	result := &CapacityResult{Known: true}
+	if nodesLimited && int64(len(nodes.Items))+extra.nodes > maxNodes {
+		result.Exceeded = append(result.Exceeded, fmt.Sprintf("%d extra nodes would exceed the maximum of %d nodes, with %d already", extra.nodes, maxNodes, len(nodes.Items)))
+	}
+	if coresLimited && cores+extra.cores > maxCores {
+		result.Exceeded = append(result.Exceeded, fmt.Sprintf("%d extra cores would exceed the maximum of %d cores, with %d already", extra.cores, maxCores, cores))
+	}
+	if memoryLimited && memory+extra.memory > maxMemory {
+		result.Exceeded = append(result.Exceeded, fmt.Sprintf("%dGiB extra memory would exceed the maximum of %dGiB, with %dGiB already", extra.memory, maxMemory, memory))
+	}
+	return result, nil
+}
+
+// extraCapacity totals the extra nodes EnsureScaleUpNodes is yet to create and, where the
+// MachineSets record it, the size of their instances
+func (s *machineSetScaler) extraCapacity(c client.Client) (*extraCapacity, error) {
+	upgradeMachinesets := &machineapi.MachineSetList{}
+	err := c.List(context.TODO(), upgradeMachinesets, []client.ListOption{
+		client.InNamespace(MACHINE_API_NAMESPACE),
+		client.MatchingLabels{LABEL_UPGRADE: "true"},
+	}...)
+	if err != nil {
+		return nil, err
+	}
+	originalMachineSets := &machineapi.MachineSetList{}
+	err = c.List(context.TODO(), originalMachineSets, []client.ListOption{
+		client.InNamespace(MACHINE_API_NAMESPACE),
+		client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+	}...)
+	if err != nil {
+		return nil, err
+	}
+	if len(originalMachineSets.Items) == 0 {
+		return nil, fmt.Errorf("failed to get original machineset")
+	}
+
+	created := map[string]bool{}
+	for _, ums := range upgradeMachinesets.Items {
+		created[ums.Name] = true
+	}
+	extra := &extraCapacity{sized: true}
+	extraReplicas := s.extraReplicas(len(originalMachineSets.Items))
+	for i, ms := range originalMachineSets.Items {
+		replicas := int64(extraReplicas[i])
+		if created[ms.Name+"-upgrade"] || replicas == 0 {
+			continue
+		}
+		extra.nodes += replicas
+		vcpu, cpuErr := strconv.ParseInt(ms.Annotations[ANNOTATION_VCPU], 10, 64)
+		memoryMb, memErr := strconv.ParseInt(ms.Annotations[ANNOTATION_MEMORY_MB], 10, 64)
+		if cpuErr != nil || memErr != nil {
+			extra.sized = false
+			continue
+		}
+		extra.cores += replicas * vcpu
+		extra.memory += replicas * memoryMb / 1024
+	}
+	return extra, nil
+}
@@ -0,0 +1,178 @@
+package scaler
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Capacity checks", func() {
+
+	var (
+		logger              logr.Logger
+		mockKubeClient      *mocks.MockClient
+		mockCtrl            *gomock.Controller
+		scaler              *machineSetScaler
+		upgradeMachinesets  machineapi.MachineSetList
+		originalMachineSets machineapi.MachineSetList
+		nodes               corev1.NodeList
+	)
+
+	machineSet := func(name string) machineapi.MachineSet {
+		return machineapi.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: MACHINE_API_NAMESPACE,
+				Annotations: map[string]string{
+					ANNOTATION_VCPU:      "4",
+					ANNOTATION_MEMORY_MB: "16384",
+				},
+			},
+		}
+	}
+	node := func(name string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+			},
+		}
+	}
+	clusterAutoscaler := func(resourceLimits map[string]interface{}) unstructured.Unstructured {
+		ca := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"resourceLimits": resourceLimits},
+		}}
+		ca.SetGroupVersionKind(clusterAutoscalerGVK)
+		ca.SetName(CLUSTER_AUTOSCALER_NAME)
+		return ca
+	}
+	expectMachineSets := func() {
+		gomock.InOrder(
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+				client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
+			}).SetArg(1, upgradeMachinesets),
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+				client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+			}).SetArg(1, originalMachineSets),
+		)
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		scaler = &machineSetScaler{}
+		logger = logf.Log.WithName("capacity test logger")
+		upgradeMachinesets = machineapi.MachineSetList{}
+		originalMachineSets = machineapi.MachineSetList{Items: []machineapi.MachineSet{
+			machineSet("test-worker-a"),
+			machineSet("test-worker-b"),
+		}}
+		nodes = corev1.NodeList{Items: []corev1.Node{node("node-1"), node("node-2"), node("node-3")}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the ClusterAutoscaler limits the cluster", func() {
+		It("has the capacity for the extra nodes within the limits", func() {
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: CLUSTER_AUTOSCALER_NAME}, gomock.Any()).SetArg(2, clusterAutoscaler(map[string]interface{}{
+				"maxNodesTotal": int64(5),
+				"cores":         map[string]interface{}{"min": int64(0), "max": int64(20)},
+				"memory":        map[string]interface{}{"min": int64(0), "max": int64(80)},
+			}))
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, nodes)
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeTrue())
+			Expect(result.Exceeded).To(BeEmpty())
+		})
+
+		It("does not have the capacity for extra nodes beyond the limits", func() {
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterAutoscaler(map[string]interface{}{
+				"maxNodesTotal": int64(4),
+				"cores":         map[string]interface{}{"min": int64(0), "max": int64(16)},
+			}))
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, nodes)
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeTrue())
+			Expect(result.Exceeded).To(ConsistOf(
+				"2 extra nodes would exceed the maximum of 4 nodes, with 3 already",
+				"8 extra cores would exceed the maximum of 16 cores, with 12 already",
+			))
+		})
+
+		It("does not check cores when the size of the instances is not known", func() {
+			delete(originalMachineSets.Items[1].Annotations, ANNOTATION_VCPU)
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterAutoscaler(map[string]interface{}{
+				"cores": map[string]interface{}{"min": int64(0), "max": int64(16)},
+			}))
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeFalse())
+		})
+
+		It("only counts the extra nodes yet to be created", func() {
+			upgradeMachinesets = machineapi.MachineSetList{Items: []machineapi.MachineSet{
+				machineSet("test-worker-a-upgrade"),
+				machineSet("test-worker-b-upgrade"),
+			}}
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeTrue())
+			Expect(result.Exceeded).To(BeEmpty())
+		})
+	})
+
+	Context("When the capacity of the cluster can not be determined", func() {
+		It("reports the capacity as unknown without a ClusterAutoscaler", func() {
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				errors.NewNotFound(schema.GroupResource{Group: clusterAutoscalerGVK.Group, Resource: "clusterautoscalers"}, CLUSTER_AUTOSCALER_NAME))
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeFalse())
+		})
+
+		It("reports the capacity as unknown when the ClusterAutoscaler sets no limits", func() {
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterAutoscaler(map[string]interface{}{}))
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Known).To(BeFalse())
+		})
+
+		It("surfaces other errors getting the ClusterAutoscaler", func() {
+			expectMachineSets()
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			result, err := scaler.CheckCapacity(mockKubeClient, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package scaler
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -22,11 +23,37 @@ const (
 	LABEL_UPGRADE         = "upgrade.managed.openshift.io"
 	LABEL_MACHINESET      = "machine.openshift.io/cluster-api-machineset"
 	MACHINE_API_NAMESPACE = "openshift-machine-api"
+	MACHINE_PHASE_RUNNING = "Running"
+	MACHINE_PHASE_FAILED  = "Failed"
+
+	ANNOTATION_ORIGINAL_MACHINESET = "upgrade.managed.openshift.io/original-machineset"
 )
 
-type machineSetScaler struct{}
+type machineSetScaler struct {
+	// capacityReservation is the total number of extra nodes to add, one per MachineSet when nil
+	capacityReservation *int32
+	// spotInstances requests spot instances for the extra nodes where the platform supports them
+	spotInstances bool
+}
 
-// This will create a new MachineSet with 1 extra replicas for workers in every region and report when the nodes are ready.
+// extraReplicas spreads the capacity reservation over the supplied number of MachineSets, giving
+// any remainder to the first of them
+func (s *machineSetScaler) extraReplicas(machineSets int) []int32 {
+	replicas := make([]int32, machineSets)
+	for i := range replicas {
+		if s.capacityReservation == nil {
+			replicas[i] = 1
+			continue
+		}
+		replicas[i] = *s.capacityReservation / int32(machineSets)
+		if int32(i) < *s.capacityReservation%int32(machineSets) {
+			replicas[i]++
+		}
+	}
+	return replicas
+}
+
+// This will create a new MachineSet with extra replicas for workers in every region, as set by the capacity reservation, and report when the nodes are ready.
 func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Duration, logger logr.Logger) (bool, error) {
 	upgradeMachinesets := &machineapi.MachineSetList{}
 
@@ -53,8 +80,9 @@ func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Dura
 		return false, fmt.Errorf("failed to get original machineset")
 	}
 
+	extraReplicas := s.extraReplicas(len(originalMachineSets.Items))
 	updated := false
-	for _, ms := range originalMachineSets.Items {
+	for i, ms := range originalMachineSets.Items {
 
 		found := false
 		for _, ums := range upgradeMachinesets.Items {
@@ -66,8 +94,11 @@ func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Dura
 			logger.Info(fmt.Sprintf("machineset for upgrade already created :%s", ms.Name))
 			continue
 		}
+		replica := extraReplicas[i]
+		if replica == 0 {
+			continue
+		}
 		updated = true
-		replica := int32(1)
 		newMs := ms.DeepCopy()
 
 		newMs.ObjectMeta = metav1.ObjectMeta{
@@ -76,12 +107,25 @@ func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Dura
 			Labels: map[string]string{
 				LABEL_UPGRADE: "true",
 			},
+			// Record the MachineSet being cloned. It is never changed by the scaler, so that the
+			// changes of the cluster autoscaler or an admin to it during the upgrade are kept.
+			Annotations: map[string]string{
+				ANNOTATION_ORIGINAL_MACHINESET: ms.Name,
+			},
 		}
 		newMs.Spec.Replicas = &replica
 		newMs.Spec.Template.Labels[LABEL_UPGRADE] = "true"
 		newMs.Spec.Template.Labels[LABEL_MACHINESET] = newMs.Name
 		newMs.Spec.Selector.MatchLabels[LABEL_UPGRADE] = "true"
 		newMs.Spec.Selector.MatchLabels[LABEL_MACHINESET] = newMs.Name
+		if s.spotInstances {
+			spot, err := useSpotInstances(&newMs.Spec.Template.Spec.ProviderSpec)
+			if err != nil {
+				logger.Info(fmt.Sprintf("unable to request spot instances for machineset %s, using on-demand instances: %v", newMs.Name, err))
+			} else if !spot {
+				logger.Info(fmt.Sprintf("spot instances are not supported for machineset %s, using on-demand instances", newMs.Name))
+			}
+		}
 		logger.Info(fmt.Sprintf("creating machineset %s for upgrade", newMs.Name))
 
 		err = c.Create(context.TODO(), newMs)
@@ -95,58 +139,96 @@ func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Dura
 		// New machineset created, machines must not ready at the moment, so skip following steps
 		return false, nil
 	}
-	allNodeReady := true
+	stuck := []string{}
+	timedOut := false
 	for _, ms := range upgradeMachinesets.Items {
+		unready, err := unreadyMachines(c, ms)
+		if err != nil {
+			if IsMachineFailedError(err) {
+				logger.Info("extra upgrade machines failed to provision")
+			} else {
+				logger.Error(err, "failed to check extra upgrade machines")
+			}
+			return false, err
+		}
+		if len(unready) == 0 {
+			continue
+		}
+		stuck = append(stuck, unready...)
 		//We assume the create time is the start time for scale up extra compute nodes
-		startTime := ms.CreationTimestamp
-		if ms.Status.Replicas != ms.Status.ReadyReplicas {
+		if time.Now().After(ms.CreationTimestamp.Time.Add(timeOut)) {
+			timedOut = true
+		}
+	}
+	if len(stuck) > 0 {
+		if timedOut {
+			return false, NewScaleTimeOutError(fmt.Sprintf("extra upgrade machines did not become ready within %s: %s", timeOut, strings.Join(stuck, ", ")))
+		}
+		logger.Info(fmt.Sprintf("waiting for extra upgrade machines to become ready: %s", strings.Join(stuck, ", ")))
+		return false, nil
+	}
 
-			if time.Now().After(startTime.Time.Add(timeOut)) {
-				return false, NewScaleTimeOutError(fmt.Sprintf("Machineset %s provisioning timout", ms.Name))
+	return true, nil
+}
+
+// unreadyMachines describes the machines of an upgrade MachineSet that are not yet Running with a Ready node
+func unreadyMachines(c client.Client, ms machineapi.MachineSet) ([]string, error) {
+	machines := &machineapi.MachineList{}
+	err := c.List(context.TODO(), machines, []client.ListOption{
+		client.InNamespace(MACHINE_API_NAMESPACE),
+		client.MatchingLabels{LABEL_UPGRADE: "true"},
+		client.MatchingLabels{LABEL_MACHINESET: ms.Name},
+	}...)
+	if err != nil {
+		return nil, err
+	}
+
+	unready := []string{}
+	failed := []string{}
+	if ms.Spec.Replicas != nil && int32(len(machines.Items)) < *ms.Spec.Replicas {
+		unready = append(unready, fmt.Sprintf("%s (%d of %d machines created)", ms.Name, len(machines.Items), *ms.Spec.Replicas))
+	}
+	for _, machine := range machines.Items {
+		// A Failed machine will not recover, unlike one still Provisioning, so there is no point waiting on it
+		if machine.Status.Phase != nil && *machine.Status.Phase == MACHINE_PHASE_FAILED {
+			reason := "no error reported"
+			if machine.Status.ErrorMessage != nil {
+				reason = *machine.Status.ErrorMessage
 			}
-			logger.Info(fmt.Sprintf("not all machines are ready for machineset:%s", ms.Name))
-			return false, nil
+			failed = append(failed, fmt.Sprintf("%s: %s", machine.Name, reason))
+			continue
 		}
-
-		machines := &machineapi.MachineList{}
-		err := c.List(context.TODO(), machines, []client.ListOption{
-			client.InNamespace(MACHINE_API_NAMESPACE),
-			client.MatchingLabels{LABEL_UPGRADE: "true"},
-			client.MatchingLabels{LABEL_MACHINESET: ms.Name},
-		}...)
-		if err != nil || len(machines.Items) != 1 {
-			logger.Error(err, "failed to list extra upgrade machine")
-			return false, err
+		if machine.Status.Phase == nil || *machine.Status.Phase != MACHINE_PHASE_RUNNING {
+			phase := "Pending"
+			if machine.Status.Phase != nil {
+				phase = *machine.Status.Phase
+			}
+			unready = append(unready, fmt.Sprintf("%s (phase %s)", machine.Name, phase))
+			continue
+		}
+		if machine.Status.NodeRef == nil {
+			unready = append(unready, fmt.Sprintf("%s (no node)", machine.Name))
+			continue
 		}
-
-		machine := machines.Items[0]
 		node := &corev1.Node{}
 		err = c.Get(context.TODO(), types.NamespacedName{Name: machine.Status.NodeRef.Name}, node)
 		if err != nil {
-			logger.Error(err, "failed to get node")
-			return false, err
+			return nil, err
 		}
 		nodeReady := false
-		var nodeName string
 		for _, con := range node.Status.Conditions {
 			if con.Type == corev1.NodeReady && con.Status == corev1.ConditionTrue {
 				nodeReady = true
-				nodeName = node.Name
 			}
 		}
 		if !nodeReady {
-			allNodeReady = false
-			if time.Now().After(startTime.Time.Add(timeOut)) {
-				logger.Info("node is not ready within timeout time")
-				return false, NewScaleTimeOutError(fmt.Sprintf("Timeout waiting for node:%s to become ready", nodeName))
-			}
+			unready = append(unready, fmt.Sprintf("%s (node %s not Ready)", machine.Name, machine.Status.NodeRef.Name))
 		}
 	}
-	if !allNodeReady {
-		return false, nil
+	if len(failed) > 0 {
+		return nil, NewMachineFailedError(fmt.Sprintf("extra upgrade machines failed: %s", strings.Join(failed, "; ")))
 	}
-
-	return allNodeReady, nil
+	return unready, nil
 }
 
 // This will remove extra MachineSets and report when the nodes are removed.
@@ -8,6 +8,7 @@ import (
 	logr "github.com/go-logr/logr"
 	gomock "github.com/golang/mock/gomock"
 	drain "github.com/openshift/managed-upgrade-operator/pkg/drain"
+	scaler "github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	reflect "reflect"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 	time "time"
@@ -36,6 +37,21 @@ func (m *MockScaler) EXPECT() *MockScalerMockRecorder {
 	return m.recorder
 }
 
+// CheckCapacity mocks base method
+func (m *MockScaler) CheckCapacity(arg0 client.Client, arg1 logr.Logger) (*scaler.CapacityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCapacity", arg0, arg1)
+	ret0, _ := ret[0].(*scaler.CapacityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCapacity indicates an expected call of CheckCapacity
+func (mr *MockScalerMockRecorder) CheckCapacity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCapacity", reflect.TypeOf((*MockScaler)(nil).CheckCapacity), arg0, arg1)
+}
+
 // EnsureScaleDownNodes mocks base method
 func (m *MockScaler) EnsureScaleDownNodes(arg0 client.Client, arg1 drain.NodeDrainStrategy, arg2 logr.Logger) (bool, error) {
 	m.ctrl.T.Helper()
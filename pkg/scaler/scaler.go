@@ -12,10 +12,34 @@ import (
 type Scaler interface {
 	EnsureScaleUpNodes(client.Client, time.Duration, logr.Logger) (bool, error)
 	EnsureScaleDownNodes(client.Client, drain.NodeDrainStrategy, logr.Logger) (bool, error)
+	CheckCapacity(client.Client, logr.Logger) (*CapacityResult, error)
 }
 
-func NewScaler() Scaler {
-	return &machineSetScaler{}
+// Option configures the Scaler returned by NewScaler
+type Option func(*machineSetScaler)
+
+// WithCapacityReservation sets the total number of extra worker nodes added for the upgrade,
+// spread across the worker MachineSets, in place of one extra node per MachineSet
+func WithCapacityReservation(nodes int32) Option {
+	return func(s *machineSetScaler) {
+		s.capacityReservation = &nodes
+	}
+}
+
+// WithSpotInstances requests spot or preemptible instances for the extra worker nodes on the
+// platforms that support them
+func WithSpotInstances() Option {
+	return func(s *machineSetScaler) {
+		s.spotInstances = true
+	}
+}
+
+func NewScaler(opts ...Option) Scaler {
+	s := &machineSetScaler{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 type scaleTimeOutError struct {
@@ -35,6 +59,24 @@ func NewScaleTimeOutError(msg string) *scaleTimeOutError {
 	return &scaleTimeOutError{message: msg}
 }
 
+type machineFailedError struct {
+	message string
+}
+
+func (mfErr *machineFailedError) Error() string {
+	return mfErr.message
+}
+
+// IsMachineFailedError reports whether scaling failed because an extra machine could not be provisioned
+func IsMachineFailedError(err error) bool {
+	_, ok := err.(*machineFailedError)
+	return ok
+}
+
+func NewMachineFailedError(msg string) *machineFailedError {
+	return &machineFailedError{message: msg}
+}
+
 type drainTimeOutError struct {
 	nodeName string
 }
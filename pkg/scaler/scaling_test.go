@@ -2,7 +2,9 @@ package scaler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,6 +13,7 @@ import (
 	"github.com/golang/mock/gomock"
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -30,6 +33,7 @@ var _ = Describe("Node scaling tests", func() {
 		mockKubeClient *mocks.MockClient
 		mockCtrl       *gomock.Controller
 		scaler         Scaler
+		runningPhase   = MACHINE_PHASE_RUNNING
 	)
 
 	BeforeEach(func() {
@@ -163,6 +167,132 @@ var _ = Describe("Node scaling tests", func() {
 			})
 		})
 
+		Context("When a capacity reservation is configured", func() {
+			BeforeEach(func() {
+				upgradeMachinesets = &machineapi.MachineSetList{}
+				originalMachineSets = &machineapi.MachineSetList{}
+				for _, name := range []string{"test-worker-a", "test-worker-b"} {
+					originalMachineSets.Items = append(originalMachineSets.Items, machineapi.MachineSet{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      name,
+							Namespace: MACHINE_API_NAMESPACE,
+						},
+						Spec: machineapi.MachineSetSpec{
+							Selector: metav1.LabelSelector{MatchLabels: make(map[string]string)},
+							Template: machineapi.MachineTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{Labels: make(map[string]string)},
+							},
+						},
+					})
+				}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+					}).SetArg(1, *originalMachineSets),
+				)
+			})
+			It("spreads the reserved nodes across the machinesets", func() {
+				scaler = NewScaler(WithCapacityReservation(3))
+				replicas := map[string]int32{}
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+					func(ctx context.Context, ms *machineapi.MachineSet) error {
+						replicas[ms.Name] = *ms.Spec.Replicas
+						Expect(ms.Annotations[ANNOTATION_ORIGINAL_MACHINESET]).To(Equal(strings.TrimSuffix(ms.Name, "-upgrade")))
+						return nil
+					})
+				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+				Expect(replicas).To(Equal(map[string]int32{"test-worker-a-upgrade": 2, "test-worker-b-upgrade": 1}))
+			})
+			It("skips machinesets without any reserved nodes", func() {
+				scaler = NewScaler(WithCapacityReservation(1))
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, ms *machineapi.MachineSet) error {
+						Expect(ms.Name).To(Equal("test-worker-a-upgrade"))
+						Expect(*ms.Spec.Replicas).To(Equal(int32(1)))
+						return nil
+					})
+				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("adds no nodes when the reservation is zero", func() {
+				scaler = NewScaler(WithCapacityReservation(0))
+				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("When spot instances are requested", func() {
+			var providerSpec string
+			JustBeforeEach(func() {
+				upgradeMachinesets = &machineapi.MachineSetList{}
+				originalMachineSets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-worker", Namespace: MACHINE_API_NAMESPACE},
+							Spec: machineapi.MachineSetSpec{
+								Selector: metav1.LabelSelector{MatchLabels: make(map[string]string)},
+								Template: machineapi.MachineTemplateSpec{
+									ObjectMeta: metav1.ObjectMeta{Labels: make(map[string]string)},
+									Spec: machineapi.MachineSpec{
+										ProviderSpec: machineapi.ProviderSpec{Value: &runtime.RawExtension{Raw: []byte(providerSpec)}},
+									},
+								},
+							},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+					}).SetArg(1, *originalMachineSets),
+				)
+				scaler = NewScaler(WithSpotInstances())
+			})
+			Context("When the platform supports spot instances", func() {
+				BeforeEach(func() {
+					providerSpec = `{"kind":"AWSMachineProviderConfig","instanceType":"m5.xlarge"}`
+				})
+				It("requests spot instances in the cloned provider spec", func() {
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, ms *machineapi.MachineSet) error {
+							spec := map[string]interface{}{}
+							Expect(json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, &spec)).To(Succeed())
+							Expect(spec).To(HaveKeyWithValue("spotMarketOptions", map[string]interface{}{}))
+							Expect(spec).To(HaveKeyWithValue("instanceType", "m5.xlarge"))
+							return nil
+						})
+					result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(BeFalse())
+				})
+			})
+			Context("When the platform does not support spot instances", func() {
+				BeforeEach(func() {
+					providerSpec = `{"kind":"VSphereMachineProviderSpec","numCPUs":4}`
+				})
+				It("falls back to on-demand instances", func() {
+					mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, ms *machineapi.MachineSet) error {
+							Expect(string(ms.Spec.Template.Spec.ProviderSpec.Value.Raw)).To(Equal(providerSpec))
+							return nil
+						})
+					result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(BeFalse())
+				})
+			})
+		})
+
 		Context("When we're waiting for scale-out to finish", func() {
 			It("Indicates that scaling has not yet completed", func() {
 				upgradeMachinesets = &machineapi.MachineSetList{
@@ -190,6 +320,15 @@ var _ = Describe("Node scaling tests", func() {
 						},
 					},
 				}
+				provisioningPhase := "Provisioning"
+				upgradeMachines := &machineapi.MachineList{
+					Items: []machineapi.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+							Status:     machineapi.MachineStatus{Phase: &provisioningPhase},
+						},
+					},
+				}
 				gomock.InOrder(
 					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
@@ -197,11 +336,109 @@ var _ = Describe("Node scaling tests", func() {
 					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
 					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"}, client.MatchingLabels{LABEL_MACHINESET: upgradeMachinesets.Items[0].ObjectMeta.Name},
+					}).SetArg(1, *upgradeMachines),
 				)
 				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(BeFalse())
 			})
+
+			It("Fails early, with the provider error, when a machine has failed", func() {
+				failedPhase := MACHINE_PHASE_FAILED
+				providerError := "InsufficientInstanceCapacity: no capacity in us-east-1a"
+				upgradeMachinesets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:              "test-infra-upgrade",
+								Namespace:         MACHINE_API_NAMESPACE,
+								CreationTimestamp: metav1.Time{Time: time.Now()},
+							},
+						},
+					},
+				}
+				originalMachineSets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{{ObjectMeta: metav1.ObjectMeta{Name: "test-infra", Namespace: MACHINE_API_NAMESPACE}}},
+				}
+				upgradeMachines := &machineapi.MachineList{
+					Items: []machineapi.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+							Status:     machineapi.MachineStatus{Phase: &failedPhase, ErrorMessage: &providerError},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"}, client.MatchingLabels{LABEL_MACHINESET: "test-infra-upgrade"},
+					}).SetArg(1, *upgradeMachines),
+				)
+				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+				Expect(IsMachineFailedError(err)).To(BeTrue())
+				Expect(IsScaleTimeOutError(err)).To(BeFalse())
+				Expect(err.Error()).To(ContainSubstring("test-machine: " + providerError))
+				Expect(result).To(BeFalse())
+			})
+
+			It("Names the machines that never become ready", func() {
+				provisioningPhase := "Provisioning"
+				upgradeMachinesets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:              "test-infra-upgrade",
+								Namespace:         MACHINE_API_NAMESPACE,
+								CreationTimestamp: metav1.Time{Time: time.Now().Add(-60 * time.Minute)},
+							},
+						},
+					},
+				}
+				originalMachineSets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{{ObjectMeta: metav1.ObjectMeta{Name: "test-infra", Namespace: MACHINE_API_NAMESPACE}}},
+				}
+				upgradeMachines := &machineapi.MachineList{
+					Items: []machineapi.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-machine-a"},
+							Status:     machineapi.MachineStatus{Phase: &provisioningPhase},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-machine-b"},
+							Status:     machineapi.MachineStatus{Phase: &runningPhase, NodeRef: &corev1.ObjectReference{Name: "test-node-b"}},
+						},
+					},
+				}
+				node := corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-node-b"},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+					},
+				}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{LABEL_UPGRADE: "true"}, client.MatchingLabels{LABEL_MACHINESET: "test-infra-upgrade"},
+					}).SetArg(1, *upgradeMachines),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, node),
+				)
+				result, err := scaler.EnsureScaleUpNodes(mockKubeClient, testDuration, logger)
+				Expect(IsScaleTimeOutError(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("test-machine-a (phase Provisioning), test-machine-b (node test-node-b not Ready)"))
+				Expect(result).To(BeFalse())
+			})
 		})
 
 		Context("When scaled nodes are not ready", func() {
@@ -235,6 +472,7 @@ var _ = Describe("Node scaling tests", func() {
 							},
 							Spec: machineapi.MachineSpec{},
 							Status: machineapi.MachineStatus{
+								Phase: &runningPhase,
 								NodeRef: &corev1.ObjectReference{
 									Name: node.Name,
 								},
@@ -349,6 +587,7 @@ var _ = Describe("Node scaling tests", func() {
 							},
 							Spec: machineapi.MachineSpec{},
 							Status: machineapi.MachineStatus{
+								Phase: &runningPhase,
 								NodeRef: &corev1.ObjectReference{
 									Name: node.Name,
 								},
@@ -497,6 +736,95 @@ var _ = Describe("Node scaling tests", func() {
 			Expect(result).To(BeTrue())
 		})
 
+		Context("When the original MachineSets were scaled during the upgrade", func() {
+			var (
+				originalMachineSets *machineapi.MachineSetList
+				nodes               *corev1.NodeList
+			)
+			BeforeEach(func() {
+				var replicas int32 = 2
+				upgradeMachinesets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-machineset-upgrade",
+								Namespace: MACHINE_API_NAMESPACE,
+								Annotations: map[string]string{
+									ANNOTATION_ORIGINAL_MACHINESET: "test-machineset",
+								},
+							},
+						},
+					},
+				}
+				originalMachineSets = &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-machineset", Namespace: MACHINE_API_NAMESPACE},
+							Spec:       machineapi.MachineSetSpec{Replicas: &replicas},
+						},
+					},
+				}
+				nodes = &corev1.NodeList{Items: []corev1.Node{{}, {}}}
+			})
+			It("leaves the replicas of the original MachineSets as they are", func() {
+				var autoscaled int32 = 6
+				originalMachineSets.Items[0].Spec.Replicas = &autoscaled
+				nodes = &corev1.NodeList{Items: make([]corev1.Node, 6)}
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						NotMatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						NotMatchingLabels{machinery.MasterLabel: ""},
+					}).SetArg(1, *nodes),
+				)
+				result, err := scaler.EnsureScaleDownNodes(mockKubeClient, nil, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("is safe to scale down twice", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *upgradeMachinesets),
+					mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						NotMatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						NotMatchingLabels{machinery.MasterLabel: ""},
+					}).SetArg(1, *nodes),
+					// The upgrade machineset is gone the second time around
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						client.MatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, machineapi.MachineSetList{}),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(MACHINE_API_NAMESPACE),
+						NotMatchingLabels{LABEL_UPGRADE: "true"},
+					}).SetArg(1, *originalMachineSets),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						NotMatchingLabels{machinery.MasterLabel: ""},
+					}).SetArg(1, *nodes),
+				)
+				for i := 0; i < 2; i++ {
+					result, err := scaler.EnsureScaleDownNodes(mockKubeClient, nil, logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(BeTrue())
+				}
+			})
+		})
+
 		It("should apply drain strategies if NodeDrainStrategy exists", func() {
 			mockDrainStrategy := mockDrain.NewMockNodeDrainStrategy(mockCtrl)
 			var replicas int32 = 1
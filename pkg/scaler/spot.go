@@ -0,0 +1,46 @@
+package scaler
+
+import (
+	"encoding/json"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// spotInstanceFields are the provider spec fields requesting spot or preemptible instances, by
+// the kind of provider spec that supports them
+var spotInstanceFields = map[string]struct {
+	field string
+	value interface{}
+}{
+	"AWSMachineProviderConfig": {field: "spotMarketOptions", value: map[string]interface{}{}},
+	"AzureMachineProviderSpec": {field: "spotVMOptions", value: map[string]interface{}{}},
+	"GCPMachineProviderSpec":   {field: "preemptible", value: true},
+}
+
+// useSpotInstances sets the fields of a provider spec requesting spot instances. It reports
+// false, leaving the provider spec unchanged, when the platform does not support them.
+func useSpotInstances(providerSpec *machineapi.ProviderSpec) (bool, error) {
+	if providerSpec.Value == nil || len(providerSpec.Value.Raw) == 0 {
+		return false, nil
+	}
+	spec := map[string]interface{}{}
+	err := json.Unmarshal(providerSpec.Value.Raw, &spec)
+	if err != nil {
+		return false, err
+	}
+
+	kind, _ := spec["kind"].(string)
+	spot, ok := spotInstanceFields[kind]
+	if !ok {
+		return false, nil
+	}
+	spec[spot.field] = spot.value
+
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return false, err
+	}
+	providerSpec.Value = &runtime.RawExtension{Raw: raw}
+	return true, nil
+}
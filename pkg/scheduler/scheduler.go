@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"github.com/prometheus/common/log"
+	"hash/fnv"
 	"time"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -9,7 +10,7 @@ import (
 
 //go:generate mockgen -destination=mocks/mockScheduler.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/scheduler Scheduler
 type Scheduler interface {
-	IsReadyToUpgrade(*upgradev1alpha1.UpgradeConfig, time.Duration) SchedulerResult
+	IsReadyToUpgrade(*upgradev1alpha1.UpgradeConfig, time.Duration, time.Duration) SchedulerResult
 }
 
 type scheduler struct{}
@@ -24,14 +25,18 @@ type SchedulerResult struct {
 	TimeUntilUpgrade time.Duration
 }
 
-func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration) SchedulerResult {
+// IsReadyToUpgrade indicates whether the upgrade may commence, which it may once the upgradeAt time
+// delayed by the jitter has passed. The upgrade window's time out runs from the upgradeAt time
+// regardless of the jitter.
+func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration, jitter time.Duration) SchedulerResult {
 	upgradeTime, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
 	if err != nil {
 		log.Error(err, "failed to parse spec.upgradeAt", upgradeConfig.Spec.UpgradeAt)
 		return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: 0}
 	}
+	commenceTime := upgradeTime.Add(jitter)
 	now := time.Now()
-	if now.After(upgradeTime) {
+	if now.After(commenceTime) {
 		// Is the current time within the allowable upgrade window
 		if upgradeTime.Add(timeOut).After(now) {
 			return SchedulerResult{IsReady: true, IsBreached: false, TimeUntilUpgrade: 0}
@@ -41,7 +46,20 @@ func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfi
 	}
 
 	// It hasn't reached the upgrade window yet
-	pendingTime := upgradeTime.Sub(now)
+	pendingTime := commenceTime.Sub(now)
 	log.Infof("Upgrade is scheduled in %d hours %d mins", int(pendingTime.Hours()), int(pendingTime.Minutes())-(int(pendingTime.Hours())*60))
 	return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: pendingTime}
 }
+
+// CommenceJitter returns a delay of up to max to apply to the upgradeAt time. It is derived from
+// the seed, so that it is stable across reconciles; seeding it with the cluster's ID staggers the
+// upgrades of clusters which share an upgradeAt time.
+func CommenceJitter(seed string, max time.Duration) time.Duration {
+	seconds := uint64(max / time.Second)
+	if seed == "" || seconds == 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	return time.Duration(h.Sum64()%seconds) * time.Second
+}
@@ -18,22 +18,65 @@ var _ = Describe("Scheduler", func() {
 	It("should be ready to upgrade if upgradeAt is 10 mins before now", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
 		Expect(result.IsReady).To(BeTrue())
 	})
 	It("should be not ready to upgrade if upgradeAt is 80 mins before now", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(80*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
 		Expect(result.IsReady).To(BeFalse())
 	})
 	It("it should not be ready to upgrade and indicate breach if upgradeAt is after timeout", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 5 * time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 5 * time.Minute, 0)
 		Expect(result.IsReady).To(BeTrue())
 		Expect(result.IsBreached).To(BeTrue())
 	})
+
+	Context("When the commencement of the upgrade is jittered", func() {
+		It("should not be ready to upgrade until the jitter has passed", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 20*time.Minute)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically("~", 10*time.Minute, time.Minute))
+		})
+		It("should be ready to upgrade once the jitter has passed", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, time.Now().Add(-30*time.Minute).Format(time.RFC3339))
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 20*time.Minute)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+		It("should measure the upgrade window from upgradeAt", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, time.Now().Add(-70*time.Minute).Format(time.RFC3339))
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 20*time.Minute)
+			Expect(result.IsBreached).To(BeTrue())
+		})
+	})
+
+	Context("When computing the jitter of a cluster", func() {
+		It("computes the same jitter for the same cluster", func() {
+			Expect(CommenceJitter("cluster-a", time.Hour)).To(Equal(CommenceJitter("cluster-a", time.Hour)))
+		})
+		It("computes different jitters for different clusters", func() {
+			Expect(CommenceJitter("cluster-a", time.Hour)).NotTo(Equal(CommenceJitter("cluster-b", time.Hour)))
+		})
+		It("keeps the jitter within the maximum", func() {
+			for _, id := range []string{"cluster-a", "cluster-b", "cluster-c", "cluster-d"} {
+				jitter := CommenceJitter(id, 30*time.Minute)
+				Expect(jitter).To(BeNumerically(">=", 0))
+				Expect(jitter).To(BeNumerically("<", 30*time.Minute))
+			}
+		})
+		It("does not jitter when disabled or without a cluster ID", func() {
+			Expect(CommenceJitter("cluster-a", 0)).To(BeZero())
+			Expect(CommenceJitter("", time.Hour)).To(BeZero())
+		})
+	})
 })
 
 func testUpgradeConfig(proceed bool, upgradeAt string) *upgradev1alpha1.UpgradeConfig {
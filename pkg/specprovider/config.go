@@ -3,11 +3,15 @@ package specprovider
 import (
 	"fmt"
 	"strings"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/fileprovider"
 )
 
 const (
-	OCM   ConfigManagerSource = "OCM"
-	LOCAL ConfigManagerSource = "LOCAL"
+	OCM       ConfigManagerSource = "OCM"
+	LOCAL     ConfigManagerSource = "LOCAL"
+	CONFIGMAP ConfigManagerSource = fileprovider.CONFIGMAP
+	FILE      ConfigManagerSource = fileprovider.FILE
 )
 
 type ConfigManagerSource string
@@ -36,6 +40,8 @@ func (cfg *SpecProviderConfig) IsValid() error {
 		return nil
 	case string(LOCAL):
 		return nil
+	case string(CONFIGMAP), string(FILE):
+		return nil
 	default:
 		return ErrInvalidSpecProvider
 	}
@@ -1,6 +1,7 @@
 package specprovider
 
 import (
+	"fmt"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -8,6 +9,7 @@ import (
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/fileprovider"
 	"github.com/openshift/managed-upgrade-operator/pkg/localprovider"
 	"github.com/openshift/managed-upgrade-operator/pkg/ocmprovider"
 	"github.com/openshift/managed-upgrade-operator/util"
@@ -61,6 +63,20 @@ func (ppb *specProviderBuilder) New(client client.Client, builder configmanager.
 			return nil, err
 		}
 		return provider, nil
+	case fileprovider.CONFIGMAP:
+		cfg, err := readFileProviderConfig(client, builder)
+		if err != nil {
+			return nil, err
+		}
+		logf.Log.Logger.Info(fmt.Sprintf("Using ConfigMap %s as the upgrade config provider", cfg.ConfigManager.ConfigMapName))
+		return fileprovider.NewConfigMapProvider(client, cfg.ConfigManager.ConfigMapName, cfg.GetConfigMapKey())
+	case fileprovider.FILE:
+		cfg, err := readFileProviderConfig(client, builder)
+		if err != nil {
+			return nil, err
+		}
+		logf.Log.Logger.Info(fmt.Sprintf("Using file %s as the upgrade config provider", cfg.ConfigManager.SpecPath))
+		return fileprovider.NewFileProvider(cfg.ConfigManager.SpecPath)
 	}
 	return nil, ErrInvalidSpecProvider
 }
@@ -111,3 +127,18 @@ func readLocalProviderConfig(client client.Client, cfb configmanager.ConfigManag
 
 	return cfg, cfg.IsValid()
 }
+
+// Read file provider configuration
+func readFileProviderConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*fileprovider.FileProviderConfig, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	cfm := cfb.New(client, ns)
+	cfg := &fileprovider.FileProviderConfig{}
+	err = cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.IsValid()
+}
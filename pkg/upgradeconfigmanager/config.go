@@ -5,23 +5,43 @@ import (
 	"time"
 )
 
+const (
+	// The interval with which the source is polled when a source is configured without one
+	defaultWatchInterval = 60 * time.Minute
+	// The shortest interval with which the source is polled, so that it is not overloaded
+	minWatchInterval = 5 * time.Minute
+)
+
 type UpgradeConfigManagerConfig struct {
 	ConfigManager ConfigManager `yaml:"configManager"`
 }
 
 type ConfigManager struct {
-	WatchIntervalMinutes int    `yaml:"watchInterval" default:"1"`
+	Source               string `yaml:"source"`
+	WatchIntervalMinutes int    `yaml:"watchInterval" default:"60"`
 }
 
 var ErrNoConfigManagerDefined = fmt.Errorf("no configManager defined in configuration")
 
 func (cfg *UpgradeConfigManagerConfig) IsValid() error {
-	if cfg.ConfigManager.WatchIntervalMinutes <= 0 {
+	if cfg.ConfigManager.WatchIntervalMinutes < 0 {
+		return fmt.Errorf("config manager watch interval is invalid")
+	}
+	if cfg.ConfigManager.Source == "" && cfg.ConfigManager.WatchIntervalMinutes == 0 {
 		return ErrNoConfigManagerDefined
 	}
 	return nil
 }
 
+// GetWatchInterval returns the interval with which the source is polled, which is no shorter
+// than minWatchInterval
 func (cfg *UpgradeConfigManagerConfig) GetWatchInterval() time.Duration {
-	return time.Duration(cfg.ConfigManager.WatchIntervalMinutes) * time.Minute
+	if cfg.ConfigManager.WatchIntervalMinutes == 0 {
+		return defaultWatchInterval
+	}
+	interval := time.Duration(cfg.ConfigManager.WatchIntervalMinutes) * time.Minute
+	if interval < minWatchInterval {
+		return minWatchInterval
+	}
+	return interval
 }
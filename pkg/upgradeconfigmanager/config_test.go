@@ -0,0 +1,43 @@
+package upgradeconfigmanager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpgradeConfigManager config", func() {
+	It("is not defined without a source or watch interval", func() {
+		Expect((&UpgradeConfigManagerConfig{}).IsValid()).To(Equal(ErrNoConfigManagerDefined))
+	})
+
+	It("rejects a negative watch interval", func() {
+		cfg := &UpgradeConfigManagerConfig{ConfigManager: ConfigManager{Source: "OCM", WatchIntervalMinutes: -1}}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("defaults the watch interval when only a source is configured", func() {
+		cfg := &UpgradeConfigManagerConfig{ConfigManager: ConfigManager{Source: "OCM"}}
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetWatchInterval()).To(Equal(60 * time.Minute))
+	})
+
+	It("honors the configured watch interval", func() {
+		cfg := &UpgradeConfigManagerConfig{ConfigManager: ConfigManager{Source: "OCM", WatchIntervalMinutes: 15}}
+		Expect(cfg.GetWatchInterval()).To(Equal(15 * time.Minute))
+	})
+
+	It("does not poll more often than the minimum watch interval", func() {
+		cfg := &UpgradeConfigManagerConfig{ConfigManager: ConfigManager{Source: "OCM", WatchIntervalMinutes: 1}}
+		Expect(cfg.GetWatchInterval()).To(Equal(5 * time.Minute))
+	})
+
+	It("jitters the watch interval within the jitter factor", func() {
+		for i := 0; i < 100; i++ {
+			d := durationWithJitter(time.Hour, JITTER_FACTOR)
+			Expect(d).To(BeNumerically(">=", 54*time.Minute))
+			Expect(d).To(BeNumerically("<=", 66*time.Minute))
+		}
+	})
+})
@@ -288,6 +288,7 @@ func (s *upgradeConfigManager) Refresh() (bool, error) {
 		}
 
 		replacementUpgradeConfig.SetResourceVersion("")
+		replacementUpgradeConfig.SetFinalizers(nil)
 
 		err = s.client.Create(context.TODO(), &replacementUpgradeConfig)
 		if err != nil {
@@ -0,0 +1,52 @@
+package upgradehook
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type Config struct {
+	// URL receives a POST of the upgrade's details before it commences. The hook is disabled
+	// when unset.
+	URL string `yaml:"url"`
+	// Timeout is the number of seconds to wait for each response
+	Timeout int `yaml:"timeout" default:"30"`
+	// Attempts is how many times the request is made before the hook is considered unreachable
+	Attempts int `yaml:"attempts" default:"3"`
+}
+
+func (cfg *Config) IsEnabled() bool {
+	return cfg.URL != ""
+}
+
+func (cfg *Config) IsValid() error {
+	if !cfg.IsEnabled() {
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("config upgradeHook url is invalid")
+	}
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("config upgradeHook timeout is invalid")
+	}
+	if cfg.Attempts < 0 {
+		return fmt.Errorf("config upgradeHook attempts is invalid")
+	}
+	return nil
+}
+
+func (cfg *Config) GetTimeoutDuration() time.Duration {
+	if cfg.Timeout == 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(cfg.Timeout) * time.Second
+}
+
+func (cfg *Config) GetAttempts() int {
+	if cfg.Attempts == 0 {
+		return 3
+	}
+	return cfg.Attempts
+}
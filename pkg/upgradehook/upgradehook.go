@@ -0,0 +1,112 @@
+// Package upgradehook gates the commencement of an upgrade on the approval of an external
+// HTTP endpoint, such as a change-management system.
+package upgradehook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Metadata describes the upgrade to the hook
+type Metadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   string `json:"version"`
+	Channel   string `json:"channel"`
+	UpgradeAt string `json:"upgradeAt"`
+}
+
+// response is the optional body of a hook's reply. A 2xx response without a body approves
+// the upgrade.
+type response struct {
+	Approved *bool  `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+type deniedError struct {
+	message string
+}
+
+func (de *deniedError) Error() string {
+	return de.message
+}
+
+// IsDeniedError reports whether the hook answered and refused the upgrade, as opposed to
+// being unreachable
+func IsDeniedError(err error) bool {
+	_, ok := err.(*deniedError)
+	return ok
+}
+
+type Hook struct {
+	URL        string
+	Attempts   int
+	RetryDelay time.Duration
+	client     *http.Client
+}
+
+func NewHook(cfg *Config) *Hook {
+	return &Hook{
+		URL:        cfg.URL,
+		Attempts:   cfg.GetAttempts(),
+		RetryDelay: time.Second,
+		client:     &http.Client{Timeout: cfg.GetTimeoutDuration()},
+	}
+}
+
+// Approve POSTs the upgrade's metadata to the hook and returns nil only if the hook approves
+// the upgrade. Server errors and unreachable hooks are retried; a 4xx response or a 2xx
+// response with "approved": false is a denial and is not.
+func (h *Hook) Approve(metadata Metadata) error {
+	body, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= h.Attempts; attempt++ {
+		lastErr = h.post(body)
+		if lastErr == nil || IsDeniedError(lastErr) {
+			return lastErr
+		}
+		if attempt < h.Attempts {
+			time.Sleep(h.RetryDelay)
+		}
+	}
+	return fmt.Errorf("upgrade hook did not respond after %d attempts: %v", h.Attempts, lastErr)
+}
+
+func (h *Hook) post(body []byte) error {
+	resp, err := h.client.Post(h.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("upgrade hook returned server error %d", resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return &deniedError{message: fmt.Sprintf("upgrade hook denied the upgrade with status %d", resp.StatusCode)}
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil
+	}
+	reply := response{}
+	if err := json.Unmarshal(content, &reply); err != nil {
+		return &deniedError{message: fmt.Sprintf("upgrade hook returned an unrecognised response: %v", err)}
+	}
+	if reply.Approved != nil && !*reply.Approved {
+		return &deniedError{message: fmt.Sprintf("upgrade hook denied the upgrade: %s", reply.Reason)}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package upgradehook
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpgradeHook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UpgradeHook Suite")
+}
@@ -0,0 +1,97 @@
+package upgradehook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade hook", func() {
+	var (
+		server   *httptest.Server
+		handler  http.HandlerFunc
+		requests int
+		received Metadata
+		metadata = Metadata{Name: "osd-upgrade-config", Namespace: "openshift-managed-upgrade-operator", Version: "4.5.1", Channel: "stable-4.5"}
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		received = Metadata{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			handler(w, r)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newHook := func() *Hook {
+		hook := NewHook(&Config{URL: server.URL, Timeout: 1, Attempts: 2})
+		hook.RetryDelay = time.Millisecond
+		return hook
+	}
+
+	Context("When the hook approves the upgrade", func() {
+		It("Approves on an empty 2xx response", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+			Expect(newHook().Approve(metadata)).To(Succeed())
+			Expect(received).To(Equal(metadata))
+		})
+		It("Approves on an approving response", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(`{"approved": true}`)) }
+			Expect(newHook().Approve(metadata)).To(Succeed())
+		})
+	})
+
+	Context("When the hook denies the upgrade", func() {
+		It("Denies on a refusing response without retrying", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"approved": false, "reason": "change freeze"}`))
+			}
+			err := newHook().Approve(metadata)
+			Expect(IsDeniedError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("change freeze"))
+			Expect(requests).To(Equal(1))
+		})
+		It("Denies on a client error", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusForbidden) }
+			err := newHook().Approve(metadata)
+			Expect(IsDeniedError(err)).To(BeTrue())
+			Expect(requests).To(Equal(1))
+		})
+	})
+
+	Context("When the hook can not give an answer", func() {
+		It("Retries server errors and then fails", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) }
+			err := newHook().Approve(metadata)
+			Expect(err).To(HaveOccurred())
+			Expect(IsDeniedError(err)).To(BeFalse())
+			Expect(requests).To(Equal(2))
+		})
+		It("Fails when the hook does not respond in time", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) { time.Sleep(1500 * time.Millisecond) }
+			err := newHook().Approve(metadata)
+			Expect(err).To(HaveOccurred())
+			Expect(IsDeniedError(err)).To(BeFalse())
+			Expect(err.Error()).To(ContainSubstring("did not respond after 2 attempts"))
+		})
+	})
+
+	Context("When validating the config", func() {
+		It("Accepts an unset hook", func() {
+			Expect((&Config{}).IsValid()).To(Succeed())
+		})
+		It("Rejects a URL that is not http", func() {
+			Expect((&Config{URL: "ftp://example.com"}).IsValid()).NotTo(Succeed())
+		})
+	})
+})
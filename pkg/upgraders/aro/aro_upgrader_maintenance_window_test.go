@@ -1,11 +1,42 @@
 package aro
 
 import (
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ARO Upgrader Maintenance Window", func() {
+	var (
+		logger        logr.Logger
+		mockCtrl      *gomock.Controller
+		mockCVClient  *cvMocks.MockClusterVersion
+		upgradeConfig *upgradev1alpha1.UpgradeConfig
+		config        *aroUpgradeConfig
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		logger = logf.Log.WithName("aro upgrader test logger")
+		config = &aroUpgradeConfig{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
 	Context("When performing ARO upgrade", func() {
 		It("Checks Upgrade is Successful", func() {
 			status, err := checkUpgrade()
@@ -13,4 +44,81 @@ var _ = Describe("ARO Upgrader Maintenance Window", func() {
 			Expect(status).To(BeTrue())
 		})
 	})
+
+	Context("When assessing if the maintenance window is open", func() {
+		// 2021-03-06 is a Saturday
+		window := maintenanceWindow{Days: []string{"Saturday"}, StartTime: "22:00", Duration: 240}
+
+		It("is open during the window", func() {
+			Expect(window.IsOpen(time.Date(2021, 3, 6, 23, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("stays open past midnight", func() {
+			Expect(window.IsOpen(time.Date(2021, 3, 7, 1, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("is closed before the window opens and after it closes", func() {
+			Expect(window.IsOpen(time.Date(2021, 3, 6, 21, 59, 0, 0, time.UTC))).To(BeFalse())
+			Expect(window.IsOpen(time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("is closed on other days", func() {
+			Expect(window.IsOpen(time.Date(2021, 3, 5, 23, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("rejects invalid windows", func() {
+			Expect((&maintenanceWindow{StartTime: "25:00", Duration: 60}).IsValid()).NotTo(Succeed())
+			Expect((&maintenanceWindow{StartTime: "22:00"}).IsValid()).NotTo(Succeed())
+			Expect((&maintenanceWindow{StartTime: "22:00", Duration: 60, Days: []string{"Caturday"}}).IsValid()).NotTo(Succeed())
+			Expect((&maintenanceWindow{}).IsValid()).To(Succeed())
+		})
+	})
+
+	Context("When checking the maintenance window before upgrading", func() {
+		It("permits the upgrade when no window is configured", func() {
+			result, err := MaintenanceWindowPermitted(nil, config, nil, nil, nil, nil, mockCVClient, nil, upgradeConfig, nil, nil, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+
+		It("permits the upgrade within the window", func() {
+			config.MaintenanceWindow = maintenanceWindow{StartTime: time.Now().UTC().Add(-1 * time.Hour).Format("15:04"), Duration: 120}
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+			result, err := MaintenanceWindowPermitted(nil, config, nil, nil, nil, nil, mockCVClient, nil, upgradeConfig, nil, nil, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+
+		It("defers the upgrade outside the window", func() {
+			config.MaintenanceWindow = maintenanceWindow{StartTime: time.Now().UTC().Add(2 * time.Hour).Format("15:04"), Duration: 60}
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+			result, err := MaintenanceWindowPermitted(nil, config, nil, nil, nil, nil, mockCVClient, nil, upgradeConfig, nil, nil, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("does not interrupt an upgrade that has commenced", func() {
+			config.MaintenanceWindow = maintenanceWindow{StartTime: time.Now().UTC().Add(2 * time.Hour).Format("15:04"), Duration: 60}
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := MaintenanceWindowPermitted(nil, config, nil, nil, nil, nil, mockCVClient, nil, upgradeConfig, nil, nil, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+
+		It("requeues rather than fails the upgrade when deferred", func() {
+			config.MaintenanceWindow = maintenanceWindow{StartTime: time.Now().UTC().Add(2 * time.Hour).Format("15:04"), Duration: 60}
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+			cu := &aroClusterUpgrader{
+				Steps:    UpgradeSteps{upgradev1alpha1.MaintenanceWindowPermitted: MaintenanceWindowPermitted},
+				Ordering: aroUpgradeStepOrdering,
+				cvClient: mockCVClient,
+				cfg:      config,
+			}
+			phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+			Expect(condition.Type).To(Equal(upgradev1alpha1.MaintenanceWindowPermitted))
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		})
+	})
 })
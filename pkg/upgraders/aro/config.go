@@ -2,6 +2,7 @@ package aro
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
@@ -16,6 +17,7 @@ type aroUpgradeConfig struct {
 	ExtDependencyAvailabilityCheck ac.ExtDependencyAvailabilityCheck `yaml:"extDependencyAvailabilityChecks"`
 	Verification                   verification                      `yaml:"verification"`
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
+	MaintenanceWindow              maintenanceWindow                 `yaml:"maintenanceWindow"`
 }
 
 type maintenanceConfig struct {
@@ -55,6 +57,80 @@ func (cfg *upgradeWindow) GetUpgradeDelayedTriggerDuration() time.Duration {
 	return time.Duration(cfg.DelayTrigger) * time.Minute
 }
 
+// maintenanceWindow is the recurring Azure maintenance window within which an upgrade may commence
+type maintenanceWindow struct {
+	// Days are the weekdays, e.g. "Saturday", on which the window opens. It opens daily when unset.
+	Days []string `yaml:"days"`
+	// StartTime is the UTC time of day, as HH:MM, at which the window opens. Upgrades are not
+	// restricted to a window when unset.
+	StartTime string `yaml:"startTime"`
+	// Duration is the number of minutes the window remains open
+	Duration int `yaml:"duration"`
+}
+
+func (cfg *maintenanceWindow) IsConfigured() bool {
+	return cfg.StartTime != ""
+}
+
+func (cfg *maintenanceWindow) IsValid() error {
+	if !cfg.IsConfigured() {
+		return nil
+	}
+	if _, err := time.Parse("15:04", cfg.StartTime); err != nil {
+		return fmt.Errorf("Config maintenanceWindow startTime is invalid (Requires HH:MM)")
+	}
+	if cfg.Duration <= 0 || cfg.Duration > 24*60 {
+		return fmt.Errorf("Config maintenanceWindow duration is invalid (Requires int between 1 - 1440 inclusive)")
+	}
+	for _, day := range cfg.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("Config maintenanceWindow day %s is invalid", day)
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether the window is open at the supplied time. A window opened the day before
+// may still be open after midnight.
+func (cfg *maintenanceWindow) IsOpen(t time.Time) bool {
+	start, err := time.Parse("15:04", cfg.StartTime)
+	if err != nil {
+		return false
+	}
+	t = t.UTC()
+	for daysAgo := 0; daysAgo <= 1; daysAgo++ {
+		day := t.AddDate(0, 0, -daysAgo)
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		closes := opens.Add(time.Duration(cfg.Duration) * time.Minute)
+		if cfg.opensOn(opens.Weekday()) && !t.Before(opens) && t.Before(closes) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *maintenanceWindow) opensOn(weekday time.Weekday) bool {
+	if len(cfg.Days) == 0 {
+		return true
+	}
+	for _, day := range cfg.Days {
+		if d, ok := weekdays[strings.ToLower(day)]; ok && d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
 type scaleConfig struct {
 	TimeOut int `yaml:"timeOut" default:"30"`
 }
@@ -81,12 +157,18 @@ func (cfg *aroUpgradeConfig) IsValid() error {
 	if cfg.NodeDrain.ExpectedNodeDrainTime <= 0 {
 		return fmt.Errorf("Config nodeDrain expectedNodeDrainTime is invalid")
 	}
+	if err := cfg.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("Config upgrade window delay trigger is invalid")
 	}
 	if cfg.UpgradeWindow.TimeOut < 0 {
 		return fmt.Errorf("Config upgrade window time out is invalid")
 	}
+	if err := cfg.MaintenanceWindow.IsValid(); err != nil {
+		return err
+	}
 	if len(cfg.ExtDependencyAvailabilityCheck.HTTP.URLS) > 0 && cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout <= 0 || cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout > 60 {
 		return fmt.Errorf("Config HTTP timeout is invalid (Requires int between 1 - 60 inclusive)")
 	}
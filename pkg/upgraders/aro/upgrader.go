@@ -1,7 +1,12 @@
 package aro
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -18,7 +23,9 @@ import (
 
 var (
 	steps                  UpgradeSteps
-	aroUpgradeStepOrdering = []upgradev1alpha1.UpgradeConditionType{}
+	aroUpgradeStepOrdering = []upgradev1alpha1.UpgradeConditionType{
+		upgradev1alpha1.MaintenanceWindowPermitted,
+	}
 )
 
 // Represents a named series of steps as part of an upgrade process
@@ -47,7 +54,9 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		return nil, err
 	}
 
-	steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{}
+	steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+		upgradev1alpha1.MaintenanceWindowPermitted: MaintenanceWindowPermitted,
+	}
 
 	return &aroClusterUpgrader{
 		Steps:                steps,
@@ -80,10 +89,50 @@ type aroClusterUpgrader struct {
 	availabilityCheckers ac.AvailabilityCheckers
 }
 
+// MaintenanceWindowPermitted defers the upgrade until the cluster's Azure maintenance window is open
+func MaintenanceWindowPermitted(c client.Client, cfg *aroUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.MaintenanceWindow.IsConfigured() {
+		logger.Info("No maintenance window is configured, the upgrade may commence at any time")
+		return true, nil
+	}
+
+	// The window only gates the start of an upgrade, not one already in progress
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	if upgradeCommenced {
+		return true, nil
+	}
+
+	if !cfg.MaintenanceWindow.IsOpen(time.Now()) {
+		logger.Info(fmt.Sprintf("Outside of the maintenance window opening at %s UTC, deferring the upgrade", cfg.MaintenanceWindow.StartTime))
+		return false, nil
+	}
+	return true, nil
+}
+
 // This triggers the ARO upgrade process.
-// TODO: Right now it shows dummy message that upgrade is done. Actual implementation pending.
+// TODO: Only the pre-upgrade steps are implemented; once they pass it shows a dummy message that upgrade is done.
 func (cu aroClusterUpgrader) UpgradeCluster(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, *upgradev1alpha1.UpgradeCondition, error) {
 	logger.Info("Upgrading ARO cluster")
+
+	for i, key := range cu.Ordering {
+		logger.Info(fmt.Sprintf("Performing %s", key))
+		cu.recordProgress(upgradeConfig, i)
+		result, err := cu.Steps[key](cu.client, cu.cfg, cu.scaler, cu.drainstrategyBuilder, cu.metrics, cu.maintenance, cu.cvClient, cu.notifier, upgradeConfig, cu.machinery, cu.availabilityCheckers, logger)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Error when %s", key))
+			condition := newUpgradeCondition(fmt.Sprintf("%s not done", key), err.Error(), key, corev1.ConditionFalse)
+			return upgradev1alpha1.UpgradePhaseUpgrading, condition, err
+		}
+		if !result {
+			logger.Info(fmt.Sprintf("%s not done, skip following steps", key))
+			condition := newUpgradeCondition(fmt.Sprintf("%s not done", key), fmt.Sprintf("%s still in progress", key), key, corev1.ConditionFalse)
+			return upgradev1alpha1.UpgradePhaseUpgrading, condition, nil
+		}
+	}
+
 	condition := &upgradev1alpha1.UpgradeCondition{
 		Type:    "UpgradeSuccessful",
 		Status:  "Upgrade is completed",
@@ -92,3 +141,56 @@ func (cu aroClusterUpgrader) UpgradeCluster(upgradeConfig *upgradev1alpha1.Upgra
 	}
 	return upgradev1alpha1.UpgradePhaseUpgraded, condition, nil
 }
+
+// recordProgress records the step at index in the ordering as the step the upgrade is performing, for
+// the controller to persist. The time the upgrade reached the step is kept while it remains on it.
+func (cu aroClusterUpgrader) recordProgress(upgradeConfig *upgradev1alpha1.UpgradeConfig, index int) {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if history == nil {
+		return
+	}
+	startTime := &metav1.Time{Time: time.Now()}
+	if history.Progress != nil && history.Progress.Step == cu.Ordering[index] && history.Progress.StartTime != nil {
+		startTime = history.Progress.StartTime
+	}
+	history.Progress = &upgradev1alpha1.UpgradeProgress{
+		Step:       cu.Ordering[index],
+		StepIndex:  index + 1,
+		TotalSteps: len(cu.Ordering),
+		StartTime:  startTime,
+	}
+	upgradeConfig.Status.History.SetHistory(*history)
+}
+
+// CleanupUpgrade has nothing to undo, as the ARO upgrade steps make no changes to the cluster
+func (cu aroClusterUpgrader) CleanupUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) error {
+	return nil
+}
+
+// PlanUpgrade returns the ordered steps the upgrade would perform. As the ARO upgrade steps only
+// check whether the upgrade may proceed, each is run as a pre-flight check.
+func (cu aroClusterUpgrader) PlanUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) ([]upgradev1alpha1.UpgradeConditionType, upgradev1alpha1.Conditions, error) {
+	checks := upgradev1alpha1.Conditions{}
+	for _, key := range cu.Ordering {
+		logger.Info(fmt.Sprintf("Performing %s as part of a dry run", key))
+		result, err := cu.Steps[key](cu.client, cu.cfg, cu.scaler, cu.drainstrategyBuilder, cu.metrics, cu.maintenance, cu.cvClient, cu.notifier, upgradeConfig, cu.machinery, cu.availabilityCheckers, logger)
+		switch {
+		case err != nil:
+			checks = append(checks, *newUpgradeCondition(fmt.Sprintf("%s failed", key), err.Error(), key, corev1.ConditionFalse))
+		case !result:
+			checks = append(checks, *newUpgradeCondition(fmt.Sprintf("%s not met", key), fmt.Sprintf("%s is not yet met", key), key, corev1.ConditionFalse))
+		default:
+			checks = append(checks, *newUpgradeCondition(fmt.Sprintf("%s passed", key), fmt.Sprintf("%s passed", key), key, corev1.ConditionTrue))
+		}
+	}
+	return []upgradev1alpha1.UpgradeConditionType(cu.Ordering), checks, nil
+}
+
+func newUpgradeCondition(reason, msg string, conditionType upgradev1alpha1.UpgradeConditionType, s corev1.ConditionStatus) *upgradev1alpha1.UpgradeCondition {
+	return &upgradev1alpha1.UpgradeCondition{
+		Type:    conditionType,
+		Status:  s,
+		Reason:  reason,
+		Message: msg,
+	}
+}
@@ -2,10 +2,44 @@ package osd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
+	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradehook"
+)
+
+const (
+	defaultCleanupVerificationAttempts = 5
+	defaultStepTimeout                 = 360
+	defaultNodeCheckConcurrency        = 10
+	defaultClusterOperatorTimeout      = 30
+	defaultDegradedTimeout             = 15
+	maxCapacityReservation             = 20
+)
+
+const (
+	// workerSilencePlatform silences warning and info alerts in platform namespaces while the workers upgrade
+	workerSilencePlatform = "platform"
+	// workerSilenceNodes only silences node alerts while the workers upgrade
+	workerSilenceNodes = "nodes"
+)
+
+// defaultNodeAlerts are the alerts silenced while the workers upgrade in the "nodes" mode of
+// the worker silence, as workers going NotReady and unreachable while they reboot is expected
+var defaultNodeAlerts = []string{"KubeNodeNotReady", "KubeNodeUnreachable", "KubeNodeReadinessFlapping"}
+
+const (
+	// healthCheckError holds up the upgrade when a health check is not met
+	healthCheckError = "error"
+	// healthCheckWarn only logs when a health check is not met
+	healthCheckWarn = "warn"
 )
 
 type osdUpgradeConfig struct {
@@ -16,11 +50,42 @@ type osdUpgradeConfig struct {
 	ExtDependencyAvailabilityCheck ac.ExtDependencyAvailabilityCheck `yaml:"extDependencyAvailabilityChecks"`
 	Verification                   verification                      `yaml:"verification"`
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
+	StepTimeouts                   stepTimeouts                      `yaml:"stepTimeouts"`
+	EtcdBackup                     etcdBackup                        `yaml:"etcdBackup"`
+	WorkerPool                     workerPool                        `yaml:"workerPool"`
+	UpgradeHook                    upgradehook.Config                `yaml:"upgradeHook"`
+	Steps                          stepConfig                        `yaml:"steps"`
+	FeatureGates                   featureGates                      `yaml:"featureGates"`
 }
 
 type maintenanceConfig struct {
 	ControlPlaneTime int           `yaml:"controlPlaneTime" default:"60"`
 	IgnoredAlerts    ignoredAlerts `yaml:"ignoredAlerts"`
+	// Creator is recorded on maintenance silences to identify them as this operator's.
+	// The operator name is used when unset.
+	Creator string `yaml:"creator"`
+	// CommentTemplate renders maintenance silence comments from {{.Description}}, {{.Version}}
+	// and {{.Cluster}}. It must include the description and version.
+	CommentTemplate string `yaml:"commentTemplate"`
+	// Cluster identifies this cluster in maintenance silence comments
+	Cluster string `yaml:"cluster"`
+	// WorkerSilence selects the alerts silenced while the workers upgrade
+	WorkerSilence workerSilence `yaml:"workerSilence"`
+	// AlertManagerURL is the base URL, such as https://alertmanager.example.com, of an Alertmanager
+	// holding the maintenance silences in place of the in-cluster one
+	AlertManagerURL string `yaml:"alertManagerURL"`
+	// CleanupVerificationAttempts is how many times the removal of maintenance silences is
+	// verified before the upgrade carries on regardless
+	CleanupVerificationAttempts int `yaml:"cleanupVerificationAttempts" default:"5"`
+}
+
+type workerSilence struct {
+	// Mode is "platform" to silence warning and info alerts in platform namespaces, or "nodes" to
+	// silence only the NodeAlerts, leaving application alerts live to surface real regressions
+	Mode string `yaml:"mode" default:"platform"`
+	// NodeAlerts are the names of the alerts silenced in "nodes" mode. The node readiness and
+	// reachability alerts are silenced when unset.
+	NodeAlerts []string `yaml:"nodeAlerts"`
 }
 
 type ignoredAlerts struct {
@@ -28,20 +93,77 @@ type ignoredAlerts struct {
 	// e.g. 'etcdMembersDown' happens as the masters drain/reboot and a master is offline but this is expected and will resolve.
 	// This is a list of critical alerts that can be ignored while upgrading of controlplane occurs
 	ControlPlaneCriticals []string `yaml:"controlPlaneCriticals"`
+	// Some alerts are noisy for the whole of an upgrade whatever their severity, e.g. 'KubeNodeUnreachable'
+	// as workers reboot. This is a list of alert names that are silenced from the control plane upgrade
+	// until the end of the worker upgrade.
+	Upgrade []string `yaml:"upgrade"`
 }
 
 func (cfg *maintenanceConfig) IsValid() error {
 	if cfg.ControlPlaneTime <= 0 {
 		return fmt.Errorf("config maintenace controlPlaneTime out is invalid")
 	}
+	if cfg.CleanupVerificationAttempts < 0 {
+		return fmt.Errorf("config maintenance cleanupVerificationAttempts is invalid")
+	}
+	if _, err := maintenance.ParseCommentTemplate(cfg.CommentTemplate); err != nil {
+		return fmt.Errorf("config maintenance commentTemplate is invalid: %v", err)
+	}
+	if _, err := maintenance.ParseAlertManagerURL(cfg.AlertManagerURL); err != nil {
+		return fmt.Errorf("config maintenance alertManagerURL is invalid: %v", err)
+	}
+	if err := cfg.WorkerSilence.IsValid(); err != nil {
+		return err
+	}
+
+	return nil
+}
 
+func (cfg *workerSilence) IsValid() error {
+	mode := cfg.GetMode()
+	if mode != workerSilencePlatform && mode != workerSilenceNodes {
+		return fmt.Errorf("config maintenance workerSilence mode %s is invalid", cfg.Mode)
+	}
+	for _, name := range cfg.NodeAlerts {
+		if name == "" {
+			return fmt.Errorf("config maintenance workerSilence nodeAlerts must not have empty names")
+		}
+	}
 	return nil
 }
 
+func (cfg *workerSilence) GetMode() string {
+	if cfg.Mode == "" {
+		return workerSilencePlatform
+	}
+	return cfg.Mode
+}
+
+// GetAlerts returns the names of the only alerts silenced while the workers upgrade, which is
+// none in "platform" mode, as the whole of the platform's warning and info alerts are silenced
+func (cfg *workerSilence) GetAlerts() []string {
+	if cfg.GetMode() != workerSilenceNodes {
+		return nil
+	}
+	if len(cfg.NodeAlerts) == 0 {
+		return defaultNodeAlerts
+	}
+	return cfg.NodeAlerts
+}
+
 func (cfg *maintenanceConfig) GetControlPlaneDuration() time.Duration {
 	return time.Duration(cfg.ControlPlaneTime) * time.Minute
 }
 
+// GetCleanupVerificationAttempts returns the configured verification attempts, defaulting
+// to 5 when unset
+func (cfg *maintenanceConfig) GetCleanupVerificationAttempts() int {
+	if cfg.CleanupVerificationAttempts == 0 {
+		return defaultCleanupVerificationAttempts
+	}
+	return cfg.CleanupVerificationAttempts
+}
+
 type upgradeWindow struct {
 	TimeOut      int `yaml:"timeOut" default:"120"`
 	DelayTrigger int `yaml:"delayTrigger" default:"30"`
@@ -55,25 +177,304 @@ func (cfg *upgradeWindow) GetUpgradeDelayedTriggerDuration() time.Duration {
 	return time.Duration(cfg.DelayTrigger) * time.Minute
 }
 
+type stepTimeouts struct {
+	// Default is the number of minutes a step may remain incomplete before it is reported as
+	// timed out. The upgrade window still decides when an upgrade has failed. Steps waiting on
+	// the cluster to upgrade are not given the default.
+	Default int `yaml:"default" default:"360"`
+	// Steps overrides the default timeout, in minutes, for individual steps
+	Steps map[upgradev1alpha1.UpgradeConditionType]int `yaml:"steps"`
+}
+
+func (cfg *stepTimeouts) IsValid() error {
+	if cfg.Default < 0 {
+		return fmt.Errorf("config stepTimeouts default is invalid")
+	}
+	for step, timeout := range cfg.Steps {
+		if timeout <= 0 {
+			return fmt.Errorf("config stepTimeouts for step %s is invalid", step)
+		}
+	}
+	return nil
+}
+
+// GetTimeout returns how long the step may remain incomplete, or zero if it never times out
+func (cfg *stepTimeouts) GetTimeout(step upgradev1alpha1.UpgradeConditionType) time.Duration {
+	if timeout, ok := cfg.Steps[step]; ok {
+		return time.Duration(timeout) * time.Minute
+	}
+	if containsStep(untimedSteps, step) {
+		return 0
+	}
+	if cfg.Default == 0 {
+		return time.Duration(defaultStepTimeout) * time.Minute
+	}
+	return time.Duration(cfg.Default) * time.Minute
+}
+
+type stepConfig struct {
+	// Disabled lists optional steps that are skipped
+	Disabled []upgradev1alpha1.UpgradeConditionType `yaml:"disabled"`
+	// Order lists reorderable steps in the sequence they should run. The listed steps take over
+	// the positions they hold in the default ordering.
+	Order []upgradev1alpha1.UpgradeConditionType `yaml:"order"`
+}
+
+func (cfg *stepConfig) IsValid() error {
+	for _, step := range cfg.Disabled {
+		if !containsStep(osdUpgradeStepOrdering, step) {
+			return fmt.Errorf("config steps disabled step %s is unknown", step)
+		}
+		if containsStep(mandatorySteps, step) {
+			return fmt.Errorf("config steps disabled step %s is mandatory and cannot be disabled", step)
+		}
+	}
+	seen := map[upgradev1alpha1.UpgradeConditionType]bool{}
+	for _, step := range cfg.Order {
+		if !containsStep(reorderableSteps, step) {
+			return fmt.Errorf("config steps order step %s cannot be reordered", step)
+		}
+		if seen[step] {
+			return fmt.Errorf("config steps order lists step %s more than once", step)
+		}
+		seen[step] = true
+	}
+	return nil
+}
+
+// GetOrdering applies the configured order and disabled steps to the supplied ordering
+func (cfg *stepConfig) GetOrdering(ordering UpgradeStepOrdering) UpgradeStepOrdering {
+	result := UpgradeStepOrdering{}
+	next := 0
+	for _, step := range ordering {
+		if containsStep(cfg.Order, step) {
+			step = cfg.Order[next]
+			next++
+		}
+		if !containsStep(cfg.Disabled, step) {
+			result = append(result, step)
+		}
+	}
+	return result
+}
+
+func containsStep(steps []upgradev1alpha1.UpgradeConditionType, step upgradev1alpha1.UpgradeConditionType) bool {
+	for _, s := range steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+type etcdBackup struct {
+	// MaxAge is the number of minutes since the newest completed backup after which an upgrade
+	// will not commence. The check is disabled when unset.
+	MaxAge int `yaml:"maxAge"`
+	// APIVersion, Kind and Namespace identify the custom resources recording backups
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Namespace  string `yaml:"namespace"`
+}
+
+func (cfg *etcdBackup) IsEnabled() bool {
+	return cfg.MaxAge > 0
+}
+
+func (cfg *etcdBackup) IsValid() error {
+	if cfg.MaxAge < 0 {
+		return fmt.Errorf("config etcdBackup maxAge is invalid")
+	}
+	if cfg.IsEnabled() && (cfg.APIVersion == "" || cfg.Kind == "" || cfg.Namespace == "") {
+		return fmt.Errorf("config etcdBackup requires an apiVersion, kind and namespace")
+	}
+	return nil
+}
+
+func (cfg *etcdBackup) GetMaxAgeDuration() time.Duration {
+	return time.Duration(cfg.MaxAge) * time.Minute
+}
+
+type workerPool struct {
+	// MaxUnavailable replaces the worker pool's maxUnavailable, as a number or percentage of
+	// nodes, for the duration of the upgrade. The pool is left unchanged when unset.
+	MaxUnavailable string `yaml:"maxUnavailable"`
+	// Pause pauses the worker pool until the control plane has upgraded. A pool already paused
+	// by an admin is never resumed by the upgrade.
+	Pause bool `yaml:"pause"`
+	// CordonBatchSize cordons worker nodes in batches of this size ahead of the machine-config
+	// daemon draining them. Nodes are not cordoned ahead of their drain when unset.
+	CordonBatchSize int `yaml:"cordonBatchSize"`
+	// DegradedTimeout is the number of minutes the worker pool may remain degraded, failing to
+	// render or apply its configuration, before the upgrade is failed. A pool which recovers
+	// within it carries on upgrading.
+	DegradedTimeout int `yaml:"degradedTimeout" default:"15"`
+}
+
+// GetDegradedTimeoutDuration returns how long the worker pool may remain degraded, defaulting
+// to 15 minutes when unset
+func (cfg *workerPool) GetDegradedTimeoutDuration() time.Duration {
+	if cfg.DegradedTimeout == 0 {
+		return defaultDegradedTimeout * time.Minute
+	}
+	return time.Duration(cfg.DegradedTimeout) * time.Minute
+}
+
+func (cfg *workerPool) IsValid() error {
+	if cfg.CordonBatchSize < 0 {
+		return fmt.Errorf("config workerPool cordonBatchSize is invalid")
+	}
+	if cfg.DegradedTimeout < 0 {
+		return fmt.Errorf("config workerPool degradedTimeout is invalid")
+	}
+	if cfg.MaxUnavailable == "" {
+		return nil
+	}
+	maxUnavailable := intstr.Parse(cfg.MaxUnavailable)
+	value, err := intstr.GetValueFromIntOrPercent(&maxUnavailable, 100, true)
+	if err != nil || value <= 0 {
+		return fmt.Errorf("config workerPool maxUnavailable is invalid")
+	}
+	return nil
+}
+
 type scaleConfig struct {
 	TimeOut int `yaml:"timeOut" default:"30"`
+	// CapacityReservation is the total number of extra worker nodes added for upgrades requesting
+	// a capacity reservation. One node is added per worker MachineSet when unset.
+	CapacityReservation *int32 `yaml:"capacityReservation"`
+	// SpotInstances requests spot or preemptible instances for the extra worker nodes, falling back
+	// to on-demand instances on platforms without them
+	SpotInstances bool `yaml:"spotInstances"`
+}
+
+func (cfg *scaleConfig) IsValid() error {
+	if cfg.TimeOut <= 0 {
+		return fmt.Errorf("config scale timeOut is invalid")
+	}
+	if cfg.CapacityReservation != nil && (*cfg.CapacityReservation < 0 || *cfg.CapacityReservation > maxCapacityReservation) {
+		return fmt.Errorf("config scale capacityReservation is invalid (Requires int between 0 - %d inclusive)", maxCapacityReservation)
+	}
+	return nil
+}
+
+// GetScalerOptions returns the options for building the extra worker node Scaler
+func (cfg *scaleConfig) GetScalerOptions() []scaler.Option {
+	opts := []scaler.Option{}
+	if cfg.CapacityReservation != nil {
+		opts = append(opts, scaler.WithCapacityReservation(*cfg.CapacityReservation))
+	}
+	if cfg.SpotInstances {
+		opts = append(opts, scaler.WithSpotInstances())
+	}
+	return opts
 }
 
 type healthCheck struct {
 	IgnoredCriticals []string `yaml:"ignoredCriticals"`
+	// IgnoredAlerts lists sets of labels identifying known-benign critical alerts. An alert is
+	// ignored when it carries every label of any one set with the same value.
+	IgnoredAlerts []map[string]string `yaml:"ignoredAlerts"`
+	// NodeReadiness is how nodes which are NotReady or unschedulable before the upgrade are
+	// treated, either "error" or "warn"
+	NodeReadiness string `yaml:"nodeReadiness" default:"error"`
+	// PodDisruptionBudgets is how PodDisruptionBudgets which can never allow a disruption, and so
+	// block the drain of workers, are treated, either "error" or "warn"
+	PodDisruptionBudgets string `yaml:"podDisruptionBudgets" default:"warn"`
+	// PendingAlertThreshold is the number of minutes after which a pending critical alert, which is
+	// about to fire, fails the health check as a firing one does. Only firing alerts are checked when 0.
+	PendingAlertThreshold int `yaml:"pendingAlertThreshold" default:"0"`
+}
+
+func (cfg *healthCheck) IsValid() error {
+	for i, labels := range cfg.IgnoredAlerts {
+		if len(labels) == 0 {
+			return fmt.Errorf("config healthCheck ignoredAlerts entry %d has no labels", i)
+		}
+	}
+	if !isValidHealthCheckMode(cfg.GetNodeReadinessMode()) {
+		return fmt.Errorf("config healthCheck nodeReadiness %s is invalid", cfg.NodeReadiness)
+	}
+	if !isValidHealthCheckMode(cfg.GetPodDisruptionBudgetsMode()) {
+		return fmt.Errorf("config healthCheck podDisruptionBudgets %s is invalid", cfg.PodDisruptionBudgets)
+	}
+	if cfg.PendingAlertThreshold < 0 {
+		return fmt.Errorf("config healthCheck pendingAlertThreshold is invalid")
+	}
+	return nil
+}
+
+func (cfg *healthCheck) GetNodeReadinessMode() string {
+	return getHealthCheckMode(cfg.NodeReadiness, healthCheckError)
+}
+
+func (cfg *healthCheck) GetPodDisruptionBudgetsMode() string {
+	return getHealthCheckMode(cfg.PodDisruptionBudgets, healthCheckWarn)
+}
+
+func (cfg *healthCheck) GetPendingAlertThreshold() time.Duration {
+	return time.Duration(cfg.PendingAlertThreshold) * time.Minute
+}
+
+func getHealthCheckMode(mode string, defaultMode string) string {
+	if mode == "" {
+		return defaultMode
+	}
+	return strings.ToLower(mode)
+}
+
+func isValidHealthCheckMode(mode string) bool {
+	return mode == healthCheckError || mode == healthCheckWarn
+}
+
+// isIgnored reports whether an alert with the supplied labels is on the ignore list
+func (cfg *healthCheck) isIgnored(alertLabels map[string]string) bool {
+	for _, labels := range cfg.IgnoredAlerts {
+		matches := true
+		for name, value := range labels {
+			if v, ok := alertLabels[name]; !ok || v != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
 }
 
 type verification struct {
 	IgnoredNamespaces        []string `yaml:"ignoredNamespaces"`
 	NamespacePrefixesToCheck []string `yaml:"namespacePrefixesToCheck"`
+	// NodeCheckConcurrency caps how many worker nodes are evaluated at once when checking
+	// that the workers have upgraded
+	NodeCheckConcurrency int `yaml:"nodeCheckConcurrency" default:"10"`
+	// ClusterOperatorTimeout is the number of minutes to wait after the upgrade for all
+	// ClusterOperators to become Available and not Degraded
+	ClusterOperatorTimeout int `yaml:"clusterOperatorTimeout" default:"30"`
+}
+
+func (cfg *verification) GetNodeCheckConcurrency() int {
+	if cfg.NodeCheckConcurrency <= 0 {
+		return defaultNodeCheckConcurrency
+	}
+	return cfg.NodeCheckConcurrency
+}
+
+func (cfg *verification) GetClusterOperatorTimeout() time.Duration {
+	if cfg.ClusterOperatorTimeout <= 0 {
+		return time.Duration(defaultClusterOperatorTimeout) * time.Minute
+	}
+	return time.Duration(cfg.ClusterOperatorTimeout) * time.Minute
 }
 
 func (cfg *osdUpgradeConfig) IsValid() error {
 	if err := cfg.Maintenance.IsValid(); err != nil {
 		return err
 	}
-	if cfg.Scale.TimeOut <= 0 {
-		return fmt.Errorf("config scale timeOut is invalid")
+	if err := cfg.Scale.IsValid(); err != nil {
+		return err
 	}
 	if cfg.NodeDrain.Timeout <= 0 {
 		return fmt.Errorf("config nodeDrain timeOut is invalid")
@@ -81,12 +482,36 @@ func (cfg *osdUpgradeConfig) IsValid() error {
 	if cfg.NodeDrain.ExpectedNodeDrainTime <= 0 {
 		return fmt.Errorf("config nodeDrain expectedNodeDrainTime is invalid")
 	}
+	if err := cfg.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("config upgrade window delay trigger is invalid")
 	}
 	if cfg.UpgradeWindow.TimeOut < 0 {
 		return fmt.Errorf("config upgrade window time out is invalid")
 	}
+	if err := cfg.HealthCheck.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.EtcdBackup.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.WorkerPool.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.UpgradeHook.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.StepTimeouts.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.Steps.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.FeatureGates.IsValid(); err != nil {
+		return err
+	}
 	if len(cfg.ExtDependencyAvailabilityCheck.HTTP.URLS) > 0 && cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout <= 0 || cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout > 60 {
 		return fmt.Errorf("config HTTP timeout is invalid (Requires int between 1 - 60 inclusive)")
 	}
@@ -0,0 +1,56 @@
+package osd
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// dryRunChecks are the pre-flight checks a dry run performs, as they only read the state of the
+// cluster. The upgrade hook is not asked to approve the upgrade, as it may act upon the request.
+var dryRunChecks = []upgradev1alpha1.UpgradeConditionType{
+	upgradev1alpha1.UpgradePreHealthCheck,
+	upgradev1alpha1.ExtDepAvailabilityCheck,
+	upgradev1alpha1.EtcdBackupVerified,
+	upgradev1alpha1.PodDisruptionBudgetsChecked,
+}
+
+// PlanUpgrade returns the ordered steps the upgrade would perform and the results of the
+// pre-flight checks amongst them, without making any changes to the cluster
+func (cu osdClusterUpgrader) PlanUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) ([]upgradev1alpha1.UpgradeConditionType, upgradev1alpha1.Conditions, error) {
+	checks := upgradev1alpha1.Conditions{}
+	for _, key := range cu.Ordering {
+		if !containsStep(dryRunChecks, key) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Performing %s as part of a dry run", key))
+		ok, err := cu.preflightCheck(key, upgradeConfig, logger)
+		checks = append(checks, newPreflightCondition(key, ok, err))
+	}
+	return []upgradev1alpha1.UpgradeConditionType(cu.Ordering), checks, nil
+}
+
+func (cu osdClusterUpgrader) preflightCheck(key upgradev1alpha1.UpgradeConditionType, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	if key == upgradev1alpha1.UpgradePreHealthCheck {
+		// The health check step records its result in the metrics, so its checks are run directly
+		ok, err := performClusterHealthCheck(cu.client, cu.metrics, cu.cvClient, cu.cfg, logger)
+		if err != nil || !ok {
+			return ok, err
+		}
+		return performNodeReadinessCheck(cu.client, cu.cfg, logger)
+	}
+	return cu.Steps[key](cu.client, cu.cfg, cu.scaler, cu.drainstrategyBuilder, cu.metrics, cu.maintenance, cu.cvClient, cu.notifier, upgradeConfig, cu.machinery, cu.availabilityCheckers, logger)
+}
+
+func newPreflightCondition(key upgradev1alpha1.UpgradeConditionType, ok bool, err error) upgradev1alpha1.UpgradeCondition {
+	if err != nil {
+		return *newUpgradeCondition(fmt.Sprintf("%s failed", key), err.Error(), key, corev1.ConditionFalse)
+	}
+	if !ok {
+		return *newUpgradeCondition(fmt.Sprintf("%s not met", key), fmt.Sprintf("%s is not yet met", key), key, corev1.ConditionFalse)
+	}
+	return *newUpgradeCondition(fmt.Sprintf("%s passed", key), fmt.Sprintf("%s passed", key), key, corev1.ConditionTrue)
+}
@@ -0,0 +1,65 @@
+package osd
+
+import (
+	"fmt"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// featureGate puts upgrade steps behind a gate, so that they can ship before they run everywhere
+type featureGate struct {
+	// Steps only run while the gate is enabled
+	Steps []upgradev1alpha1.UpgradeConditionType
+	// Default is whether the gate is enabled when the config does not set it. Experimental
+	// steps ship disabled by default.
+	Default bool
+}
+
+// featureGateRegistry holds every known feature gate by name. Mandatory steps must not be gated.
+var featureGateRegistry = map[string]featureGate{
+	"EtcdBackupVerification": {
+		Steps:   []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.EtcdBackupVerified},
+		Default: false,
+	},
+	"WorkerPoolPause": {
+		Steps:   []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.PauseWorkerPool},
+		Default: false,
+	},
+}
+
+// featureGates enables or disables feature gates by name, overriding their defaults
+type featureGates map[string]bool
+
+func (cfg featureGates) IsValid() error {
+	for name := range cfg {
+		if _, ok := featureGateRegistry[name]; !ok {
+			return fmt.Errorf("config featureGates gate %s is unknown", name)
+		}
+	}
+	return nil
+}
+
+// IsEnabled reports whether the named gate is enabled
+func (cfg featureGates) IsEnabled(name string) bool {
+	if enabled, ok := cfg[name]; ok {
+		return enabled
+	}
+	return featureGateRegistry[name].Default
+}
+
+// GateOrdering removes the steps of disabled gates from the supplied ordering
+func (cfg featureGates) GateOrdering(ordering UpgradeStepOrdering) UpgradeStepOrdering {
+	gated := []upgradev1alpha1.UpgradeConditionType{}
+	for name, gate := range featureGateRegistry {
+		if !cfg.IsEnabled(name) {
+			gated = append(gated, gate.Steps...)
+		}
+	}
+	result := UpgradeStepOrdering{}
+	for _, step := range ordering {
+		if !containsStep(gated, step) {
+			result = append(result, step)
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package osd
+
+import (
+	"sync"
+	"time"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// upgradePhase groups upgrade steps so that the time spent in each part of the upgrade can be reported
+type upgradePhase string
+
+const (
+	phasePreUpgrade   upgradePhase = "pre_upgrade"
+	phaseControlPlane upgradePhase = "control_plane"
+	phaseWorkers      upgradePhase = "workers"
+	phasePostUpgrade  upgradePhase = "post_upgrade"
+)
+
+var (
+	stepPhases = map[upgradev1alpha1.UpgradeConditionType]upgradePhase{
+		upgradev1alpha1.SendStartedNotification:       phasePreUpgrade,
+		upgradev1alpha1.UpgradeDelayedCheck:           phasePreUpgrade,
+		upgradev1alpha1.UpgradePreHealthCheck:         phasePreUpgrade,
+		upgradev1alpha1.ExtDepAvailabilityCheck:       phasePreUpgrade,
+		upgradev1alpha1.EtcdBackupVerified:            phasePreUpgrade,
+		upgradev1alpha1.PodDisruptionBudgetsChecked:   phasePreUpgrade,
+		upgradev1alpha1.PreUpgradeHookApproved:        phasePreUpgrade,
+		upgradev1alpha1.UpgradeScaleUpExtraNodes:      phasePreUpgrade,
+		upgradev1alpha1.WorkerMaxUnavailableSet:       phasePreUpgrade,
+		upgradev1alpha1.PauseWorkerPool:               phasePreUpgrade,
+		upgradev1alpha1.ControlPlaneMaintWindow:       phasePreUpgrade,
+		upgradev1alpha1.CommenceUpgrade:               phaseControlPlane,
+		upgradev1alpha1.ControlPlaneUpgraded:          phaseControlPlane,
+		upgradev1alpha1.ResumeWorkerPool:              phaseWorkers,
+		upgradev1alpha1.RemoveControlPlaneMaintWindow: phaseWorkers,
+		upgradev1alpha1.WorkersMaintWindow:            phaseWorkers,
+		upgradev1alpha1.AllWorkerNodesUpgraded:        phaseWorkers,
+		upgradev1alpha1.WorkerNodesUncordoned:         phaseWorkers,
+		upgradev1alpha1.RemoveExtraScaledNodes:        phasePostUpgrade,
+		upgradev1alpha1.WorkerMaxUnavailableRestored:  phasePostUpgrade,
+		upgradev1alpha1.UpdateSubscriptions:           phasePostUpgrade,
+		upgradev1alpha1.PostUpgradeVerification:       phasePostUpgrade,
+		upgradev1alpha1.ClusterOperatorsAvailable:     phasePostUpgrade,
+		upgradev1alpha1.RemoveMaintWindow:             phasePostUpgrade,
+		upgradev1alpha1.PostClusterHealthCheck:        phasePostUpgrade,
+		upgradev1alpha1.SendCompletedNotification:     phasePostUpgrade,
+	}
+
+	// upgradePhaseTimer is shared by the upgraders built on each reconcile
+	upgradePhaseTimer = newPhaseTimer(time.Now)
+)
+
+// phaseTimer reports the time spent in each phase of an upgrade as the step runner moves through them.
+// Phase start times are held in memory, so a phase in progress when the operator restarts is timed
+// from the restart.
+type phaseTimer struct {
+	mutex     sync.Mutex
+	now       func() time.Time
+	version   string
+	current   upgradePhase
+	startedAt time.Time
+}
+
+func newPhaseTimer(now func() time.Time) *phaseTimer {
+	return &phaseTimer{now: now}
+}
+
+// Observe records that the upgrade is waiting on the given step
+func (pt *phaseTimer) Observe(metricsClient metrics.Metrics, upgradeConfig *upgradev1alpha1.UpgradeConfig, step upgradev1alpha1.UpgradeConditionType) {
+	phase, ok := stepPhases[step]
+	if !ok {
+		return
+	}
+
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	now := pt.now()
+	if pt.version != upgradeConfig.Spec.Desired.Version {
+		metricsClient.ResetMetricUpgradePhaseDuration()
+		pt.version = upgradeConfig.Spec.Desired.Version
+		pt.current = ""
+	}
+	if phase != pt.current {
+		if pt.current != "" {
+			metricsClient.UpdateMetricUpgradePhaseDuration(upgradeConfig.Name, string(pt.current), now.Sub(pt.startedAt))
+		}
+		pt.current = phase
+		pt.startedAt = now
+	}
+	metricsClient.UpdateMetricUpgradePhaseDuration(upgradeConfig.Name, string(phase), now.Sub(pt.startedAt))
+}
+
+// Complete records the end of the final phase of the upgrade
+func (pt *phaseTimer) Complete(metricsClient metrics.Metrics, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.version != upgradeConfig.Spec.Desired.Version || pt.current == "" {
+		return
+	}
+	metricsClient.UpdateMetricUpgradePhaseDuration(upgradeConfig.Name, string(pt.current), pt.now().Sub(pt.startedAt))
+	pt.current = ""
+}
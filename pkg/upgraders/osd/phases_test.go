@@ -0,0 +1,80 @@
+package osd
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Upgrade phase timing", func() {
+	var (
+		mockCtrl          *gomock.Controller
+		mockMetricsClient *mockMetrics.MockMetrics
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		timer             *phaseTimer
+		now               time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		now = time.Date(2021, 3, 6, 12, 0, 0, 0, time.UTC)
+		timer = newPhaseTimer(func() time.Time { return now })
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	advance := func(d time.Duration) {
+		now = now.Add(d)
+	}
+
+	It("records the time spent in each phase as the upgrade advances", func() {
+		name := upgradeConfig.Name
+		gomock.InOrder(
+			mockMetricsClient.EXPECT().ResetMetricUpgradePhaseDuration(),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phasePreUpgrade), time.Duration(0)),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phasePreUpgrade), 10*time.Minute),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phaseControlPlane), time.Duration(0)),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phaseControlPlane), 30*time.Minute),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phaseControlPlane), 40*time.Minute),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phaseWorkers), time.Duration(0)),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phaseWorkers), 60*time.Minute),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phasePostUpgrade), time.Duration(0)),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(name, string(phasePostUpgrade), 5*time.Minute),
+		)
+
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.UpgradePreHealthCheck)
+		advance(10 * time.Minute)
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.ControlPlaneUpgraded)
+		advance(30 * time.Minute)
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.ControlPlaneUpgraded)
+		advance(10 * time.Minute)
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.AllWorkerNodesUpgraded)
+		advance(60 * time.Minute)
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.PostClusterHealthCheck)
+		advance(5 * time.Minute)
+		timer.Complete(mockMetricsClient, upgradeConfig)
+	})
+
+	It("resets the phase durations when a new upgrade starts", func() {
+		gomock.InOrder(
+			mockMetricsClient.EXPECT().ResetMetricUpgradePhaseDuration(),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(gomock.Any(), string(phaseWorkers), time.Duration(0)),
+			mockMetricsClient.EXPECT().ResetMetricUpgradePhaseDuration(),
+			mockMetricsClient.EXPECT().UpdateMetricUpgradePhaseDuration(gomock.Any(), string(phasePreUpgrade), time.Duration(0)),
+		)
+
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.AllWorkerNodesUpgraded)
+		advance(time.Hour)
+		upgradeConfig.Spec.Desired.Version = "4.99.0"
+		timer.Observe(mockMetricsClient, upgradeConfig, upgradev1alpha1.UpgradePreHealthCheck)
+	})
+})
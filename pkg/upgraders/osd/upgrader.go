@@ -3,15 +3,22 @@ package osd
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-multierror"
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -25,6 +32,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradehook"
 )
 
 var (
@@ -34,20 +42,58 @@ var (
 		upgradev1alpha1.UpgradeDelayedCheck,
 		upgradev1alpha1.UpgradePreHealthCheck,
 		upgradev1alpha1.ExtDepAvailabilityCheck,
+		upgradev1alpha1.EtcdBackupVerified,
+		upgradev1alpha1.PodDisruptionBudgetsChecked,
+		upgradev1alpha1.PreUpgradeHookApproved,
 		upgradev1alpha1.UpgradeScaleUpExtraNodes,
+		upgradev1alpha1.WorkerMaxUnavailableSet,
+		upgradev1alpha1.PauseWorkerPool,
 		upgradev1alpha1.ControlPlaneMaintWindow,
 		upgradev1alpha1.CommenceUpgrade,
 		upgradev1alpha1.ControlPlaneUpgraded,
+		upgradev1alpha1.ResumeWorkerPool,
 		upgradev1alpha1.RemoveControlPlaneMaintWindow,
 		upgradev1alpha1.WorkersMaintWindow,
 		upgradev1alpha1.AllWorkerNodesUpgraded,
+		upgradev1alpha1.WorkerNodesUncordoned,
 		upgradev1alpha1.RemoveExtraScaledNodes,
+		upgradev1alpha1.WorkerMaxUnavailableRestored,
 		upgradev1alpha1.UpdateSubscriptions,
 		upgradev1alpha1.PostUpgradeVerification,
+		upgradev1alpha1.ClusterOperatorsAvailable,
 		upgradev1alpha1.RemoveMaintWindow,
 		upgradev1alpha1.PostClusterHealthCheck,
 		upgradev1alpha1.SendCompletedNotification,
 	}
+	// mandatorySteps cannot be disabled: they perform and check the upgrade itself, or undo
+	// changes made to the cluster by earlier steps
+	mandatorySteps = []upgradev1alpha1.UpgradeConditionType{
+		upgradev1alpha1.UpgradePreHealthCheck,
+		upgradev1alpha1.CommenceUpgrade,
+		upgradev1alpha1.ControlPlaneUpgraded,
+		upgradev1alpha1.ResumeWorkerPool,
+		upgradev1alpha1.RemoveControlPlaneMaintWindow,
+		upgradev1alpha1.AllWorkerNodesUpgraded,
+		upgradev1alpha1.WorkerNodesUncordoned,
+		upgradev1alpha1.RemoveExtraScaledNodes,
+		upgradev1alpha1.WorkerMaxUnavailableRestored,
+		upgradev1alpha1.RemoveMaintWindow,
+		upgradev1alpha1.PostClusterHealthCheck,
+	}
+	// untimedSteps wait on the cluster itself to upgrade, which takes longer the larger the
+	// cluster. They only time out when given a timeout of their own.
+	untimedSteps = []upgradev1alpha1.UpgradeConditionType{
+		upgradev1alpha1.ControlPlaneUpgraded,
+		upgradev1alpha1.AllWorkerNodesUpgraded,
+	}
+	// reorderableSteps are the pre-upgrade checks, which may run in any order
+	reorderableSteps = []upgradev1alpha1.UpgradeConditionType{
+		upgradev1alpha1.UpgradePreHealthCheck,
+		upgradev1alpha1.ExtDepAvailabilityCheck,
+		upgradev1alpha1.EtcdBackupVerified,
+		upgradev1alpha1.PodDisruptionBudgetsChecked,
+		upgradev1alpha1.PreUpgradeHookApproved,
+	}
 )
 
 // Represents a named series of steps as part of an upgrade process
@@ -66,7 +112,13 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		return nil, err
 	}
 
-	m, err := maintenance.NewBuilder().NewClient(c)
+	m, err := maintenance.NewBuilder(
+		maintenance.WithCreator(cfg.Maintenance.Creator),
+		maintenance.WithCommentTemplate(cfg.Maintenance.CommentTemplate),
+		maintenance.WithCluster(cfg.Maintenance.Cluster),
+		maintenance.WithAlertManagerURL(cfg.Maintenance.AlertManagerURL),
+		maintenance.WithWorkerAlerts(cfg.Maintenance.WorkerSilence.GetAlerts()),
+	).NewClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -81,16 +133,25 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		upgradev1alpha1.UpgradeDelayedCheck:           UpgradeDelayedCheck,
 		upgradev1alpha1.UpgradePreHealthCheck:         PreClusterHealthCheck,
 		upgradev1alpha1.ExtDepAvailabilityCheck:       ExternalDependencyAvailabilityCheck,
+		upgradev1alpha1.EtcdBackupVerified:            EtcdBackupCheck,
+		upgradev1alpha1.PodDisruptionBudgetsChecked:   PodDisruptionBudgetCheck,
+		upgradev1alpha1.PreUpgradeHookApproved:        PreUpgradeHook,
 		upgradev1alpha1.UpgradeScaleUpExtraNodes:      EnsureExtraUpgradeWorkers,
+		upgradev1alpha1.WorkerMaxUnavailableSet:       SetWorkerMaxUnavailable,
+		upgradev1alpha1.PauseWorkerPool:               PauseWorkerPool,
 		upgradev1alpha1.ControlPlaneMaintWindow:       CreateControlPlaneMaintWindow,
 		upgradev1alpha1.CommenceUpgrade:               CommenceUpgrade,
 		upgradev1alpha1.ControlPlaneUpgraded:          ControlPlaneUpgraded,
+		upgradev1alpha1.ResumeWorkerPool:              ResumeWorkerPool,
 		upgradev1alpha1.RemoveControlPlaneMaintWindow: RemoveControlPlaneMaintWindow,
 		upgradev1alpha1.WorkersMaintWindow:            CreateWorkerMaintWindow,
 		upgradev1alpha1.AllWorkerNodesUpgraded:        AllWorkersUpgraded,
+		upgradev1alpha1.WorkerNodesUncordoned:         UncordonWorkerNodes,
 		upgradev1alpha1.RemoveExtraScaledNodes:        RemoveExtraScaledNodes,
+		upgradev1alpha1.WorkerMaxUnavailableRestored:  RestoreWorkerMaxUnavailable,
 		upgradev1alpha1.UpdateSubscriptions:           UpdateSubscriptions,
 		upgradev1alpha1.PostUpgradeVerification:       PostUpgradeVerification,
+		upgradev1alpha1.ClusterOperatorsAvailable:     ClusterOperatorsAvailable,
 		upgradev1alpha1.RemoveMaintWindow:             RemoveMaintWindow,
 		upgradev1alpha1.PostClusterHealthCheck:        PostClusterHealthCheck,
 		upgradev1alpha1.SendCompletedNotification:     SendCompletedNotification,
@@ -98,17 +159,18 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 
 	return &osdClusterUpgrader{
 		Steps:                steps,
-		Ordering:             osdUpgradeStepOrdering,
+		Ordering:             cfg.FeatureGates.GateOrdering(cfg.Steps.GetOrdering(osdUpgradeStepOrdering)),
 		client:               c,
 		maintenance:          m,
 		metrics:              mc,
-		scaler:               scaler.NewScaler(),
+		scaler:               scaler.NewScaler(cfg.Scale.GetScalerOptions()...),
 		drainstrategyBuilder: drain.NewBuilder(),
 		cvClient:             cv.NewCVClient(c),
 		cfg:                  cfg,
 		machinery:            machinery.NewMachinery(),
 		notifier:             notifier,
 		availabilityCheckers: acs,
+		phaseTimer:           upgradePhaseTimer,
 	}, nil
 }
 
@@ -126,6 +188,7 @@ type osdClusterUpgrader struct {
 	machinery            machinery.Machinery
 	notifier             eventmanager.EventManager
 	availabilityCheckers ac.AvailabilityCheckers
+	phaseTimer           *phaseTimer
 }
 
 // PreClusterHealthCheck performs cluster healthy check
@@ -146,6 +209,12 @@ func PreClusterHealthCheck(c client.Client, cfg *osdUpgradeConfig, scaler scaler
 		return false, err
 	}
 
+	ok, err = performNodeReadinessCheck(c, cfg, logger)
+	if err != nil || !ok {
+		metricsClient.UpdateMetricClusterCheckFailed(upgradeConfig.Name)
+		return false, err
+	}
+
 	metricsClient.UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
 	return true, nil
 }
@@ -168,9 +237,21 @@ func EnsureExtraUpgradeWorkers(c client.Client, cfg *osdUpgradeConfig, s scaler.
 		return true, nil
 	}
 
+	// Scaling up fails late when the cluster has no room for the extra nodes, so check first
+	capacity, err := s.CheckCapacity(c, logger)
+	if err != nil {
+		return false, err
+	}
+	if !capacity.Known {
+		logger.Info("Unable to determine whether the cluster has the capacity for the extra node(s), scaling up regardless")
+	} else if len(capacity.Exceeded) > 0 {
+		metricsClient.UpdateMetricScalingFailed(upgradeConfig.Name)
+		return false, fmt.Errorf("the cluster does not have the capacity for the extra node(s): %s", strings.Join(capacity.Exceeded, "; "))
+	}
+
 	isScaled, err := s.EnsureScaleUpNodes(c, cfg.GetScaleDuration(), logger)
 	if err != nil {
-		if scaler.IsScaleTimeOutError(err) {
+		if scaler.IsScaleTimeOutError(err) || scaler.IsMachineFailedError(err) {
 			metricsClient.UpdateMetricScalingFailed(upgradeConfig.Name)
 		}
 		return false, err
@@ -212,6 +293,111 @@ func ExternalDependencyAvailabilityCheck(c client.Client, cfg *osdUpgradeConfig,
 	return true, nil
 }
 
+// EtcdBackupCheck verifies that a recent etcd backup exists so that a failed upgrade is recoverable
+func EtcdBackupCheck(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.EtcdBackup.IsEnabled() {
+		return true, nil
+	}
+
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	desired := upgradeConfig.Spec.Desired
+	if upgradeCommenced {
+		logger.Info(fmt.Sprintf("ClusterVersion is already set to Channel %s Version %s, skipping %s", desired.Channel, desired.Version, upgradev1alpha1.EtcdBackupVerified))
+		return true, nil
+	}
+
+	backups := &unstructured.UnstructuredList{}
+	backups.SetAPIVersion(cfg.EtcdBackup.APIVersion)
+	backups.SetKind(cfg.EtcdBackup.Kind + "List")
+	err = c.List(context.TODO(), backups, client.InNamespace(cfg.EtcdBackup.Namespace))
+	if err != nil {
+		return false, fmt.Errorf("unable to list %s: %v", cfg.EtcdBackup.Kind, err)
+	}
+
+	var latest *time.Time
+	for _, backup := range backups.Items {
+		completed, ok := backupCompletionTime(backup)
+		if ok && (latest == nil || completed.After(*latest)) {
+			latest = &completed
+		}
+	}
+	if latest == nil {
+		return false, fmt.Errorf("no completed %s found in namespace %s", cfg.EtcdBackup.Kind, cfg.EtcdBackup.Namespace)
+	}
+	if age := time.Since(*latest); age > cfg.EtcdBackup.GetMaxAgeDuration() {
+		return false, fmt.Errorf("the latest %s completed %s ago, older than the maximum age of %s", cfg.EtcdBackup.Kind, age.Round(time.Minute), cfg.EtcdBackup.GetMaxAgeDuration())
+	}
+
+	logger.Info(fmt.Sprintf("Found a %s completed at %s", cfg.EtcdBackup.Kind, latest.UTC().Format(time.RFC3339)))
+	return true, nil
+}
+
+// PodDisruptionBudgetCheck checks that no PodDisruptionBudget will block the drain of the workers
+func PodDisruptionBudgetCheck(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	if upgradeCommenced {
+		desired := upgradeConfig.Spec.Desired
+		logger.Info(fmt.Sprintf("ClusterVersion is already set to Channel %s Version %s, skipping %s", desired.Channel, desired.Version, upgradev1alpha1.PodDisruptionBudgetsChecked))
+		return true, nil
+	}
+
+	return performPDBCheck(c, cfg, logger)
+}
+
+// PreUpgradeHook asks the configured upgrade hook to approve the upgrade before it commences.
+// The upgrade does not proceed until the hook approves it.
+func PreUpgradeHook(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.UpgradeHook.IsEnabled() {
+		return true, nil
+	}
+
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	desired := upgradeConfig.Spec.Desired
+	if upgradeCommenced {
+		logger.Info(fmt.Sprintf("ClusterVersion is already set to Channel %s Version %s, skipping %s", desired.Channel, desired.Version, upgradev1alpha1.PreUpgradeHookApproved))
+		return true, nil
+	}
+
+	err = upgradehook.NewHook(&cfg.UpgradeHook).Approve(upgradehook.Metadata{
+		Name:      upgradeConfig.Name,
+		Namespace: upgradeConfig.Namespace,
+		Version:   desired.Version,
+		Channel:   desired.Channel,
+		UpgradeAt: upgradeConfig.Spec.UpgradeAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// backupCompletionTime returns when a backup completed, from its status.completionTimestamp. A
+// backup with a status.phase other than Completed has not completed successfully.
+func backupCompletionTime(backup unstructured.Unstructured) (time.Time, bool) {
+	phase, found, err := unstructured.NestedString(backup.Object, "status", "phase")
+	if err != nil || (found && phase != "Completed") {
+		return time.Time{}, false
+	}
+	timestamp, found, err := unstructured.NestedString(backup.Object, "status", "completionTimestamp")
+	if err != nil || !found {
+		return time.Time{}, false
+	}
+	completed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return completed, true
+}
+
 // CommenceUpgrade will update the clusterversion object to apply the desired version to trigger real OCP upgrade
 func CommenceUpgrade(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
 
@@ -245,6 +431,11 @@ func CreateControlPlaneMaintWindow(c client.Client, cfg *osdUpgradeConfig, scale
 		return false, err
 	}
 
+	err = m.SetAlerts(endTime, upgradeConfig.Spec.Desired.Version, cfg.Maintenance.IgnoredAlerts.Upgrade)
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -299,6 +490,16 @@ func CreateWorkerMaintWindow(c client.Client, cfg *osdUpgradeConfig, scaler scal
 		return false, err
 	}
 
+	err = m.SetAlerts(endTime, upgradeConfig.Spec.Desired.Version, cfg.Maintenance.IgnoredAlerts.Upgrade)
+	if err != nil {
+		return false, err
+	}
+
+	// The workers are underway regardless, so a failed notification should not hold them up
+	err = nc.Notify(upgradeConfig, notifier.StateWorkersStarted)
+	if err != nil {
+		logger.Error(err, "Failed to send the worker upgrade notification")
+	}
 	return true, nil
 }
 
@@ -314,8 +515,30 @@ func AllWorkersUpgraded(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Sc
 		return false, errSilence
 	}
 
-	if upgradingResult.IsUpgrading {
+	// A pool failing to render or apply its configuration is given time to recover, as the
+	// degradation can be transient, before the upgrade is failed
+	if upgradingResult.IsDegraded {
+		degradedFor := time.Since(upgradingResult.DegradedSince)
+		if degradedFor > cfg.WorkerPool.GetDegradedTimeoutDuration() {
+			return false, &upgradeFailedError{
+				reason:  "Worker pool degraded",
+				message: fmt.Sprintf("The worker MachineConfigPool has been degraded for %s: %s", degradedFor.Round(time.Minute), upgradingResult.DegradedReason),
+			}
+		}
+		logger.Info(fmt.Sprintf("the worker pool is degraded, waiting up to %s for it to recover: %s", cfg.WorkerPool.GetDegradedTimeoutDuration(), upgradingResult.DegradedReason))
+	}
+
+	// The pool must also report the update complete, so that workers are not declared upgraded
+	// before the pool has started rolling out the new configuration
+	if upgradingResult.IsUpgrading || !upgradingResult.IsUpdated {
 		logger.Info(fmt.Sprintf("not all workers are upgraded, upgraded: %v, total: %v", upgradingResult.UpdatedCount, upgradingResult.MachineCount))
+		if cfg.WorkerPool.CordonBatchSize > 0 {
+			cordonResult, err := machinery.CordonNodes(c, "worker", cfg.WorkerPool.CordonBatchSize)
+			if err != nil {
+				return false, err
+			}
+			logger.Info(fmt.Sprintf("workers cordoned ahead of their drain: %s", strings.Join(cordonResult.Batch, ",")))
+		}
 		if !silenceActive {
 			logger.Info("Worker upgrade timeout.")
 			metricsClient.UpdateMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version)
@@ -325,6 +548,15 @@ func AllWorkersUpgraded(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Sc
 		return false, nil
 	}
 
+	nodesResult, err := machinery.AreNodesUpgraded(c, "worker", cfg.Verification.GetNodeCheckConcurrency())
+	if err != nil {
+		return false, err
+	}
+	if !nodesResult.AllUpgraded() {
+		logger.Info(fmt.Sprintf("not all workers are ready on the target configuration, upgraded: %v, total: %v, waiting for: %s", nodesResult.UpgradedCount, nodesResult.NodeCount, strings.Join(nodesResult.NotUpgraded, ",")))
+		return false, nil
+	}
+
 	metricsClient.ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version)
 	return true, nil
 }
@@ -358,6 +590,73 @@ func RemoveExtraScaledNodes(c client.Client, cfg *osdUpgradeConfig, s scaler.Sca
 	return isScaledDown, nil
 }
 
+// SetWorkerMaxUnavailable applies the configured maxUnavailable to the worker pool, so that more
+// workers upgrade at once. The pool's original value is restored by RestoreWorkerMaxUnavailable.
+func SetWorkerMaxUnavailable(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if cfg.WorkerPool.MaxUnavailable == "" {
+		return true, nil
+	}
+
+	err := machinery.SetMaxUnavailable(c, "worker", intstr.Parse(cfg.WorkerPool.MaxUnavailable))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UncordonWorkerNodes uncordons any worker nodes still cordoned ahead of their drain once the
+// workers have upgraded
+func UncordonWorkerNodes(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	err := machinery.UncordonNodes(c, "worker")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RestoreWorkerMaxUnavailable restores the worker pool's maxUnavailable from before the upgrade
+func RestoreWorkerMaxUnavailable(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	err := machinery.RestoreMaxUnavailable(c, "worker")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PauseWorkerPool pauses the worker pool while the control plane upgrades, when configured to, so
+// that workers only start upgrading once the control plane has finished
+func PauseWorkerPool(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.WorkerPool.Pause {
+		return true, nil
+	}
+
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	if upgradeCommenced {
+		return true, nil
+	}
+
+	result, err := machinery.PausePool(c, "worker")
+	if err != nil {
+		return false, err
+	}
+	if !result.PausedByUpgrade {
+		logger.Info("The worker pool was already paused by an admin and will not be resumed by the upgrade")
+	}
+	return true, nil
+}
+
+// ResumeWorkerPool resumes the worker pool if it was paused by PauseWorkerPool
+func ResumeWorkerPool(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	err := machinery.ResumePool(c, "worker")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // UpdateSubscriptions will update the subscriptions for the 3rd party components, like logging
 func UpdateSubscriptions(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
 	for _, item := range upgradeConfig.Spec.SubscriptionUpdates {
@@ -395,6 +694,28 @@ func PostUpgradeVerification(c client.Client, cfg *osdUpgradeConfig, scaler scal
 	return true, nil
 }
 
+// ClusterOperatorsAvailable waits for every ClusterOperator to be Available and not Degraded,
+// failing once they have not settled within the configured timeout
+func ClusterOperatorsAvailable(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	result, err := cvClient.GetUnavailableOperators()
+	if err != nil {
+		return false, err
+	}
+	if len(result.Unavailable) == 0 {
+		return true, nil
+	}
+
+	unhealthy := strings.Join(result.Unavailable, ", ")
+	timeout := cfg.Verification.GetClusterOperatorTimeout()
+	started := stepStartTime(upgradeConfig, upgradev1alpha1.ClusterOperatorsAvailable)
+	if time.Since(started.Time) > timeout {
+		return false, fmt.Errorf("cluster operators did not become available within %s: %s", timeout, unhealthy)
+	}
+
+	logger.Info(fmt.Sprintf("Waiting for cluster operators to become available: %s", unhealthy))
+	return false, nil
+}
+
 // performPostUpgradeVerification verifies all replicasets are at expected counts and all daemonsets are at expected counts
 func performUpgradeVerification(c client.Client, cfg *osdUpgradeConfig, metricsClient metrics.Metrics, logger logr.Logger) (bool, error) {
 
@@ -478,9 +799,47 @@ func RemoveMaintWindow(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Sca
 		return false, err
 	}
 
+	err = m.EndAlerts()
+	if err != nil {
+		return false, err
+	}
+
+	key := upgradeConfig.Name + "/" + upgradeConfig.Spec.Desired.Version
+	err = m.VerifyEnded()
+	if err != nil {
+		failures := cleanupVerificationFailures.record(key)
+		if failures < cfg.Maintenance.GetCleanupVerificationAttempts() {
+			return false, err
+		}
+		logger.Error(err, fmt.Sprintf("Maintenance silences could not be verified as removed after %d attempts, continuing the upgrade", failures))
+	}
+	cleanupVerificationFailures.reset(key)
+
 	return true, nil
 }
 
+// cleanupVerificationFailures counts failed maintenance cleanup verifications per upgrade. The
+// upgrader is rebuilt on every reconcile so the count is kept at package level.
+var cleanupVerificationFailures = &failureCounter{counts: map[string]int{}}
+
+type failureCounter struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+func (fc *failureCounter) record(key string) int {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.counts[key]++
+	return fc.counts[key]
+}
+
+func (fc *failureCounter) reset(key string) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	delete(fc.counts, key)
+}
+
 // PostClusterHealthCheck performs cluster health check after upgrade
 func PostClusterHealthCheck(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
 	ok, err := performClusterHealthCheck(c, metricsClient, cvClient, cfg, logger)
@@ -524,7 +883,7 @@ func ControlPlaneUpgraded(c client.Client, cfg *osdUpgradeConfig, scaler scaler.
 
 // SendStartedNotification sends a notification on upgrade commencement
 func SendStartedNotification(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
-	err := nc.Notify(notifier.StateStarted)
+	err := nc.Notify(upgradeConfig, notifier.StateStarted)
 	if err != nil {
 		return false, err
 	}
@@ -554,7 +913,7 @@ func UpgradeDelayedCheck(c client.Client, cfg *osdUpgradeConfig, scaler scaler.S
 	delayTimeoutTrigger := cfg.UpgradeWindow.GetUpgradeDelayedTriggerDuration()
 	// Send notification if the managed upgrade started but did not hit the controlplane upgrade phase in delayTimeoutTrigger minutes
 	if !startTime.IsZero() && delayTimeoutTrigger > 0 && time.Now().After(startTime.Add(delayTimeoutTrigger)) {
-		err := nc.Notify(notifier.StateDelayed)
+		err := nc.Notify(upgradeConfig, notifier.StateDelayed)
 		if err != nil {
 			return false, err
 		}
@@ -564,21 +923,38 @@ func UpgradeDelayedCheck(c client.Client, cfg *osdUpgradeConfig, scaler scaler.S
 
 // SendCompletedNotification sends a notification on upgrade completion
 func SendCompletedNotification(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
-	err := nc.Notify(notifier.StateCompleted)
+	err := nc.Notify(upgradeConfig, notifier.StateCompleted)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+// pastPointOfNoReturn reports whether the upgrade can no longer be aborted. Once the desired
+// version has been applied to the ClusterVersion the control plane is upgrading and aborting
+// would leave it half-applied, so the upgrade must be seen through.
+func pastPointOfNoReturn(cvClient cv.ClusterVersion, upgradeConfig *upgradev1alpha1.UpgradeConfig) (bool, error) {
+	return cvClient.HasUpgradeCommenced(upgradeConfig)
+}
+
+// upgradeFailedError is returned by a step when the upgrade can no longer succeed, so that the
+// upgrade is failed rather than the step retried until the upgrade window is exceeded
+type upgradeFailedError struct {
+	reason  string
+	message string
+}
+
+func (e *upgradeFailedError) Error() string {
+	return e.message
+}
+
 // Flags if the cluster has reached a condition during upgrade where it should be treated as failed
 func shouldFailUpgrade(cvClient cv.ClusterVersion, cfg *osdUpgradeConfig, upgradeConfig *upgradev1alpha1.UpgradeConfig) (bool, error) {
-	commenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	committed, err := pastPointOfNoReturn(cvClient, upgradeConfig)
 	if err != nil {
 		return false, err
 	}
-	// If the upgrade has commenced, there's no going back
-	if commenced {
+	if committed {
 		return false, nil
 	}
 
@@ -600,49 +976,154 @@ func shouldFailUpgrade(cvClient cv.ClusterVersion, cfg *osdUpgradeConfig, upgrad
 func (cu osdClusterUpgrader) UpgradeCluster(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, *upgradev1alpha1.UpgradeCondition, error) {
 	logger.Info("Upgrading cluster")
 
+	// Refresh the count of maintenance silences after the steps have run on every reconcile, so
+	// that silences left behind when the upgrade completes are noticed
+	defer func() {
+		if err := cu.maintenance.RecordActiveSilences(cu.metrics, upgradeConfig.Name); err != nil {
+			logger.Error(err, "Failed to record the active maintenance silences")
+		}
+	}()
+
 	// Determine if the upgrade has reached conditions warranting failure
 	cancelUpgrade, _ := shouldFailUpgrade(cu.cvClient, cu.cfg, upgradeConfig)
 	if cancelUpgrade {
 
 		// Perform whatever actions are needed in the event of an upgrade failure
-		err := performUpgradeFailure(cu.client, cu.metrics, cu.scaler, cu.notifier, upgradeConfig, logger)
+		err := performUpgradeFailure(cu.client, cu.metrics, cu.scaler, cu.machinery, cu.maintenance, cu.notifier, upgradeConfig, logger)
 
-		// If we couldn't notify of failure - do nothing, return the existing phase, try again next time
+		// If we couldn't clean up or notify of failure - do nothing, return the existing phase, try again next time
 		if err != nil {
 			h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
-			condition := newUpgradeCondition("Upgrade failed", "FailedUpgrade notification sent", "FailedUpgrade", corev1.ConditionFalse)
+			condition := newUpgradeCondition("Upgrade failed", fmt.Sprintf("Aborting the upgrade failed: %v", err), "FailedUpgrade", corev1.ConditionFalse)
 			return h.Phase, condition, nil
 		}
 
+		// flag window breached metric
+		cu.metrics.UpdateMetricUpgradeWindowBreached(upgradeConfig.Name)
+
 		logger.Info("Failing upgrade")
-		condition := newUpgradeCondition("Upgrade failed", "FailedUpgrade notification sent", "FailedUpgrade", corev1.ConditionTrue)
+		msg := fmt.Sprintf("The upgrade did not commence within the %s upgrade window and was aborted. FailedUpgrade notification sent", cu.cfg.UpgradeWindow.GetUpgradeWindowTimeOutDuration())
+		condition := newUpgradeCondition("Upgrade window exceeded", msg, "FailedUpgrade", corev1.ConditionTrue)
 		return upgradev1alpha1.UpgradePhaseFailed, condition, nil
 	}
 
-	for _, key := range cu.Ordering {
+	for i, key := range cu.Ordering {
 
 		logger.Info(fmt.Sprintf("Performing %s", key))
+		startTime := stepStartTime(upgradeConfig, key)
+		cu.recordProgress(upgradeConfig, i, startTime)
 		result, err := cu.Steps[key](cu.client, cu.cfg, cu.scaler, cu.drainstrategyBuilder, cu.metrics, cu.maintenance, cu.cvClient, cu.notifier, upgradeConfig, cu.machinery, cu.availabilityCheckers, logger)
 
+		if failedErr, ok := err.(*upgradeFailedError); ok {
+			logger.Error(err, fmt.Sprintf("%s failed the upgrade", key))
+			cu.observePhase(upgradeConfig, key)
+			err = performUpgradeFailure(cu.client, cu.metrics, cu.scaler, cu.machinery, cu.maintenance, cu.notifier, upgradeConfig, logger)
+			if err != nil {
+				condition := newUpgradeCondition("Upgrade failed", fmt.Sprintf("Aborting the upgrade failed: %v", err), "FailedUpgrade", corev1.ConditionFalse)
+				return upgradev1alpha1.UpgradePhaseUpgrading, condition, nil
+			}
+			msg := fmt.Sprintf("%s. FailedUpgrade notification sent", failedErr.Error())
+			condition := newUpgradeCondition(failedErr.reason, msg, "FailedUpgrade", corev1.ConditionTrue)
+			return upgradev1alpha1.UpgradePhaseFailed, condition, nil
+		}
+		timeout := cu.cfg.StepTimeouts.GetTimeout(key)
+		if (err != nil || !result) && timeout > 0 && time.Since(startTime.Time) > timeout {
+			timeoutErr := fmt.Errorf("%s has not completed within its timeout of %s", key, timeout)
+			if err != nil {
+				timeoutErr = fmt.Errorf("%v: %v", timeoutErr, err)
+			}
+			logger.Error(timeoutErr, fmt.Sprintf("%s timed out", key))
+			cu.observePhase(upgradeConfig, key)
+			condition := newUpgradeCondition(fmt.Sprintf("%s timed out", key), timeoutErr.Error(), key, corev1.ConditionFalse)
+			condition.StartTime = startTime
+			return upgradev1alpha1.UpgradePhaseUpgrading, condition, timeoutErr
+		}
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("Error when %s", key))
+			cu.observePhase(upgradeConfig, key)
 			condition := newUpgradeCondition(fmt.Sprintf("%s not done", key), err.Error(), key, corev1.ConditionFalse)
+			condition.StartTime = startTime
 			return upgradev1alpha1.UpgradePhaseUpgrading, condition, err
 		}
 		if !result {
 			logger.Info(fmt.Sprintf("%s not done, skip following steps", key))
+			cu.observePhase(upgradeConfig, key)
 			condition := newUpgradeCondition(fmt.Sprintf("%s not done", key), fmt.Sprintf("%s still in progress", key), key, corev1.ConditionFalse)
+			condition.StartTime = startTime
 			return upgradev1alpha1.UpgradePhaseUpgrading, condition, nil
 		}
 	}
 
+	if cu.phaseTimer != nil {
+		cu.phaseTimer.Complete(cu.metrics, upgradeConfig)
+	}
 	key := cu.Ordering[len(cu.Ordering)-1]
 	condition := newUpgradeCondition(fmt.Sprintf("%s done", key), fmt.Sprintf("%s is completed", key), key, corev1.ConditionTrue)
 	return upgradev1alpha1.UpgradePhaseUpgraded, condition, nil
 }
 
+// recordProgress records the step at index in the ordering as the step the upgrade is performing, for
+// the controller to persist along with the step's condition
+func (cu osdClusterUpgrader) recordProgress(upgradeConfig *upgradev1alpha1.UpgradeConfig, index int, startTime *metav1.Time) {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if history == nil {
+		return
+	}
+	history.Progress = &upgradev1alpha1.UpgradeProgress{
+		Step:       cu.Ordering[index],
+		StepIndex:  index + 1,
+		TotalSteps: len(cu.Ordering),
+		StartTime:  startTime,
+	}
+	upgradeConfig.Status.History.SetHistory(*history)
+}
+
+// CleanupUpgrade removes the extra capacity, worker pool settings, cordons and maintenance windows the
+// upgrade may have put in place. Every cleanup is attempted even if an earlier one fails.
+func (cu osdClusterUpgrader) CleanupUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) error {
+	me := &multierror.Error{}
+
+	_, err := cu.scaler.EnsureScaleDownNodes(cu.client, nil, logger)
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to scale down the extra upgrade nodes: %v", err))
+	}
+	err = cu.machinery.ResumePool(cu.client, "worker")
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to resume the worker pool: %v", err))
+	}
+	err = cu.machinery.RestoreMaxUnavailable(cu.client, "worker")
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to restore the worker pool maxUnavailable: %v", err))
+	}
+	err = cu.machinery.UncordonNodes(cu.client, "worker")
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to uncordon the worker nodes: %v", err))
+	}
+	err = cu.maintenance.EndControlPlane()
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to remove the control plane maintenance window: %v", err))
+	}
+	err = cu.maintenance.EndWorker()
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to remove the worker maintenance window: %v", err))
+	}
+	err = cu.maintenance.EndAlerts()
+	if err != nil {
+		me = multierror.Append(me, fmt.Errorf("failed to remove the alerts maintenance window: %v", err))
+	}
+
+	return me.ErrorOrNil()
+}
+
+// observePhase records the time spent in the phase of the step the upgrade is waiting on
+func (cu osdClusterUpgrader) observePhase(upgradeConfig *upgradev1alpha1.UpgradeConfig, key upgradev1alpha1.UpgradeConditionType) {
+	if cu.phaseTimer != nil {
+		cu.phaseTimer.Observe(cu.metrics, upgradeConfig, key)
+	}
+}
+
 // Carry out routines related to moving to an upgrade-failed state
-func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s scaler.Scaler, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) error {
+func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s scaler.Scaler, mc machinery.Machinery, m maintenance.Maintenance, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) error {
 	// TearDown the extra machineset
 	_, err := s.EnsureScaleDownNodes(c, nil, logger)
 	if err != nil {
@@ -650,14 +1131,49 @@ func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s sca
 		return err
 	}
 
-	// Notify of failure
-	err = nc.Notify(notifier.StateFailed)
+	// Resume the worker pool if the upgrade paused it
+	err = mc.ResumePool(c, "worker")
+	if err != nil {
+		logger.Error(err, "Failed to resume the worker pool when upgrade failed")
+		return err
+	}
+
+	// Put back the worker pool's maxUnavailable
+	err = mc.RestoreMaxUnavailable(c, "worker")
+	if err != nil {
+		logger.Error(err, "Failed to restore the worker pool maxUnavailable when upgrade failed")
+		return err
+	}
+
+	// Uncordon the workers cordoned ahead of their drain
+	err = mc.UncordonNodes(c, "worker")
 	if err != nil {
+		logger.Error(err, "Failed to uncordon the worker nodes when upgrade failed")
 		return err
 	}
 
-	// flag window breached metric
-	metricsClient.UpdateMetricUpgradeWindowBreached(upgradeConfig.Name)
+	// Remove the maintenance windows, including that of the workers should they fail to upgrade
+	err = m.EndControlPlane()
+	if err != nil {
+		logger.Error(err, "Failed to remove the control plane maintenance window when upgrade failed")
+		return err
+	}
+	err = m.EndAlerts()
+	if err != nil {
+		logger.Error(err, "Failed to remove the alerts maintenance window when upgrade failed")
+		return err
+	}
+	err = m.EndWorker()
+	if err != nil {
+		logger.Error(err, "Failed to remove the worker maintenance window when upgrade failed")
+		return err
+	}
+
+	// Notify of failure
+	err = nc.Notify(upgradeConfig, notifier.StateFailed)
+	if err != nil {
+		return err
+	}
 
 	// cancel previously triggered metrics
 	metricsClient.ResetFailureMetrics()
@@ -665,6 +1181,84 @@ func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s sca
 	return nil
 }
 
+// performNodeReadinessCheck checks that no node is NotReady or unschedulable, as upgrading on top
+// of existing node problems compounds them. Nodes annotated as under maintenance are not checked.
+func performNodeReadinessCheck(c client.Client, cfg *osdUpgradeConfig, logger logr.Logger) (bool, error) {
+	nodes := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodes)
+	if err != nil {
+		return false, err
+	}
+
+	unhealthy := []string{}
+	for _, node := range nodes.Items {
+		if _, ok := node.GetAnnotations()[machinery.NodeMaintenanceAnnotation]; ok {
+			continue
+		}
+		if !isNodeReady(&node) {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (NotReady)", node.Name))
+		} else if node.Spec.Unschedulable {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (unschedulable)", node.Name))
+		}
+	}
+	if len(unhealthy) == 0 {
+		return true, nil
+	}
+
+	err = fmt.Errorf("nodes are not ready to upgrade: %s", strings.Join(unhealthy, ", "))
+	if cfg.HealthCheck.GetNodeReadinessMode() == healthCheckWarn {
+		logger.Info(fmt.Sprintf("Upgrading regardless, %v", err))
+		return true, nil
+	}
+	logger.Info(err.Error())
+	return false, err
+}
+
+// performPDBCheck checks for PodDisruptionBudgets which can never allow a disruption, as the
+// drain of any worker running their pods would be blocked until the force drain timeout.
+// Budgets which allow no disruption only until their pods are healthy, such as while scaling,
+// are reported but do not fail the check.
+func performPDBCheck(c client.Client, cfg *osdUpgradeConfig, logger logr.Logger) (bool, error) {
+	pdbs := &policyv1beta1.PodDisruptionBudgetList{}
+	err := c.List(context.TODO(), pdbs)
+	if err != nil {
+		return false, err
+	}
+
+	blocking := []string{}
+	for _, pdb := range pdbs.Items {
+		if pdb.Status.DisruptionsAllowed > 0 || pdb.Status.ExpectedPods == 0 {
+			continue
+		}
+		name := pdb.Namespace + "/" + pdb.Name
+		if pdb.Status.DesiredHealthy < pdb.Status.ExpectedPods {
+			logger.Info(fmt.Sprintf("PodDisruptionBudget %s allows no disruptions until %d of its %d pods are healthy", name, pdb.Status.DesiredHealthy, pdb.Status.ExpectedPods))
+			continue
+		}
+		blocking = append(blocking, name)
+	}
+	if len(blocking) == 0 {
+		return true, nil
+	}
+
+	err = fmt.Errorf("PodDisruptionBudgets can never allow a disruption and will block the drain of workers: %s", strings.Join(blocking, ", "))
+	logger.Info(err.Error())
+	if cfg.HealthCheck.GetPodDisruptionBudgetsMode() == healthCheckWarn {
+		return true, nil
+	}
+	return false, err
+}
+
+// isNodeReady reports whether the node's Ready condition is True
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // check several things about the cluster and report problems
 // * critical alerts
 // * degraded operators (if there are critical alerts only)
@@ -674,15 +1268,34 @@ func performClusterHealthCheck(c client.Client, metricsClient metrics.Metrics, c
 	if len(ic) > 0 {
 		icQuery = `,alertname!="` + strings.Join(ic, `",alertname!="`) + `"`
 	}
-	healthCheckQuery := `ALERTS{alertstate="firing",severity="critical",namespace=~"^openshift.*|^kube-.*|^default$",namespace!="openshift-customer-monitoring",namespace!="openshift-logging",namespace!="openshift-operators"` + icQuery + "}"
+	criticalAlertSelector := `severity="critical",namespace=~"^openshift.*|^kube-.*|^default$",namespace!="openshift-customer-monitoring",namespace!="openshift-logging",namespace!="openshift-operators"` + icQuery
+	healthCheckQuery := `ALERTS{alertstate="firing",` + criticalAlertSelector + "}"
 	alerts, err := metricsClient.Query(healthCheckQuery)
 	if err != nil {
 		return false, fmt.Errorf("unable to query critical alerts: %s", err)
 	}
 
-	if len(alerts.Data.Result) > 0 {
-		logger.Info("There are critical alerts exists, cannot upgrade now")
-		return false, fmt.Errorf("there are %d critical alerts", len(alerts.Data.Result))
+	firing := []string{}
+	for _, alert := range alerts.Data.Result {
+		if cfg.HealthCheck.isIgnored(alert.Metric) {
+			continue
+		}
+		firing = append(firing, alert.Metric["alertname"])
+	}
+	if len(firing) > 0 {
+		logger.Info(fmt.Sprintf("There are critical alerts exists, cannot upgrade now: %s", strings.Join(firing, ",")))
+		return false, fmt.Errorf("there are %d critical alerts", len(firing))
+	}
+
+	if threshold := cfg.HealthCheck.GetPendingAlertThreshold(); threshold > 0 {
+		pending, err := pendingCriticalAlerts(metricsClient, cfg, criticalAlertSelector, threshold)
+		if err != nil {
+			return false, err
+		}
+		if len(pending) > 0 {
+			logger.Info(fmt.Sprintf("There are critical alerts about to fire, cannot upgrade now: %s", strings.Join(pending, ",")))
+			return false, fmt.Errorf("there are %d critical alerts pending for longer than %s", len(pending), threshold)
+		}
 	}
 
 	result, err := cvClient.HasDegradedOperators()
@@ -698,6 +1311,58 @@ func performClusterHealthCheck(c client.Client, metricsClient metrics.Metrics, c
 	return true, nil
 }
 
+// pendingCriticalAlerts returns the names of the critical alerts matching the selector which have been
+// pending for at least the threshold. ALERTS_FOR_STATE holds the time each active alert became active.
+func pendingCriticalAlerts(metricsClient metrics.Metrics, cfg *osdUpgradeConfig, selector string, threshold time.Duration) ([]string, error) {
+	query := `ALERTS_FOR_STATE{` + selector + `} and ignoring(alertstate) ALERTS{alertstate="pending",` + selector + `}`
+	alerts, err := metricsClient.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pending critical alerts: %s", err)
+	}
+
+	pending := []string{}
+	for _, alert := range alerts.Data.Result {
+		if cfg.HealthCheck.isIgnored(alert.Metric) {
+			continue
+		}
+		activeSince, ok := alertActiveSince(alert)
+		if !ok || time.Since(activeSince) < threshold {
+			continue
+		}
+		pending = append(pending, alert.Metric["alertname"])
+	}
+	return pending, nil
+}
+
+// alertActiveSince reads the time an alert became active from the value of its ALERTS_FOR_STATE sample
+func alertActiveSince(alert metrics.AlertResult) (time.Time, bool) {
+	if len(alert.Value) < 2 {
+		return time.Time{}, false
+	}
+	value, ok := alert.Value[1].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// stepStartTime returns when the step was first found incomplete, as recorded on the condition
+// of a previous reconcile, or the current time if the step has only now been reached
+func stepStartTime(upgradeConfig *upgradev1alpha1.UpgradeConfig, key upgradev1alpha1.UpgradeConditionType) *metav1.Time {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if history != nil {
+		condition := history.Conditions.GetCondition(key)
+		if condition != nil && condition.IsFalse() && condition.StartTime != nil {
+			return condition.StartTime
+		}
+	}
+	return &metav1.Time{Time: time.Now()}
+}
+
 func newUpgradeCondition(reason, msg string, conditionType upgradev1alpha1.UpgradeConditionType, s corev1.ConditionStatus) *upgradev1alpha1.UpgradeCondition {
 	return &upgradev1alpha1.UpgradeCondition{
 		Type:    conditionType,
@@ -21,6 +21,7 @@ import (
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -66,6 +67,7 @@ var _ = Describe("ClusterUpgrader maintenance window tests", func() {
 				ControlPlaneTime: 90,
 				IgnoredAlerts: ignoredAlerts{
 					ControlPlaneCriticals: []string{"ignoreAlert1SRE", "ignoreAlert2SRE"},
+					Upgrade:               []string{"KubeNodeUnreachable"},
 				},
 			},
 			Scale: scaleConfig{
@@ -98,7 +100,10 @@ var _ = Describe("ClusterUpgrader maintenance window tests", func() {
 
 	Context("When creating a control plane maintenance window", func() {
 		It("Asks the maintenance client to do so", func() {
-			mockMaintClient.EXPECT().StartControlPlane(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.ControlPlaneCriticals)
+			gomock.InOrder(
+				mockMaintClient.EXPECT().StartControlPlane(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.ControlPlaneCriticals),
+				mockMaintClient.EXPECT().SetAlerts(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.Upgrade),
+			)
 			result, err := CreateControlPlaneMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeTrue())
@@ -109,12 +114,32 @@ var _ = Describe("ClusterUpgrader maintenance window tests", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeFalse())
 		})
+		It("Indicates when silencing the upgrade alerts has failed", func() {
+			gomock.InOrder(
+				mockMaintClient.EXPECT().StartControlPlane(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.ControlPlaneCriticals),
+				mockMaintClient.EXPECT().SetAlerts(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.Upgrade).Return(fmt.Errorf("fake error")),
+			)
+			result, err := CreateControlPlaneMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
 	})
 
 	Context("When creating a worker maintenance window", func() {
 		It("Asks the maintenance client to do so", func() {
 			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true, MachineCount: 4, UpdatedCount: 2}, nil)
 			mockMaintClient.EXPECT().SetWorker(gomock.Any(), upgradeConfig.Spec.Desired.Version, gomock.Any())
+			mockMaintClient.EXPECT().SetAlerts(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.Upgrade)
+			mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateWorkersStarted)
+			result, err := CreateWorkerMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("Does not hold up the workers if the notification can not be sent", func() {
+			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true, MachineCount: 4, UpdatedCount: 2}, nil)
+			mockMaintClient.EXPECT().SetWorker(gomock.Any(), upgradeConfig.Spec.Desired.Version, gomock.Any())
+			mockMaintClient.EXPECT().SetAlerts(gomock.Any(), upgradeConfig.Spec.Desired.Version, config.Maintenance.IgnoredAlerts.Upgrade)
+			mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateWorkersStarted).Return(fmt.Errorf("fake error"))
 			result, err := CreateWorkerMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeTrue())
@@ -148,18 +173,83 @@ var _ = Describe("ClusterUpgrader maintenance window tests", func() {
 		})
 	})
 
+	Context("When configuring the alerts silenced while the workers upgrade", func() {
+		It("Silences the platform's warning and info alerts by default", func() {
+			Expect(config.Maintenance.WorkerSilence.IsValid()).To(Succeed())
+			Expect(config.Maintenance.WorkerSilence.GetAlerts()).To(BeEmpty())
+		})
+		It("Silences the node alerts only in nodes mode", func() {
+			config.Maintenance.WorkerSilence.Mode = workerSilenceNodes
+			Expect(config.Maintenance.WorkerSilence.GetAlerts()).To(Equal(defaultNodeAlerts))
+			config.Maintenance.WorkerSilence.NodeAlerts = []string{"KubeNodeNotReady"}
+			Expect(config.Maintenance.WorkerSilence.GetAlerts()).To(Equal([]string{"KubeNodeNotReady"}))
+		})
+		It("Rejects an unknown mode or empty alert names", func() {
+			config.Maintenance.WorkerSilence.Mode = "everything"
+			Expect(config.Maintenance.WorkerSilence.IsValid()).NotTo(Succeed())
+			config.Maintenance.WorkerSilence = workerSilence{Mode: workerSilenceNodes, NodeAlerts: []string{""}}
+			Expect(config.Maintenance.WorkerSilence.IsValid()).NotTo(Succeed())
+		})
+	})
+
 	Context("When removing a worker maintenance window", func() {
+		BeforeEach(func() {
+			cleanupVerificationFailures = &failureCounter{counts: map[string]int{}}
+			config.Maintenance.CleanupVerificationAttempts = 2
+		})
 		It("Asks the maintenance client to do so", func() {
 			mockMaintClient.EXPECT().EndWorker()
+			mockMaintClient.EXPECT().EndAlerts()
+			mockMaintClient.EXPECT().VerifyEnded()
 			result, err := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeTrue())
 		})
-		It("Indicates when creating the maintenance window has failed", func() {
+		It("Indicates when removing the maintenance window has failed", func() {
 			mockMaintClient.EXPECT().EndWorker().Return(fmt.Errorf("fake error"))
 			result, err := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeFalse())
 		})
+		It("Requeues while maintenance silences linger", func() {
+			mockMaintClient.EXPECT().EndWorker()
+			mockMaintClient.EXPECT().EndAlerts()
+			mockMaintClient.EXPECT().VerifyEnded().Return(fmt.Errorf("1 maintenance silences are still active: test-id"))
+			result, err := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+		It("Requeues while Alertmanager can not be reached to verify", func() {
+			mockMaintClient.EXPECT().EndWorker()
+			mockMaintClient.EXPECT().EndAlerts()
+			mockMaintClient.EXPECT().VerifyEnded().Return(fmt.Errorf("unable to verify maintenance silences have ended: fake error"))
+			result, err := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+		It("Carries on once verification has failed the configured number of times", func() {
+			mockMaintClient.EXPECT().EndWorker().Times(2)
+			mockMaintClient.EXPECT().EndAlerts().Times(2)
+			mockMaintClient.EXPECT().VerifyEnded().Return(fmt.Errorf("fake error")).Times(2)
+			result, err := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+			result, err = RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("Resets the failure count once verification succeeds", func() {
+			gomock.InOrder(
+				mockMaintClient.EXPECT().VerifyEnded().Return(fmt.Errorf("fake error")),
+				mockMaintClient.EXPECT().VerifyEnded(),
+				mockMaintClient.EXPECT().VerifyEnded().Return(fmt.Errorf("fake error")),
+			)
+			mockMaintClient.EXPECT().EndWorker().Times(3)
+			mockMaintClient.EXPECT().EndAlerts().Times(3)
+			for _, expectDone := range []bool{false, true, false} {
+				result, _ := RemoveMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(result).To(Equal(expectDone))
+			}
+		})
 	})
 })
@@ -2,6 +2,8 @@ package osd
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,8 +12,11 @@ import (
 	. "github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -33,6 +38,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradehook"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 )
@@ -165,6 +171,7 @@ var _ = Describe("ClusterUpgrader", func() {
 			It("Should scale up extra nodes and set success metric on successful scaling when capacity reservation enabled", func() {
 				gomock.InOrder(
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockScalerClient.EXPECT().CheckCapacity(gomock.Any(), gomock.Any()).Return(&scaler.CapacityResult{Known: true}, nil),
 					mockScalerClient.EXPECT().EnsureScaleUpNodes(gomock.Any(), config.GetScaleDuration(), gomock.Any()).Return(true, nil),
 					mockMetricsClient.EXPECT().UpdateMetricScalingSucceeded(gomock.Any()),
 				)
@@ -176,6 +183,7 @@ var _ = Describe("ClusterUpgrader", func() {
 			It("Should set failed metric on scaling time out when capacity reservation enabled", func() {
 				gomock.InOrder(
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockScalerClient.EXPECT().CheckCapacity(gomock.Any(), gomock.Any()).Return(&scaler.CapacityResult{Known: true}, nil),
 					mockScalerClient.EXPECT().EnsureScaleUpNodes(gomock.Any(), config.GetScaleDuration(), gomock.Any()).Return(false, scaler.NewScaleTimeOutError("test scale timed out")),
 					mockMetricsClient.EXPECT().UpdateMetricScalingFailed(gomock.Any()),
 				)
@@ -184,6 +192,42 @@ var _ = Describe("ClusterUpgrader", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(ok).To(BeFalse())
 			})
+			It("Should set failed metric when an extra machine fails to provision", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockScalerClient.EXPECT().CheckCapacity(gomock.Any(), gomock.Any()).Return(&scaler.CapacityResult{Known: true}, nil),
+					mockScalerClient.EXPECT().EnsureScaleUpNodes(gomock.Any(), config.GetScaleDuration(), gomock.Any()).Return(false, scaler.NewMachineFailedError("test machine failed")),
+					mockMetricsClient.EXPECT().UpdateMetricScalingFailed(gomock.Any()),
+				)
+
+				ok, err := EnsureExtraUpgradeWorkers(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+			It("Should scale up extra nodes when the capacity of the cluster is unknown", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockScalerClient.EXPECT().CheckCapacity(gomock.Any(), gomock.Any()).Return(&scaler.CapacityResult{Known: false}, nil),
+					mockScalerClient.EXPECT().EnsureScaleUpNodes(gomock.Any(), config.GetScaleDuration(), gomock.Any()).Return(false, nil),
+				)
+
+				ok, err := EnsureExtraUpgradeWorkers(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(ok).To(BeFalse())
+			})
+			It("Should not scale up extra nodes when the cluster does not have the capacity for them", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockScalerClient.EXPECT().CheckCapacity(gomock.Any(), gomock.Any()).Return(&scaler.CapacityResult{Known: true, Exceeded: []string{"2 extra nodes would exceed the maximum of 10 nodes, with 9 already"}}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricScalingFailed(gomock.Any()),
+				)
+				mockScalerClient.EXPECT().EnsureScaleUpNodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				ok, err := EnsureExtraUpgradeWorkers(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("maximum of 10 nodes"))
+				Expect(ok).To(BeFalse())
+			})
 		})
 		Context("When capacity reservation is disabled", func() {
 			BeforeEach(func() {
@@ -237,8 +281,9 @@ var _ = Describe("ClusterUpgrader", func() {
 		Context("When all workers are upgraded", func() {
 			It("Indicates that all workers are upgraded", func() {
 				gomock.InOrder(
-					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false}, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false, IsUpdated: true}, nil),
 					mockMaintClient.EXPECT().IsActive(),
+					mockMachineryClient.EXPECT().AreNodesUpgraded(gomock.Any(), "worker", defaultNodeCheckConcurrency).Return(&machinery.NodesUpgradedResult{UpgradedCount: 3, NodeCount: 3}, nil),
 					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
 				)
 				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
@@ -246,6 +291,75 @@ var _ = Describe("ClusterUpgrader", func() {
 				Expect(result).To(BeTrue())
 			})
 		})
+		Context("When the pool is updated but a worker is not ready on the target configuration", func() {
+			It("Indicates that all workers are not upgraded", func() {
+				config.Verification.NodeCheckConcurrency = 4
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false, IsUpdated: true}, nil),
+					mockMaintClient.EXPECT().IsActive(),
+					mockMachineryClient.EXPECT().AreNodesUpgraded(gomock.Any(), "worker", 4).Return(&machinery.NodesUpgradedResult{UpgradedCount: 2, NodeCount: 3, NotUpgraded: []string{"worker-2"}}, nil),
+				)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+		})
+		Context("When the pool has yet to report its update to the target configuration", func() {
+			It("Indicates that all workers are not upgraded", func() {
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false, IsUpdated: false}, nil),
+					mockMaintClient.EXPECT().IsActive().Return(true, nil),
+					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+				)
+				mockMachineryClient.EXPECT().AreNodesUpgraded(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+		})
+		Context("When the worker pool is degraded", func() {
+			degradedResult := func(since time.Duration) *machinery.UpgradingResult {
+				return &machinery.UpgradingResult{
+					IsUpgrading:    true,
+					IsDegraded:     true,
+					DegradedReason: "Node worker-1 is reporting: \"failed to apply the configuration\"",
+					DegradedSince:  time.Now().Add(-since),
+				}
+			}
+			It("Fails the upgrade once the pool has been degraded beyond the timeout", func() {
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(degradedResult(20*time.Minute), nil),
+					mockMaintClient.EXPECT().IsActive(),
+				)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(result).To(BeFalse())
+				Expect(err).To(HaveOccurred())
+				failedErr, ok := err.(*upgradeFailedError)
+				Expect(ok).To(BeTrue())
+				Expect(failedErr.Error()).To(ContainSubstring("failed to apply the configuration"))
+			})
+			It("Waits for a pool degraded within the timeout to recover", func() {
+				config.WorkerPool.DegradedTimeout = 30
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(degradedResult(20*time.Minute), nil),
+					mockMaintClient.EXPECT().IsActive().Return(true, nil),
+					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+				)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false, IsUpdated: true}, nil),
+					mockMaintClient.EXPECT().IsActive().Return(true, nil),
+					mockMachineryClient.EXPECT().AreNodesUpgraded(gomock.Any(), "worker", defaultNodeCheckConcurrency).Return(&machinery.NodesUpgradedResult{UpgradedCount: 3, NodeCount: 3}, nil),
+					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+				)
+				result, err = AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
 		Context("When all workers are not upgraded", func() {
 			It("Indicates that all workers are not upgraded", func() {
 				gomock.InOrder(
@@ -258,6 +372,63 @@ var _ = Describe("ClusterUpgrader", func() {
 				Expect(result).To(BeFalse())
 			})
 		})
+		Context("When workers are cordoned in batches ahead of their drain", func() {
+			BeforeEach(func() {
+				config.WorkerPool.CordonBatchSize = 2
+			})
+			It("Cordons the next batch while the workers upgrade", func() {
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockMaintClient.EXPECT().IsActive().Return(true, nil),
+					mockMachineryClient.EXPECT().CordonNodes(gomock.Any(), "worker", 2).Return(&machinery.CordonResult{Batch: []string{"worker-1", "worker-2"}}, nil),
+					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+				)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("Does not cordon workers once they are all upgraded", func() {
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: false, IsUpdated: true}, nil),
+					mockMaintClient.EXPECT().IsActive(),
+					mockMachineryClient.EXPECT().AreNodesUpgraded(gomock.Any(), "worker", defaultNodeCheckConcurrency).Return(&machinery.NodesUpgradedResult{UpgradedCount: 3, NodeCount: 3}, nil),
+					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+				)
+				mockMachineryClient.EXPECT().CordonNodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("Indicates when a batch can not be cordoned", func() {
+				gomock.InOrder(
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockMaintClient.EXPECT().IsActive(),
+					mockMachineryClient.EXPECT().CordonNodes(gomock.Any(), "worker", 2).Return(nil, fmt.Errorf("fake error")),
+				)
+				result, err := AllWorkersUpgraded(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("Rejects a negative batch size", func() {
+				config.WorkerPool.CordonBatchSize = -1
+				Expect(config.WorkerPool.IsValid()).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("When uncordoning the workers", func() {
+		It("uncordons the workers cordoned ahead of their drain", func() {
+			mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker")
+			result, err := UncordonWorkerNodes(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("indicates when the workers can not be uncordoned", func() {
+			mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker").Return(fmt.Errorf("fake error"))
+			result, err := UncordonWorkerNodes(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
 	})
 
 	Context("When the cluster's upgrade process has commenced", func() {
@@ -316,6 +487,275 @@ var _ = Describe("ClusterUpgrader", func() {
 		})
 	})
 
+	Context("When verifying an etcd backup exists", func() {
+		var backupCompletedAgo = func(phase string, ago time.Duration) unstructured.Unstructured {
+			backup := unstructured.Unstructured{Object: map[string]interface{}{}}
+			_ = unstructured.SetNestedField(backup.Object, phase, "status", "phase")
+			_ = unstructured.SetNestedField(backup.Object, time.Now().Add(-ago).UTC().Format(time.RFC3339), "status", "completionTimestamp")
+			return backup
+		}
+		BeforeEach(func() {
+			config.EtcdBackup = etcdBackup{
+				MaxAge:     1440,
+				APIVersion: "velero.io/v1",
+				Kind:       "Backup",
+				Namespace:  "openshift-velero",
+			}
+		})
+		It("passes when a fresh backup has completed", func() {
+			backups := unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				backupCompletedAgo("Completed", 72*time.Hour),
+				backupCompletedAgo("Completed", 2*time.Hour),
+			}}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, backups),
+			)
+			result, err := EtcdBackupCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("fails when the newest backup is too old", func() {
+			backups := unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				backupCompletedAgo("Completed", 48*time.Hour),
+				backupCompletedAgo("Failed", time.Hour),
+			}}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, backups),
+			)
+			result, err := EtcdBackupCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("older than the maximum age"))
+			Expect(result).To(BeFalse())
+		})
+		It("fails when there are no backups", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, unstructured.UnstructuredList{}),
+			)
+			result, err := EtcdBackupCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no completed Backup found"))
+			Expect(result).To(BeFalse())
+		})
+		It("does not check once the upgrade has commenced", func() {
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := EtcdBackupCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("does not check when no maximum age is configured", func() {
+			config.EtcdBackup = etcdBackup{}
+			result, err := EtcdBackupCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When overriding the worker pool maxUnavailable", func() {
+		It("sets the configured value on the worker pool", func() {
+			config.WorkerPool.MaxUnavailable = "25%"
+			mockMachineryClient.EXPECT().SetMaxUnavailable(gomock.Any(), "worker", intstr.FromString("25%"))
+			result, err := SetWorkerMaxUnavailable(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("leaves the worker pool alone when no value is configured", func() {
+			result, err := SetWorkerMaxUnavailable(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("indicates when the worker pool can not be changed", func() {
+			config.WorkerPool.MaxUnavailable = "3"
+			mockMachineryClient.EXPECT().SetMaxUnavailable(gomock.Any(), "worker", intstr.FromInt(3)).Return(fmt.Errorf("fake error"))
+			result, err := SetWorkerMaxUnavailable(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+		It("restores the original value once the workers have upgraded", func() {
+			mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker")
+			result, err := RestoreWorkerMaxUnavailable(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("indicates when the original value can not be restored", func() {
+			mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker").Return(fmt.Errorf("fake error"))
+			result, err := RestoreWorkerMaxUnavailable(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+		It("rejects an invalid value", func() {
+			config.WorkerPool.MaxUnavailable = "0"
+			Expect(config.WorkerPool.IsValid()).To(HaveOccurred())
+			config.WorkerPool.MaxUnavailable = "ten"
+			Expect(config.WorkerPool.IsValid()).To(HaveOccurred())
+		})
+	})
+
+	Context("When pausing the worker pool", func() {
+		BeforeEach(func() {
+			config.WorkerPool.Pause = true
+		})
+		It("pauses the pool before the upgrade commences", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMachineryClient.EXPECT().PausePool(gomock.Any(), "worker").Return(&machinery.PauseResult{PausedByUpgrade: true}, nil),
+			)
+			result, err := PauseWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("carries on when an admin has already paused the pool", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMachineryClient.EXPECT().PausePool(gomock.Any(), "worker").Return(&machinery.PauseResult{PausedByUpgrade: false}, nil),
+			)
+			result, err := PauseWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("does not pause the pool once the upgrade has commenced", func() {
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := PauseWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("does not pause the pool unless configured to", func() {
+			config.WorkerPool.Pause = false
+			result, err := PauseWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("resumes the pool once the control plane has upgraded", func() {
+			mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker")
+			result, err := ResumeWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("indicates when the pool can not be resumed", func() {
+			mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker").Return(fmt.Errorf("fake error"))
+			result, err := ResumeWorkerPool(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When checking PodDisruptionBudgets before commencing the upgrade", func() {
+		var pdbs policyv1beta1.PodDisruptionBudgetList
+		pdb := func(name string, allowed, desired, expected int32) policyv1beta1.PodDisruptionBudget {
+			return policyv1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+				Status: policyv1beta1.PodDisruptionBudgetStatus{
+					DisruptionsAllowed: allowed,
+					DesiredHealthy:     desired,
+					ExpectedPods:       expected,
+				},
+			}
+		}
+		JustBeforeEach(func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, pdbs)
+		})
+
+		Context("When no PodDisruptionBudget blocks draining", func() {
+			BeforeEach(func() {
+				pdbs = policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{
+					pdb("allows-one", 1, 2, 3),
+					// Scaling up, so no disruption is allowed until the new pods are healthy
+					pdb("scaling", 0, 2, 3),
+					pdb("no-pods", 0, 0, 0),
+				}}
+			})
+			It("passes the check", func() {
+				result, err := performPDBCheck(mockKubeClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("When a PodDisruptionBudget can never allow a disruption", func() {
+			BeforeEach(func() {
+				pdbs = policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{
+					pdb("allows-one", 1, 2, 3),
+					pdb("min-available-all", 0, 3, 3),
+				}}
+			})
+			It("only reports the PodDisruptionBudget by default", func() {
+				result, err := performPDBCheck(mockKubeClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("fails the check listing the PodDisruptionBudget when configured to", func() {
+				config.HealthCheck.PodDisruptionBudgets = "error"
+				result, err := performPDBCheck(mockKubeClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("test-namespace/min-available-all"))
+				Expect(err.Error()).NotTo(ContainSubstring("allows-one"))
+				Expect(result).To(BeFalse())
+			})
+			It("holds up the upgrade when configured to fail", func() {
+				config.HealthCheck.PodDisruptionBudgets = "error"
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+				result, err := PodDisruptionBudgetCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+		})
+	})
+
+	Context("When checking PodDisruptionBudgets after the upgrade has commenced", func() {
+		It("skips the check", func() {
+			config.HealthCheck.PodDisruptionBudgets = "error"
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+			result, err := PodDisruptionBudgetCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When asking the pre-upgrade hook for approval", func() {
+		var server *httptest.Server
+		var status int
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}))
+			config.UpgradeHook = upgradehook.Config{URL: server.URL, Attempts: 1}
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+		It("proceeds when the hook approves", func() {
+			status = http.StatusOK
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+			result, err := PreUpgradeHook(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("blocks the upgrade when the hook denies", func() {
+			status = http.StatusForbidden
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+			result, err := PreUpgradeHook(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(upgradehook.IsDeniedError(err)).To(BeTrue())
+			Expect(result).To(BeFalse())
+		})
+		It("does not ask once the upgrade has commenced", func() {
+			status = http.StatusForbidden
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := PreUpgradeHook(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("does not ask when no hook is configured", func() {
+			config.UpgradeHook = upgradehook.Config{}
+			result, err := PreUpgradeHook(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
 	Context("When running the external-dependency-availability-check phase", func() {
 		It("return true if all dependencies are available", func() {
 			gomock.InOrder(
@@ -351,7 +791,7 @@ var _ = Describe("ClusterUpgrader", func() {
 	Context("When running the send-started-notification phase", func() {
 		It("will send the correct notification", func() {
 			gomock.InOrder(
-				mockEMClient.EXPECT().Notify(notifier.StateStarted),
+				mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateStarted),
 			)
 			result, err := SendStartedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).NotTo(HaveOccurred())
@@ -360,7 +800,7 @@ var _ = Describe("ClusterUpgrader", func() {
 		It("will not succeed if it can't send the notification", func() {
 			fakeErr := fmt.Errorf("fake error")
 			gomock.InOrder(
-				mockEMClient.EXPECT().Notify(notifier.StateStarted).Return(fakeErr),
+				mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateStarted).Return(fakeErr),
 			)
 			result, err := SendStartedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).To(HaveOccurred())
@@ -371,7 +811,7 @@ var _ = Describe("ClusterUpgrader", func() {
 	Context("When running the send-completed-notification phase", func() {
 		It("will send the notification", func() {
 			gomock.InOrder(
-				mockEMClient.EXPECT().Notify(notifier.StateCompleted),
+				mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateCompleted),
 			)
 			result, err := SendCompletedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).NotTo(HaveOccurred())
@@ -380,7 +820,7 @@ var _ = Describe("ClusterUpgrader", func() {
 		It("will not succeed if it can't send the notification", func() {
 			fakeErr := fmt.Errorf("fake error")
 			gomock.InOrder(
-				mockEMClient.EXPECT().Notify(notifier.StateCompleted).Return(fakeErr),
+				mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateCompleted).Return(fakeErr),
 			)
 			result, err := SendCompletedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).To(HaveOccurred())
@@ -416,7 +856,7 @@ var _ = Describe("ClusterUpgrader", func() {
 				It("will send a notification", func() {
 					gomock.InOrder(
 						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
-						mockEMClient.EXPECT().Notify(notifier.StateDelayed).Return(nil),
+						mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateDelayed).Return(nil),
 					)
 
 					result, err := UpgradeDelayedCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
@@ -427,7 +867,7 @@ var _ = Describe("ClusterUpgrader", func() {
 					fakeError := fmt.Errorf("fake error")
 					gomock.InOrder(
 						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
-						mockEMClient.EXPECT().Notify(notifier.StateDelayed).Return(fakeError),
+						mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateDelayed).Return(fakeError),
 					)
 					result, err := UpgradeDelayedCheck(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 					Expect(err).To(HaveOccurred())
@@ -488,6 +928,7 @@ var _ = Describe("ClusterUpgrader", func() {
 				notifier:    mockEMClient,
 				cfg:         config,
 				scaler:      mockScalerClient,
+				machinery:   mockMachineryClient,
 			}
 			upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
 				{
@@ -495,6 +936,7 @@ var _ = Describe("ClusterUpgrader", func() {
 					Phase:   upgradev1alpha1.UpgradePhaseUpgrading,
 				},
 			}
+			mockMaintClient.EXPECT().RecordActiveSilences(mockMetricsClient, upgradeConfig.Name).AnyTimes()
 		})
 
 		Context("When a step does not occur in the history", func() {
@@ -512,6 +954,7 @@ var _ = Describe("ClusterUpgrader", func() {
 				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
 				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
 				Expect(stepHistoryReason).To(Equal(string(step1) + " not done"))
+				Expect(condition.StartTime).NotTo(BeNil())
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -542,6 +985,204 @@ var _ = Describe("ClusterUpgrader", func() {
 
 		})
 
+		Context("When running a step fails the upgrade", func() {
+			BeforeEach(func() {
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockUpgradeFailedStep(step1),
+				}
+			})
+			It("flags the upgrade as failed with the step's reason", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil),
+					mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+					mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+					mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+					mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker"),
+					mockMaintClient.EXPECT().EndControlPlane(),
+					mockMaintClient.EXPECT().EndAlerts(),
+					mockMaintClient.EXPECT().EndWorker(),
+					mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateFailed),
+					mockMetricsClient.EXPECT().ResetFailureMetrics(),
+				)
+				mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(gomock.Any()).Times(0)
+				phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				Expect(condition.Reason).To(Equal("Step failed the upgrade"))
+				Expect(condition.Message).To(ContainSubstring("step " + string(step1) + " failed the upgrade"))
+			})
+			It("removes the worker maintenance window when a degraded worker pool fails the upgrade", func() {
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: AllWorkersUpgraded,
+				}
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{
+						IsUpgrading:    true,
+						IsDegraded:     true,
+						DegradedReason: "Node worker-1 is reporting: \"failed to apply the configuration\"",
+						DegradedSince:  time.Now().Add(-20 * time.Minute),
+					}, nil),
+					mockMaintClient.EXPECT().IsActive().Return(true, nil),
+					mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+					mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+					mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+					mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker"),
+					mockMaintClient.EXPECT().EndControlPlane(),
+					mockMaintClient.EXPECT().EndAlerts(),
+					mockMaintClient.EXPECT().EndWorker(),
+					mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateFailed),
+					mockMetricsClient.EXPECT().ResetFailureMetrics(),
+				)
+				phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+				Expect(condition.Reason).To(Equal("Worker pool degraded"))
+			})
+			It("tries again next time if the failure routine can not be completed", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil),
+					mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("fake error")),
+				)
+				phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			})
+		})
+
+		Context("When reporting the progress of the upgrade", func() {
+			var step2 = upgradev1alpha1.UpgradePreHealthCheck
+			BeforeEach(func() {
+				cu.Ordering = []upgradev1alpha1.UpgradeConditionType{step1, step2}
+			})
+
+			It("advances the progress as steps complete", func() {
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockUnsucceededStep(step1),
+					step2: makeMockUnsucceededStep(step2),
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil).Times(2)
+				_, _, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				progress := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Progress
+				Expect(progress).NotTo(BeNil())
+				Expect(progress.Step).To(Equal(step1))
+				Expect(progress.StepIndex).To(Equal(1))
+				Expect(progress.TotalSteps).To(Equal(2))
+				Expect(progress.StartTime).NotTo(BeNil())
+
+				cu.Steps[step1] = makeMockSucceedStep(step1)
+				_, _, err = cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				progress = upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Progress
+				Expect(progress.Step).To(Equal(step2))
+				Expect(progress.StepIndex).To(Equal(2))
+				Expect(progress.TotalSteps).To(Equal(2))
+			})
+
+			It("reports the step's start time from its condition", func() {
+				stepStart := &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+				upgradeConfig.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{Type: step1, Status: corev1.ConditionFalse, StartTime: stepStart},
+				}
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockUnsucceededStep(step1),
+					step2: makeMockUnsucceededStep(step2),
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				_, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				progress := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Progress
+				Expect(progress.StartTime).To(Equal(stepStart))
+				Expect(progress.StartTime).To(Equal(condition.StartTime))
+			})
+
+			It("reports the last step once the upgrade completes", func() {
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockSucceedStep(step1),
+					step2: makeMockSucceedStep(step2),
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				phase, _, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
+				progress := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Progress
+				Expect(progress.Step).To(Equal(step2))
+				Expect(progress.StepIndex).To(Equal(progress.TotalSteps))
+			})
+		})
+
+		Context("When a step has been incomplete for longer than its timeout", func() {
+			var stepStart *metav1.Time
+			BeforeEach(func() {
+				stepStart = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+				config.StepTimeouts = stepTimeouts{Steps: map[upgradev1alpha1.UpgradeConditionType]int{step1: 60}}
+				upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+					{
+						Version: upgradeConfig.Spec.Desired.Version,
+						Phase:   upgradev1alpha1.UpgradePhaseUpgrading,
+						Conditions: []upgradev1alpha1.UpgradeCondition{
+							{
+								Type:      step1,
+								Status:    corev1.ConditionFalse,
+								Reason:    string(step1) + " not done",
+								StartTime: stepStart,
+							},
+						},
+					},
+				}
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockUnsucceededStep(step1),
+				}
+			})
+
+			It("fails the step, naming it", func() {
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(string(step1)))
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+				Expect(condition.Reason).To(Equal(string(step1) + " timed out"))
+				Expect(condition.StartTime).To(Equal(stepStart))
+				Expect(stepCounter[step1]).To(Equal(1))
+			})
+
+			It("keeps the step running within the default timeout", func() {
+				config.StepTimeouts = stepTimeouts{}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				_, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Reason).To(Equal(string(step1) + " not done"))
+				Expect(condition.StartTime).To(Equal(stepStart))
+			})
+
+			It("does not time out a step waiting on the cluster to upgrade by default", func() {
+				config.StepTimeouts = stepTimeouts{}
+				stepStart.Time = time.Now().Add(-24 * time.Hour)
+				upgradeConfig.Status.History[0].Conditions[0].Type = upgradev1alpha1.ControlPlaneUpgraded
+				cu.Ordering = []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.ControlPlaneUpgraded}
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					upgradev1alpha1.ControlPlaneUpgraded: makeMockUnsucceededStep(upgradev1alpha1.ControlPlaneUpgraded),
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				_, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Reason).To(Equal(string(upgradev1alpha1.ControlPlaneUpgraded) + " not done"))
+			})
+
+			It("carries on once the step completes", func() {
+				cu.Steps = map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					step1: makeMockSucceedStep(step1),
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+				phase, _, err := cu.UpgradeCluster(upgradeConfig, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
+			})
+		})
+
 		Context("When all steps have indicated completion", func() {
 			BeforeEach(func() {
 				upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
@@ -592,13 +1233,42 @@ var _ = Describe("ClusterUpgrader", func() {
 					gomock.InOrder(
 						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
 						mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
-						mockEMClient.EXPECT().Notify(notifier.StateFailed),
-						mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
+						mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+						mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+						mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker"),
+						mockMaintClient.EXPECT().EndControlPlane(),
+						mockMaintClient.EXPECT().EndAlerts(),
+						mockMaintClient.EXPECT().EndWorker(),
+						mockEMClient.EXPECT().Notify(upgradeConfig, notifier.StateFailed),
 						mockMetricsClient.EXPECT().ResetFailureMetrics(),
+						mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
 					)
 					phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
 					Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
 					Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+					Expect(condition.Reason).To(Equal("Upgrade window exceeded"))
+					Expect(err).NotTo(HaveOccurred())
+				})
+				It("tries again next time if the maintenance windows can not be removed", func() {
+					gomock.InOrder(
+						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+						mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+						mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+						mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+						mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker"),
+						mockMaintClient.EXPECT().EndControlPlane().Return(fmt.Errorf("fake error")),
+					)
+					phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+					Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+					Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+					Expect(err).NotTo(HaveOccurred())
+				})
+				It("does not abort once the upgrade has passed the point of no return", func() {
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+					phase, condition, err := cu.UpgradeCluster(upgradeConfig, logger)
+					Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
+					Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+					Expect(stepCounter[step1]).To(Equal(1))
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
@@ -606,6 +1276,157 @@ var _ = Describe("ClusterUpgrader", func() {
 
 	})
 
+	Context("When cleaning up an upgrade", func() {
+		var cu *osdClusterUpgrader
+		BeforeEach(func() {
+			cu = &osdClusterUpgrader{
+				client:      mockKubeClient,
+				maintenance: mockMaintClient,
+				scaler:      mockScalerClient,
+				machinery:   mockMachineryClient,
+			}
+		})
+
+		It("removes the extra nodes, worker pool settings and maintenance windows", func() {
+			gomock.InOrder(
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+				mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+				mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker"),
+				mockMaintClient.EXPECT().EndControlPlane(),
+				mockMaintClient.EXPECT().EndWorker(),
+				mockMaintClient.EXPECT().EndAlerts(),
+			)
+			err := cu.CleanupUpgrade(upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("attempts every cleanup even if one fails", func() {
+			gomock.InOrder(
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(false, fmt.Errorf("fake error")),
+				mockMachineryClient.EXPECT().ResumePool(gomock.Any(), "worker"),
+				mockMachineryClient.EXPECT().RestoreMaxUnavailable(gomock.Any(), "worker"),
+				mockMachineryClient.EXPECT().UncordonNodes(gomock.Any(), "worker").Return(fmt.Errorf("fake error")),
+				mockMaintClient.EXPECT().EndControlPlane().Return(fmt.Errorf("fake error")),
+				mockMaintClient.EXPECT().EndWorker(),
+				mockMaintClient.EXPECT().EndAlerts(),
+			)
+			err := cu.CleanupUpgrade(upgradeConfig, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to scale down the extra upgrade nodes"))
+			Expect(err.Error()).To(ContainSubstring("failed to uncordon the worker nodes"))
+			Expect(err.Error()).To(ContainSubstring("failed to remove the control plane maintenance window"))
+		})
+	})
+
+	Context("When configuring the upgrade steps", func() {
+		It("runs every step by default", func() {
+			steps := stepConfig{}
+			Expect(steps.IsValid()).To(Succeed())
+			Expect(steps.GetOrdering(osdUpgradeStepOrdering)).To(Equal(UpgradeStepOrdering(osdUpgradeStepOrdering)))
+		})
+
+		It("skips a disabled optional step", func() {
+			steps := stepConfig{Disabled: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.UpgradeScaleUpExtraNodes}}
+			Expect(steps.IsValid()).To(Succeed())
+			ordering := steps.GetOrdering(osdUpgradeStepOrdering)
+			Expect(ordering).NotTo(ContainElement(upgradev1alpha1.UpgradeScaleUpExtraNodes))
+			Expect(ordering).To(HaveLen(len(osdUpgradeStepOrdering) - 1))
+		})
+
+		It("rejects disabling a mandatory step", func() {
+			steps := stepConfig{Disabled: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.CommenceUpgrade}}
+			err := steps.IsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("CommenceUpgrade is mandatory"))
+		})
+
+		It("rejects disabling a step which undoes an earlier one", func() {
+			for _, step := range []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.RemoveControlPlaneMaintWindow, upgradev1alpha1.RemoveExtraScaledNodes} {
+				steps := stepConfig{Disabled: []upgradev1alpha1.UpgradeConditionType{step}}
+				Expect(steps.IsValid()).NotTo(Succeed(), "step %s", step)
+			}
+		})
+
+		It("rejects disabling an unknown step", func() {
+			steps := stepConfig{Disabled: []upgradev1alpha1.UpgradeConditionType{"NotAStep"}}
+			Expect(steps.IsValid()).NotTo(Succeed())
+		})
+
+		It("reorders the listed pre-upgrade checks in place", func() {
+			steps := stepConfig{Order: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.EtcdBackupVerified, upgradev1alpha1.UpgradePreHealthCheck}}
+			Expect(steps.IsValid()).To(Succeed())
+			Expect(steps.GetOrdering(osdUpgradeStepOrdering)[:7]).To(Equal(UpgradeStepOrdering{
+				upgradev1alpha1.SendStartedNotification,
+				upgradev1alpha1.UpgradeDelayedCheck,
+				upgradev1alpha1.EtcdBackupVerified,
+				upgradev1alpha1.ExtDepAvailabilityCheck,
+				upgradev1alpha1.UpgradePreHealthCheck,
+				upgradev1alpha1.PodDisruptionBudgetsChecked,
+				upgradev1alpha1.PreUpgradeHookApproved,
+			}))
+		})
+
+		It("rejects reordering a step outside the pre-upgrade checks", func() {
+			steps := stepConfig{Order: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.CommenceUpgrade, upgradev1alpha1.UpgradePreHealthCheck}}
+			Expect(steps.IsValid()).NotTo(Succeed())
+		})
+
+		It("rejects a step listed twice in the order", func() {
+			steps := stepConfig{Order: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.EtcdBackupVerified, upgradev1alpha1.EtcdBackupVerified}}
+			Expect(steps.IsValid()).NotTo(Succeed())
+		})
+	})
+
+	Context("When steps are behind feature gates", func() {
+		BeforeEach(func() {
+			featureGateRegistry["TestGate"] = featureGate{Steps: []upgradev1alpha1.UpgradeConditionType{upgradev1alpha1.UpdateSubscriptions}}
+		})
+		AfterEach(func() {
+			delete(featureGateRegistry, "TestGate")
+		})
+
+		It("leaves out a gated step while its gate is disabled by default", func() {
+			gates := featureGates{}
+			Expect(gates.IsValid()).To(Succeed())
+			Expect(gates.GateOrdering(osdUpgradeStepOrdering)).NotTo(ContainElement(upgradev1alpha1.UpdateSubscriptions))
+		})
+
+		It("includes a gated step once its gate is enabled", func() {
+			gates := featureGates{"TestGate": true}
+			Expect(gates.IsValid()).To(Succeed())
+			Expect(gates.GateOrdering(osdUpgradeStepOrdering)).To(ContainElement(upgradev1alpha1.UpdateSubscriptions))
+		})
+
+		It("leaves out the experimental steps when the config does not enable them", func() {
+			gates := featureGates{}
+			ordering := gates.GateOrdering(osdUpgradeStepOrdering)
+			Expect(ordering).NotTo(ContainElement(upgradev1alpha1.EtcdBackupVerified))
+			Expect(ordering).NotTo(ContainElement(upgradev1alpha1.PauseWorkerPool))
+		})
+
+		It("runs every step once all gates are enabled", func() {
+			gates := featureGates{"TestGate": true, "EtcdBackupVerification": true, "WorkerPoolPause": true}
+			Expect(gates.IsValid()).To(Succeed())
+			Expect(gates.GateOrdering(osdUpgradeStepOrdering)).To(Equal(UpgradeStepOrdering(osdUpgradeStepOrdering)))
+		})
+
+		It("rejects an unknown gate", func() {
+			gates := featureGates{"NotAGate": true}
+			err := gates.IsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NotAGate is unknown"))
+		})
+
+		It("does not gate mandatory steps", func() {
+			for name, gate := range featureGateRegistry {
+				for _, step := range gate.Steps {
+					Expect(containsStep(mandatorySteps, step)).To(BeFalse(), "gate %s gates mandatory step %s", name, step)
+				}
+			}
+		})
+	})
+
 	Context("Unit tests", func() {
 
 		Context("When creating an UpgradeCondition", func() {
@@ -645,3 +1466,10 @@ func makeMockFailedStep(step upgradev1alpha1.UpgradeConditionType) UpgradeStep {
 		return false, fmt.Errorf("step %s failed", step)
 	}
 }
+
+func makeMockUpgradeFailedStep(step upgradev1alpha1.UpgradeConditionType) UpgradeStep {
+	return func(c client.Client, config *osdUpgradeConfig, scaler scaler.Scaler, drainBuilder drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, emClient em.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+		stepCounter[step] += 1
+		return false, &upgradeFailedError{reason: "Step failed the upgrade", message: fmt.Sprintf("step %s failed the upgrade", step)}
+	}
+}
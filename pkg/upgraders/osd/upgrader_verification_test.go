@@ -3,6 +3,7 @@ package osd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	acMocks "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks/mocks"
@@ -11,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -20,6 +22,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	mockDrain "github.com/openshift/managed-upgrade-operator/pkg/drain/mocks"
 	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
@@ -284,6 +287,51 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 		})
 	})
 
+	Context("When waiting for cluster operators to become available", func() {
+		It("completes when all operators are available", func() {
+			mockCVClient.EXPECT().GetUnavailableOperators().Return(&clusterversion.UnavailableOperatorsResult{Unavailable: []string{}}, nil)
+			result, err := ClusterOperatorsAvailable(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+
+		It("keeps waiting while operators are unavailable within the timeout", func() {
+			mockCVClient.EXPECT().GetUnavailableOperators().Return(&clusterversion.UnavailableOperatorsResult{Unavailable: []string{"dns (Degraded=True)"}}, nil)
+			result, err := ClusterOperatorsAvailable(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("fails, naming the operators, once the timeout has passed", func() {
+			config.Verification.ClusterOperatorTimeout = 10
+			upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+				{
+					Version: upgradeConfig.Spec.Desired.Version,
+					Phase:   upgradev1alpha1.UpgradePhaseUpgrading,
+					Conditions: []upgradev1alpha1.UpgradeCondition{
+						{
+							Type:      upgradev1alpha1.ClusterOperatorsAvailable,
+							Status:    corev1.ConditionFalse,
+							StartTime: &v1.Time{Time: time.Now().Add(-20 * time.Minute)},
+						},
+					},
+				},
+			}
+			mockCVClient.EXPECT().GetUnavailableOperators().Return(&clusterversion.UnavailableOperatorsResult{Unavailable: []string{"dns (Degraded=True)", "ingress (Available=False)"}}, nil)
+			result, err := ClusterOperatorsAvailable(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dns (Degraded=True), ingress (Available=False)"))
+			Expect(result).To(BeFalse())
+		})
+
+		It("surfaces errors listing the operators", func() {
+			mockCVClient.EXPECT().GetUnavailableOperators().Return(nil, fmt.Errorf("fake error"))
+			result, err := ClusterOperatorsAvailable(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+	})
+
 	Context("When the cluster healthy", func() {
 		Context("When no critical alerts are firing", func() {
 			var alertsResponse *metrics.AlertResponse
@@ -300,6 +348,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 						return &metrics.AlertResponse{}, nil
 					})
 				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil)
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.NodeList{})
 				mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
 				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(Not(HaveOccurred()))
@@ -310,6 +359,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.NodeList{}),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
 				// Pre-upgrade
@@ -340,6 +390,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.NodeList{}),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
 				// Pre-upgrade
@@ -394,6 +445,112 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 			})
 		})
 
+		Context("When configured to ignore some firing critical alerts", func() {
+			BeforeEach(func() {
+				config.HealthCheck.IgnoredAlerts = []map[string]string{
+					{"alertname": "KnownBenign"},
+					{"alertname": "NoisyOperator", "namespace": "openshift-noisy"},
+				}
+			})
+			respondWith := func(alerts ...map[string]string) *metrics.AlertResponse {
+				response := &metrics.AlertResponse{}
+				for _, labels := range alerts {
+					response.Data.Result = append(response.Data.Result, metrics.AlertResult{Metric: labels})
+				}
+				return response
+			}
+			It("will pass when only ignored alerts are firing", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(respondWith(
+						map[string]string{"alertname": "KnownBenign", "namespace": "openshift-monitoring"},
+						map[string]string{"alertname": "NoisyOperator", "namespace": "openshift-noisy"},
+					), nil),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will fail when an alert is not on the ignore list", func() {
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(respondWith(
+					map[string]string{"alertname": "KnownBenign"},
+					map[string]string{"alertname": "KubeAPIDown", "namespace": "openshift-kube-apiserver"},
+				), nil)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("there are 1 critical alerts"))
+				Expect(result).To(BeFalse())
+			})
+			It("will fail when only some of an ignored alert's labels match", func() {
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(respondWith(
+					map[string]string{"alertname": "NoisyOperator", "namespace": "openshift-other"},
+				), nil)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("will reject an ignore entry without labels", func() {
+				config.HealthCheck.IgnoredAlerts = append(config.HealthCheck.IgnoredAlerts, map[string]string{})
+				Expect(config.HealthCheck.IsValid()).To(HaveOccurred())
+			})
+		})
+
+		Context("When configured to block on pending critical alerts", func() {
+			BeforeEach(func() {
+				config.HealthCheck.PendingAlertThreshold = 10
+			})
+			pendingFor := func(duration time.Duration) *metrics.AlertResponse {
+				since := time.Now().Add(-duration)
+				return &metrics.AlertResponse{
+					Data: metrics.AlertData{
+						Result: []metrics.AlertResult{
+							{
+								Metric: map[string]string{"alertname": "KubeAPIErrorBudgetBurn", "namespace": "openshift-kube-apiserver"},
+								Value:  []interface{}{float64(time.Now().Unix()), fmt.Sprintf("%d", since.Unix())},
+							},
+						},
+					},
+				}
+			}
+			It("will pass when alerts have been pending for less than the threshold", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+					mockMetricsClient.EXPECT().Query(gomock.Any()).DoAndReturn(func(query string) (*metrics.AlertResponse, error) {
+						Expect(query).To(ContainSubstring(`alertstate="pending"`))
+						return pendingFor(5 * time.Minute), nil
+					}),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will fail when alerts have been pending for longer than the threshold", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(pendingFor(15*time.Minute), nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("there are 1 critical alerts pending for longer than 10m0s"))
+				Expect(result).To(BeFalse())
+			})
+			It("will not query pending alerts by default", func() {
+				config.HealthCheck.PendingAlertThreshold = 0
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil).Times(1),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will reject a negative threshold", func() {
+				config.HealthCheck.PendingAlertThreshold = -1
+				Expect(config.HealthCheck.IsValid()).To(HaveOccurred())
+			})
+		})
+
 		Context("When operators are degraded", func() {
 			var alertsResponse *metrics.AlertResponse
 
@@ -424,6 +581,88 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 		})
 	})
 
+	Context("When checking the readiness of nodes", func() {
+		var nodes corev1.NodeList
+		node := func(name string, ready corev1.ConditionStatus, unschedulable bool, annotations map[string]string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: v1.ObjectMeta{Name: name, Annotations: annotations},
+				Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				},
+			}
+		}
+		JustBeforeEach(func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, nodes)
+		})
+
+		Context("When all nodes are healthy", func() {
+			BeforeEach(func() {
+				nodes = corev1.NodeList{Items: []corev1.Node{
+					node("node-1", corev1.ConditionTrue, false, nil),
+					node("node-2", corev1.ConditionTrue, false, nil),
+				}}
+			})
+			It("will pass", func() {
+				result, err := performNodeReadinessCheck(mockKubeClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("When nodes are NotReady or cordoned", func() {
+			BeforeEach(func() {
+				nodes = corev1.NodeList{Items: []corev1.Node{
+					node("node-1", corev1.ConditionTrue, false, nil),
+					node("node-2", corev1.ConditionFalse, false, nil),
+					node("node-3", corev1.ConditionTrue, true, nil),
+				}}
+			})
+			It("will fail listing the offending nodes", func() {
+				result, err := performNodeReadinessCheck(mockKubeClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("node-2 (NotReady), node-3 (unschedulable)"))
+				Expect(result).To(BeFalse())
+			})
+			It("will not satisfy a pre-Upgrade health check", func() {
+				gomock.InOrder(
+					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
+				)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("will only warn when configured to", func() {
+				config.HealthCheck.NodeReadiness = "warn"
+				result, err := performNodeReadinessCheck(mockKubeClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("When nodes are cordoned for maintenance", func() {
+			BeforeEach(func() {
+				nodes = corev1.NodeList{Items: []corev1.Node{
+					node("node-1", corev1.ConditionTrue, false, nil),
+					node("node-2", corev1.ConditionFalse, true, map[string]string{machinery.NodeMaintenanceAnnotation: "true"}),
+				}}
+			})
+			It("will pass", func() {
+				result, err := performNodeReadinessCheck(mockKubeClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
+	})
+
+	It("will reject an invalid node readiness mode", func() {
+		config.HealthCheck.NodeReadiness = "ignore"
+		Expect(config.HealthCheck.IsValid()).To(HaveOccurred())
+	})
+
 	Context("When Prometheus can't be queried successfully", func() {
 		var fakeError = fmt.Errorf("fake MetricsClient query error")
 		BeforeEach(func() {
@@ -451,4 +690,67 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 			Expect(result).To(BeFalse())
 		})
 	})
+
+	Context("When planning an upgrade as a dry run", func() {
+		var cu *osdClusterUpgrader
+		BeforeEach(func() {
+			cu = &osdClusterUpgrader{
+				Steps: map[upgradev1alpha1.UpgradeConditionType]UpgradeStep{
+					upgradev1alpha1.SendStartedNotification: makeMockSucceedStep(upgradev1alpha1.SendStartedNotification),
+					upgradev1alpha1.UpgradePreHealthCheck:   makeMockSucceedStep(upgradev1alpha1.UpgradePreHealthCheck),
+					upgradev1alpha1.EtcdBackupVerified:      makeMockFailedStep(upgradev1alpha1.EtcdBackupVerified),
+					upgradev1alpha1.CommenceUpgrade:         makeMockSucceedStep(upgradev1alpha1.CommenceUpgrade),
+				},
+				Ordering: []upgradev1alpha1.UpgradeConditionType{
+					upgradev1alpha1.SendStartedNotification,
+					upgradev1alpha1.UpgradePreHealthCheck,
+					upgradev1alpha1.EtcdBackupVerified,
+					upgradev1alpha1.CommenceUpgrade,
+				},
+				client:      mockKubeClient,
+				maintenance: mockMaintClient,
+				metrics:     mockMetricsClient,
+				cvClient:    mockCVClient,
+				notifier:    mockEMClient,
+				cfg:         config,
+				scaler:      mockScaler,
+				machinery:   mockMachinery,
+			}
+		})
+
+		It("reports the ordered steps and pre-flight check results without changing the cluster", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, corev1.NodeList{}),
+			)
+			mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			mockKubeClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(gomock.Any()).Times(0)
+			mockEMClient.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(0)
+
+			steps, checks, err := cu.PlanUpgrade(upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(Equal([]upgradev1alpha1.UpgradeConditionType(cu.Ordering)))
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[0].Type).To(Equal(upgradev1alpha1.UpgradePreHealthCheck))
+			Expect(checks[0].IsTrue()).To(BeTrue())
+			Expect(checks[1].Type).To(Equal(upgradev1alpha1.EtcdBackupVerified))
+			Expect(checks[1].IsFalse()).To(BeTrue())
+			Expect(checks[1].Message).To(Equal("step EtcdBackupVerified failed"))
+			Expect(stepCounter[upgradev1alpha1.SendStartedNotification]).To(Equal(0))
+			Expect(stepCounter[upgradev1alpha1.UpgradePreHealthCheck]).To(Equal(0))
+			Expect(stepCounter[upgradev1alpha1.CommenceUpgrade]).To(Equal(0))
+		})
+
+		It("reports a failed health check", func() {
+			mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake error"))
+			_, checks, err := cu.PlanUpgrade(upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks[0].IsFalse()).To(BeTrue())
+			Expect(checks[0].Message).To(ContainSubstring("unable to query critical alerts"))
+		})
+	})
 })
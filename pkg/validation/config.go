@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+const (
+	// ModeError fails validation when a check is not met
+	ModeError = "error"
+	// ModeWarn only logs when a check is not met, such as on clusters which cannot reach
+	// the update service
+	ModeWarn = "warn"
+)
+
+const (
+	defaultUpgradeAtGracePeriod = 10 * time.Minute
+	// Workers may run kubelets one minor version behind the control plane
+	defaultMaxWorkerSkew = 1
+)
+
+type Config struct {
+	Validation ValidationConfig `yaml:"validation"`
+}
+
+type ValidationConfig struct {
+	// AvailableUpdates is how a desired version which is not offered in its channel is treated
+	AvailableUpdates string `yaml:"availableUpdates" default:"error"`
+	// PastUpgradeAt is how an upgradeAt further in the past than the grace period is treated
+	PastUpgradeAt string `yaml:"pastUpgradeAt" default:"error"`
+	// UpgradeAtGracePeriod is the number of minutes an upgradeAt may be in the past
+	UpgradeAtGracePeriod int `yaml:"upgradeAtGracePeriod" default:"10"`
+	// VersionSkew is the supported skew between the control plane and worker versions
+	VersionSkew VersionSkewConfig `yaml:"versionSkew"`
+}
+
+type VersionSkewConfig struct {
+	// MaxWorkerSkew is the number of minor versions workers may lag the control plane
+	MaxWorkerSkew int `yaml:"maxWorkerSkew" default:"1"`
+	// Versions overrides MaxWorkerSkew for control plane versions, keyed by major.minor version such as "4.6"
+	Versions map[string]int `yaml:"versions"`
+}
+
+func (cfg *Config) IsValid() error {
+	if !isValidMode(cfg.GetAvailableUpdatesMode()) {
+		return fmt.Errorf("config validation availableUpdates mode %s is invalid", cfg.Validation.AvailableUpdates)
+	}
+	if !isValidMode(cfg.GetPastUpgradeAtMode()) {
+		return fmt.Errorf("config validation pastUpgradeAt mode %s is invalid", cfg.Validation.PastUpgradeAt)
+	}
+	if cfg.Validation.UpgradeAtGracePeriod < 0 {
+		return fmt.Errorf("config validation upgradeAt grace period is invalid")
+	}
+	if cfg.Validation.VersionSkew.MaxWorkerSkew < 0 {
+		return fmt.Errorf("config validation max worker skew is invalid")
+	}
+	for version, skew := range cfg.Validation.VersionSkew.Versions {
+		if _, err := semver.ParseTolerant(version); err != nil || strings.Count(version, ".") != 1 {
+			return fmt.Errorf("config validation version skew for %s must be keyed by a major.minor version", version)
+		}
+		if skew < 0 {
+			return fmt.Errorf("config validation version skew for %s is invalid", version)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) GetAvailableUpdatesMode() string {
+	return getMode(cfg.Validation.AvailableUpdates)
+}
+
+func (cfg *Config) GetPastUpgradeAtMode() string {
+	return getMode(cfg.Validation.PastUpgradeAt)
+}
+
+func (cfg *Config) GetUpgradeAtGracePeriod() time.Duration {
+	if cfg.Validation.UpgradeAtGracePeriod == 0 {
+		return defaultUpgradeAtGracePeriod
+	}
+	return time.Duration(cfg.Validation.UpgradeAtGracePeriod) * time.Minute
+}
+
+// GetMaxWorkerSkew returns the number of minor versions workers may lag a control plane at the version
+func (cfg *Config) GetMaxWorkerSkew(version semver.Version) int {
+	if skew, ok := cfg.Validation.VersionSkew.Versions[fmt.Sprintf("%d.%d", version.Major, version.Minor)]; ok {
+		return skew
+	}
+	if cfg.Validation.VersionSkew.MaxWorkerSkew == 0 {
+		return defaultMaxWorkerSkew
+	}
+	return cfg.Validation.VersionSkew.MaxWorkerSkew
+}
+
+func getMode(mode string) string {
+	if mode == "" {
+		return ModeError
+	}
+	return strings.ToLower(mode)
+}
+
+func isValidMode(mode string) bool {
+	return mode == ModeError || mode == ModeWarn
+}
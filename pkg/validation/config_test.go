@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/blang/semver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation config", func() {
+	It("defaults to failing validation when the version is not offered", func() {
+		cfg := &Config{}
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetAvailableUpdatesMode()).To(Equal(ModeError))
+	})
+
+	It("accepts the warn mode in any case", func() {
+		cfg := &Config{Validation: ValidationConfig{AvailableUpdates: "Warn"}}
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetAvailableUpdatesMode()).To(Equal(ModeWarn))
+	})
+
+	It("rejects unknown modes", func() {
+		Expect((&Config{Validation: ValidationConfig{AvailableUpdates: "ignore"}}).IsValid()).NotTo(Succeed())
+		Expect((&Config{Validation: ValidationConfig{PastUpgradeAt: "ignore"}}).IsValid()).NotTo(Succeed())
+	})
+
+	It("defaults the upgradeAt grace period", func() {
+		Expect((&Config{}).GetUpgradeAtGracePeriod()).To(Equal(10 * time.Minute))
+		Expect((&Config{Validation: ValidationConfig{UpgradeAtGracePeriod: 30}}).GetUpgradeAtGracePeriod()).To(Equal(30 * time.Minute))
+		Expect((&Config{Validation: ValidationConfig{UpgradeAtGracePeriod: -1}}).IsValid()).NotTo(Succeed())
+	})
+
+	It("looks up the worker skew by the control plane's minor version", func() {
+		cfg := &Config{Validation: ValidationConfig{VersionSkew: VersionSkewConfig{Versions: map[string]int{"4.6": 2}}}}
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetMaxWorkerSkew(semver.MustParse("4.6.3"))).To(Equal(2))
+		Expect(cfg.GetMaxWorkerSkew(semver.MustParse("4.5.3"))).To(Equal(1))
+	})
+
+	It("rejects skews which are not keyed by a minor version", func() {
+		Expect((&Config{Validation: ValidationConfig{VersionSkew: VersionSkewConfig{Versions: map[string]int{"4.6.1": 2}}}}).IsValid()).NotTo(Succeed())
+		Expect((&Config{Validation: ValidationConfig{VersionSkew: VersionSkewConfig{MaxWorkerSkew: -1}}}).IsValid()).NotTo(Succeed())
+	})
+})
@@ -2,9 +2,11 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -12,13 +14,23 @@ import (
 	"github.com/google/uuid"
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/cluster-version-operator/pkg/cincinnati"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 )
 
 const (
 	defaultUpstreamServer = "https://api.openshift.com/api/upgrades_info/v1/graph"
+	// Pre-release versions are only published to channels with this prefix
+	preReleaseChannelPrefix = "candidate-"
+	masterNodeRoleLabel     = "node-role.kubernetes.io/master"
+	// Bounds of PDBForceDrainTimeout, in minutes. Shorter timeouts force drains before
+	// workloads have had a chance to move, and longer timeouts outlast the upgrade window.
+	minPDBForceDrainTimeout = 5
+	maxPDBForceDrainTimeout = 480
 )
 
 // NewBuilder returns a validationBuilder object that implements the ValidationBuilder interface.
@@ -32,7 +44,13 @@ type Validator interface {
 	IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *configv1.ClusterVersion, logger logr.Logger) (ValidatorResult, error)
 }
 
-type validator struct{}
+type validator struct {
+	client client.Client
+	cfg    Config
+	// fetchUpdates retrieves the updates offered in a channel the cluster is not subscribed to,
+	// defaulting to the upstream update service
+	fetchUpdates func(cV *configv1.ClusterVersion, channel string, currentVersion semver.Version) ([]configv1.Update, error)
+}
 
 type ValidatorResult struct {
 	// Indicates that the UpgradeConfig is semantically and syntactically valid
@@ -54,12 +72,36 @@ const (
 
 func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *configv1.ClusterVersion, logger logr.Logger) (ValidatorResult, error) {
 	// Validate upgradeAt as RFC3339
-	_, err := time.Parse(time.RFC3339, uC.Spec.UpgradeAt)
+	upgradeAt, err := time.Parse(time.RFC3339, uC.Spec.UpgradeAt)
 	if err != nil {
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Failed to parse upgradeAt:%s during validation", uC.Spec.UpgradeAt),
+			Message:           fmt.Sprintf("Failed to parse upgradeAt %q as an RFC3339 timestamp such as 2020-10-01T12:00:00Z: %v", uC.Spec.UpgradeAt, err),
+		}, nil
+	}
+
+	// An upgradeAt already in the past when the UpgradeConfig was first observed would commence
+	// the upgrade as soon as it is scheduled. One which has since passed is expected.
+	if !isScheduled(uC) && firstObserved(uC).Sub(upgradeAt) > v.cfg.GetUpgradeAtGracePeriod() {
+		msg := fmt.Sprintf("upgradeAt %s was more than %s in the past when the UpgradeConfig was first observed", uC.Spec.UpgradeAt, v.cfg.GetUpgradeAtGracePeriod())
+		if v.cfg.GetPastUpgradeAtMode() != ModeWarn {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           msg,
+			}, nil
+		}
+		logger.Info(msg + ", proceeding regardless")
+	}
+
+	// Validate the PDB force drain timeout is within bounds
+	pdbTimeout := uC.Spec.PDBForceDrainTimeout
+	if pdbTimeout < minPDBForceDrainTimeout || pdbTimeout > maxPDBForceDrainTimeout {
+		return ValidatorResult{
+			IsValid:           false,
+			IsAvailableUpdate: false,
+			Message:           fmt.Sprintf("PDBForceDrainTimeout %d is invalid, it must be between %d and %d minutes", pdbTimeout, minPDBForceDrainTimeout, maxPDBForceDrainTimeout),
 		}, nil
 	}
 
@@ -88,7 +130,16 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Failed to parse desired version %s as semver", version),
+			Message:           fmt.Sprintf("Failed to parse current version %s as semver", version),
+		}, nil
+	}
+
+	// Pre-release versions such as release candidates are not offered in stable channels
+	if len(desiredVersion.Pre) > 0 && !strings.HasPrefix(uC.Spec.Desired.Channel, preReleaseChannelPrefix) {
+		return ValidatorResult{
+			IsValid:           false,
+			IsAvailableUpdate: false,
+			Message:           fmt.Sprintf("Desired version %s is a pre-release, which is only available in %s channels, not %s", desiredVersion, preReleaseChannelPrefix+"*", uC.Spec.Desired.Channel),
 		}, nil
 	}
 
@@ -111,65 +162,75 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 		}, nil
 	case VersionDowngrade:
 		return ValidatorResult{
-			IsValid:           true,
+			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Downgrades to desired version %s from %s are unsupported", desiredVersion, currentVersion),
+			Message:           fmt.Sprintf("Desired version %s is lower than the current version %s: downgrades are unsupported", desiredVersion, currentVersion),
 		}, nil
 	case VersionEqual:
+		// The cluster is already at the desired version, so there is nothing to do, but the
+		// UpgradeConfig is not at fault
 		return ValidatorResult{
 			IsValid:           true,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Desired version %s matches the current version %s", desiredVersion, currentVersion),
+			Message:           fmt.Sprintf("Desired version %s is already the current version %s, there is nothing to upgrade", desiredVersion, currentVersion),
 		}, nil
 	case VersionUpgrade:
 		logger.Info(fmt.Sprintf("Desired version %s validated as greater then current version %s", desiredVersion, currentVersion))
 	}
 
-	// Validate available version is in Cincinnati.
-	desiredChannel := uC.Spec.Desired.Channel
-	clusterId, err := uuid.Parse(string(cV.Spec.ClusterID))
+	// Validate the upgrade would not leave the workers too far behind the control plane.
+	skew, err := v.checkVersionSkew(desiredVersion, currentVersion)
 	if err != nil {
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           "",
-		}, nil
+			Message:           "Failed to get the versions of the cluster's nodes during validation",
+		}, err
 	}
-	upstreamURI, err := url.Parse(getUpstreamURL(cV))
-	if err != nil {
+	if skew != "" {
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           "",
+			Message:           skew,
 		}, nil
 	}
 
-	updates, err := cincinnati.NewClient(clusterId, nil, nil).GetUpdates(upstreamURI, runtime.GOARCH, desiredChannel, currentVersion)
+	// Validate the desired version is offered in its channel.
+	desiredChannel := uC.Spec.Desired.Channel
+	updates, err := v.availableUpdates(cV, desiredChannel, currentVersion)
 	if err != nil {
+		if v.cfg.GetAvailableUpdatesMode() == ModeWarn {
+			logger.Info(fmt.Sprintf("Unable to retrieve the available updates in channel %s, proceeding without them: %v", desiredChannel, err))
+			return ValidatorResult{
+				IsValid:           true,
+				IsAvailableUpdate: true,
+				Message:           "UpgradeConfig is valid",
+			}, nil
+		}
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           "",
+			Message:           fmt.Sprintf("Failed to retrieve the available updates in channel %s", desiredChannel),
 		}, err
 	}
 
-	var cvoUpdates []configv1.Update
-	for _, update := range updates {
-		cvoUpdates = append(cvoUpdates, configv1.Update{
-			Version: update.Version.String(),
-			Image:   update.Image,
-		})
-	}
-
 	// Check whether the desired version exists in availableUpdates
 	found := false
-	for _, v := range cvoUpdates {
-		if v.Version == dv && !v.Force {
+	for _, u := range updates {
+		if u.Version == dv && !u.Force {
 			found = true
 		}
 	}
 
 	if !found {
+		if v.cfg.GetAvailableUpdatesMode() == ModeWarn {
+			logger.Info(fmt.Sprintf("Desired version %s is not offered in channel %s, proceeding regardless", desiredVersion, desiredChannel))
+			return ValidatorResult{
+				IsValid:           true,
+				IsAvailableUpdate: true,
+				Message:           "UpgradeConfig is valid",
+			}, nil
+		}
 		logger.Info(fmt.Sprintf("Failed to find the desired version %s in channel %s", desiredVersion, desiredChannel))
 		return ValidatorResult{
 			IsValid:           false,
@@ -184,9 +245,111 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 	}, nil
 }
 
+// availableUpdates returns the updates offered to the cluster in the channel. The cluster's own
+// available updates are used when it is already subscribed to the channel, otherwise the
+// update service is queried.
+func (v *validator) availableUpdates(cV *configv1.ClusterVersion, channel string, currentVersion semver.Version) ([]configv1.Update, error) {
+	if cV.Spec.Channel == channel {
+		return cV.Status.AvailableUpdates, nil
+	}
+	if v.fetchUpdates != nil {
+		return v.fetchUpdates(cV, channel, currentVersion)
+	}
+	return fetchCincinnatiUpdates(cV, channel, currentVersion)
+}
+
+// fetchCincinnatiUpdates queries the cluster's upstream update service for the updates offered in the channel
+func fetchCincinnatiUpdates(cV *configv1.ClusterVersion, channel string, currentVersion semver.Version) ([]configv1.Update, error) {
+	clusterId, err := uuid.Parse(string(cV.Spec.ClusterID))
+	if err != nil {
+		return nil, fmt.Errorf("cluster ID %s is invalid: %v", cV.Spec.ClusterID, err)
+	}
+	upstreamURI, err := url.Parse(getUpstreamURL(cV))
+	if err != nil {
+		return nil, fmt.Errorf("upstream update service is invalid: %v", err)
+	}
+
+	updates, err := cincinnati.NewClient(clusterId, nil, nil).GetUpdates(upstreamURI, runtime.GOARCH, channel, currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var cvoUpdates []configv1.Update
+	for _, update := range updates {
+		cvoUpdates = append(cvoUpdates, configv1.Update{
+			Version: update.Version.String(),
+			Image:   update.Image,
+		})
+	}
+	return cvoUpdates, nil
+}
+
+// checkVersionSkew returns why upgrading the control plane from the current to the desired
+// version would exceed the supported version skew, or an empty string if it would not.
+// Workers are compared by the minor versions of their kubelets, which advance in step with
+// the cluster's minor version.
+func (v *validator) checkVersionSkew(desiredVersion semver.Version, currentVersion semver.Version) (string, error) {
+	if desiredVersion.Major != currentVersion.Major || desiredVersion.Minor > currentVersion.Minor+1 {
+		return fmt.Sprintf("Desired version %s skips a minor version from the current version %s, the control plane can only be upgraded one minor version at a time", desiredVersion, currentVersion), nil
+	}
+
+	nodes := &corev1.NodeList{}
+	err := v.client.List(context.TODO(), nodes)
+	if err != nil {
+		return "", err
+	}
+	var controlPlaneMinor, workerMinor *uint64
+	for _, node := range nodes.Items {
+		kubeletVersion, err := semver.ParseTolerant(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse kubelet version %s of node %s: %v", node.Status.NodeInfo.KubeletVersion, node.Name, err)
+		}
+		minor := kubeletVersion.Minor
+		if _, ok := node.Labels[masterNodeRoleLabel]; ok {
+			if controlPlaneMinor == nil || minor > *controlPlaneMinor {
+				controlPlaneMinor = &minor
+			}
+		} else if workerMinor == nil || minor < *workerMinor {
+			workerMinor = &minor
+		}
+	}
+	if controlPlaneMinor == nil || workerMinor == nil {
+		return "", nil
+	}
+
+	skew := int(*controlPlaneMinor) + int(desiredVersion.Minor) - int(currentVersion.Minor) - int(*workerMinor)
+	maxSkew := v.cfg.GetMaxWorkerSkew(desiredVersion)
+	if skew > maxSkew {
+		return fmt.Sprintf("Upgrading to %s would leave worker nodes %d minor versions behind the control plane, more than the %d supported", desiredVersion, skew, maxSkew), nil
+	}
+	return "", nil
+}
+
+// isScheduled indicates whether the upgrade to the desired version has already been scheduled,
+// in which case its upgradeAt is expected to pass before it commences
+func isScheduled(uC *upgradev1alpha1.UpgradeConfig) bool {
+	history := uC.Status.History.GetHistory(uC.Spec.Desired.Version)
+	return history != nil && history.Phase != upgradev1alpha1.UpgradePhaseNew
+}
+
+// firstObserved returns when the upgrade to the desired version was first observed: when it
+// started, or failing that when the UpgradeConfig was created
+func firstObserved(uC *upgradev1alpha1.UpgradeConfig) time.Time {
+	history := uC.Status.History.GetHistory(uC.Spec.Desired.Version)
+	if history != nil && history.StartTime != nil {
+		return history.StartTime.Time
+	}
+	if !uC.CreationTimestamp.IsZero() {
+		return uC.CreationTimestamp.Time
+	}
+	return time.Now()
+}
+
 // compareVersions accepts desiredVersion and currentVersion strings as versions, converts
 // them to semver and then compares them. Returns an indication of whether the desired
-// version constitutes a downgrade, no-op or upgrade, or an error if no valid comparison can occur
+// version constitutes a downgrade, no-op or upgrade, or an error if no valid comparison can occur.
+// Pre-releases precede their release, so 4.6.0-rc.1 to 4.6.0 is an upgrade, and build
+// metadata is not compared.
 func compareVersions(dV semver.Version, cV semver.Version, logger logr.Logger) (VersionComparison, error) {
 	result := dV.Compare(cV)
 	switch result {
@@ -217,14 +380,19 @@ func getUpstreamURL(cV *configv1.ClusterVersion) string {
 
 //go:generate mockgen -destination=mocks/mockValidationBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/validation ValidationBuilder
 type ValidationBuilder interface {
-	NewClient() (Validator, error)
+	NewClient(c client.Client, cfm configmanager.ConfigManager) (Validator, error)
 }
 
 // validationBuilder is an empty struct that enables instantiation of this type and its
 // implimented interface.
 type validationBuilder struct{}
 
-// NewClient returns a Validator interface or an error if one occurs.
-func (vb *validationBuilder) NewClient() (Validator, error) {
-	return &validator{}, nil
+// NewClient returns a Validator interface configured from the config manager, or an error if one occurs.
+func (vb *validationBuilder) NewClient(c client.Client, cfm configmanager.ConfigManager) (Validator, error) {
+	cfg := &Config{}
+	err := cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &validator{client: c, cfg: *cfg}, nil
 }
@@ -1,19 +1,26 @@
 package validation
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	configv1 "github.com/openshift/api/config/v1"
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -24,6 +31,9 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 	)
 
 	var (
+		mockCtrl              *gomock.Controller
+		mockKubeClient        *mocks.MockClient
+		testNodes             corev1.NodeList
 		testValidator         Validator
 		testUpgradeConfig     *upgradev1alpha1.UpgradeConfig
 		testUpgradeConfigName types.NamespacedName
@@ -31,8 +41,28 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 		testLogger            logr.Logger
 	)
 
+	newNode := func(name string, master bool, kubeletVersion string) corev1.Node {
+		node := corev1.Node{ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+		if master {
+			node.Labels = map[string]string{masterNodeRoleLabel: ""}
+		}
+		node.Status.NodeInfo.KubeletVersion = kubeletVersion
+		return node
+	}
+
 	BeforeEach(func() {
-		testValidator = &validator{}
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		testNodes = corev1.NodeList{Items: []corev1.Node{
+			newNode("master-0", true, "v1.17.1+912792b"),
+			newNode("worker-0", false, "v1.17.1+912792b"),
+		}}
+		mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+				*list.(*corev1.NodeList) = testNodes
+				return nil
+			}).AnyTimes()
+		testValidator = &validator{client: mockKubeClient}
 		testUpgradeConfigName = types.NamespacedName{
 			Name:      "test-upgradeconfig",
 			Namespace: "test-namespace",
@@ -83,6 +113,10 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 		}
 	})
 
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
 	Context("Validating UpgradeAt timestamp", func() {
 		Context("When the UpgradeAt timestamp is NOT RFC3339 format", func() {
 			It("Validation is false and error is returned as NOT nil", func() {
@@ -92,9 +126,91 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
 				Expect(err).Should(BeNil())
 				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("RFC3339"))
+			})
+		})
+		Context("When the UpgradeAt timestamp is in the past", func() {
+			BeforeEach(func() {
+				// Versions which otherwise validate, without reaching the update service
+				testUpgradeConfig.Spec.Desired.Version = "4.4.5"
+				testClusterVersion.Status.History[1].Version = "4.4.5"
+			})
+			It("Validation is true when it is in the future", func() {
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(time.Hour).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+			})
+			It("Validation is true when it is within the grace period", func() {
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-5 * time.Minute).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+			})
+			It("Validation is false when it is beyond the grace period", func() {
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("in the past"))
+			})
+			It("Validation is true when it is beyond the grace period but only a warning is configured", func() {
+				testValidator = &validator{client: mockKubeClient, cfg: Config{Validation: ValidationConfig{PastUpgradeAt: ModeWarn}}}
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+			})
+			It("Validation is true when it was in the future when the UpgradeConfig was created", func() {
+				testUpgradeConfig.CreationTimestamp = v1.Time{Time: time.Now().Add(-3 * time.Hour)}
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+			})
+			It("Validation is false when it was beyond the grace period when the UpgradeConfig was created", func() {
+				testUpgradeConfig.CreationTimestamp = v1.Time{Time: time.Now().Add(-3 * time.Hour)}
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-5 * time.Hour).Format(time.RFC3339)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("in the past"))
+			})
+			It("Validation is true when the upgrade was scheduled before the time passed", func() {
+				testUpgradeConfig.Spec.UpgradeAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+				testUpgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: "4.4.5", Phase: upgradev1alpha1.UpgradePhasePending}}
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
 			})
 		})
 	})
+	Context("Validating PDBForceDrainTimeout", func() {
+		BeforeEach(func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.4.5"
+			testClusterVersion.Status.History[1].Version = "4.4.5"
+		})
+		It("Validation is false when it is below the minimum", func() {
+			testUpgradeConfig.Spec.PDBForceDrainTimeout = 0
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Message).Should(ContainSubstring("between 5 and 480 minutes"))
+		})
+		It("Validation is true when it is within the bounds", func() {
+			testUpgradeConfig.Spec.PDBForceDrainTimeout = 60
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+		})
+		It("Validation is false when it is above the maximum", func() {
+			testUpgradeConfig.Spec.PDBForceDrainTimeout = 481
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Message).Should(ContainSubstring("between 5 and 480 minutes"))
+		})
+	})
 	Context("Validating UpgradeConfig desired version", func() {
 		Context("When getting the current cluster version fails", func() {
 			It("Validation is false and error is returned", func() {
@@ -117,6 +233,15 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 				Expect(result.IsValid).Should(BeFalse())
 			})
 		})
+		Context("When the UpgradeConfig version is missing its patch version", func() {
+			It("Validation is false with a clear message", func() {
+				testUpgradeConfig.Spec.Desired.Version = "4.5"
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("4.5"))
+			})
+		})
 		Context("When the ClusterVersion version is NOT valid", func() {
 			It("Validation is false and error is returned as NOT nil", func() {
 				// Set version as non semver
@@ -128,6 +253,162 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 			})
 		})
 	})
+	Context("Validating the desired version against the current version", func() {
+		BeforeEach(func() {
+			testClusterVersion.Status.History[1].Version = "4.4.5"
+			testUpgradeConfig.Spec.Desired.Channel = "stable-4.4"
+		})
+		Context("When desired version is less than current version", func() {
+			It("Validation is false and the downgrade is explained", func() {
+				testUpgradeConfig.Spec.Desired.Version = "4.4.4"
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.IsAvailableUpdate).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("downgrades are unsupported"))
+			})
+		})
+		Context("When desired version is equal to current version", func() {
+			It("Does not proceed with the upgrade", func() {
+				testUpgradeConfig.Spec.Desired.Version = "4.4.5"
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("nothing to upgrade"))
+			})
+		})
+		Context("When desired version is a pre-release", func() {
+			It("Validation is false outside of a candidate channel", func() {
+				testUpgradeConfig.Spec.Desired.Version = "4.5.0-rc.1"
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("pre-release"))
+			})
+		})
+	})
+	Context("Validating the desired version is offered in its channel", func() {
+		BeforeEach(func() {
+			testClusterVersion.Status.History[1].Version = "4.4.5"
+			testUpgradeConfig.Spec.Desired.Version = "4.4.6"
+			testUpgradeConfig.Spec.Desired.Channel = "stable-4.4"
+			testClusterVersion.Spec.Channel = "stable-4.4"
+		})
+		Context("When the cluster is subscribed to the desired channel", func() {
+			It("Validation is true when the cluster is offered the version", func() {
+				testClusterVersion.Status.AvailableUpdates = []configv1.Update{{Version: "4.4.6"}}
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeTrue())
+			})
+			It("Validation is false when the cluster is not offered the version", func() {
+				testClusterVersion.Status.AvailableUpdates = []configv1.Update{{Version: "4.4.7"}}
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.IsAvailableUpdate).Should(BeFalse())
+				Expect(result.Message).Should(ContainSubstring("4.4.6"))
+			})
+			It("Validation is true when the version is not offered but only a warning is configured", func() {
+				testValidator = &validator{client: mockKubeClient, cfg: Config{Validation: ValidationConfig{AvailableUpdates: ModeWarn}}}
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeTrue())
+			})
+		})
+		Context("When the cluster is subscribed to another channel", func() {
+			var fetchErr error
+			BeforeEach(func() {
+				testClusterVersion.Spec.Channel = "fast-4.4"
+				testClusterVersion.Status.AvailableUpdates = []configv1.Update{{Version: "4.4.6"}}
+				fetchErr = nil
+				testValidator = &validator{
+					client: mockKubeClient,
+					fetchUpdates: func(cV *configv1.ClusterVersion, channel string, currentVersion semver.Version) ([]configv1.Update, error) {
+						Expect(channel).To(Equal("stable-4.4"))
+						if fetchErr != nil {
+							return nil, fetchErr
+						}
+						return []configv1.Update{{Version: "4.4.6"}}, nil
+					},
+				}
+			})
+			It("Validates against the updates offered in the desired channel", func() {
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeTrue())
+			})
+			It("Returns an error when the updates cannot be retrieved", func() {
+				fetchErr = fmt.Errorf("update service unreachable")
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).ShouldNot(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+			})
+			It("Proceeds when the updates cannot be retrieved but only a warning is configured", func() {
+				fetchErr = fmt.Errorf("update service unreachable")
+				testValidator.(*validator).cfg.Validation.AvailableUpdates = ModeWarn
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeTrue())
+			})
+		})
+	})
+	Context("Validating the version skew between the control plane and workers", func() {
+		BeforeEach(func() {
+			testClusterVersion.Status.History[1].Version = "4.4.5"
+			testUpgradeConfig.Spec.Desired.Channel = "stable-4.5"
+			testClusterVersion.Spec.Channel = "stable-4.5"
+			testClusterVersion.Status.AvailableUpdates = []configv1.Update{{Version: "4.4.6"}, {Version: "4.5.1"}, {Version: "4.6.1"}}
+		})
+		It("Validation is true for a minor upgrade when the workers match the control plane", func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.5.1"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+		})
+		It("Validation is true for a patch upgrade when the workers lag the control plane", func() {
+			testNodes.Items = append(testNodes.Items, newNode("worker-1", false, "v1.16.2"))
+			testUpgradeConfig.Spec.Desired.Version = "4.4.6"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+		})
+		It("Validation is false for a minor upgrade when the workers already lag the control plane", func() {
+			testNodes.Items = append(testNodes.Items, newNode("worker-1", false, "v1.16.2"))
+			testUpgradeConfig.Spec.Desired.Version = "4.5.1"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Message).Should(ContainSubstring("2 minor versions behind"))
+		})
+		It("Validation is true when the skew is configured for the desired version", func() {
+			testNodes.Items = append(testNodes.Items, newNode("worker-1", false, "v1.16.2"))
+			testUpgradeConfig.Spec.Desired.Version = "4.5.1"
+			testValidator = &validator{client: mockKubeClient, cfg: Config{Validation: ValidationConfig{VersionSkew: VersionSkewConfig{Versions: map[string]int{"4.5": 2}}}}}
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+		})
+		It("Validation is false when the upgrade skips a minor version", func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.6.1"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Message).Should(ContainSubstring("skips a minor version"))
+		})
+		It("Returns an error when a node's version cannot be parsed", func() {
+			testNodes.Items = append(testNodes.Items, newNode("worker-1", false, "unknown"))
+			testUpgradeConfig.Spec.Desired.Version = "4.5.1"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).ShouldNot(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+		})
+	})
 	Context("Comparing versions", func() {
 		Context("When desired version is less then current version", func() {
 			It("Indicates a downgrade", func() {
@@ -149,6 +430,15 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 				Expect(err).Should(BeNil())
 			})
 		})
+		Context("When desired version is the release of the current pre-release version", func() {
+			It("Indicates an upgrade", func() {
+				desiredVersion, _ := semver.Parse("4.6.0")
+				currentVersion, _ := semver.Parse("4.6.0-rc.4")
+				versionCompare, err := compareVersions(desiredVersion, currentVersion, testLogger)
+				Expect(versionCompare).Should(Equal(VersionUpgrade))
+				Expect(err).Should(BeNil())
+			})
+		})
 		Context("When desired version is greater then current version", func() {
 			It("Returns proceed as true", func() {
 				// Set desired == current